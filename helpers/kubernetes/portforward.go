@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// ProxyPortForward proxies the port-forward request to the portforward subresource of
+// the pod, i.e. the upgraded connection talks directly to the kube API.
+func (c *Cluster) ProxyPortForward(rw http.ResponseWriter, req *http.Request, namespace, podName string) {
+	// https://github.com/kubernetes/kubectl/blob/2acffc93b61e483bd26020df72b9aef64541bd56/pkg/cmd/portforward/portforward.go#L409
+	forwardURL := c.Kubectl.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+
+	httpClient := c.Kubectl.CoreV1().RESTClient().(*rest.RESTClient).Client
+	p := httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL = forwardURL
+			req.Host = forwardURL.Host
+			// let kube authentication work
+			delete(req.Header, "Cookie")
+			delete(req.Header, "Authorization")
+		},
+		Transport:     httpClient.Transport,
+		FlushInterval: time.Millisecond * 100,
+	}
+
+	p.ServeHTTP(rw, req)
+}
@@ -1,8 +1,13 @@
 package helpers
 
 import (
+	"archive/tar"
+	"fmt"
+	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/mholt/archiver/v3"
 	"github.com/pkg/errors"
@@ -41,3 +46,140 @@ func Tar(dir string) (string, string, error) {
 
 	return tmpDir, tarball, nil
 }
+
+// TarTo writes the file or directory at the path as tar stream into the writer. The
+// entries are named after the name, i.e. `name`, and `name/...` for the contents of a
+// directory. Symbolic links are archived as links.
+func TarTo(w io.Writer, path, name string) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(path, file)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(file)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(name, relative))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "can't create archive")
+	}
+
+	return tw.Close()
+}
+
+// UntarFrom extracts the tar stream read from the reader into the directory. The first
+// element of the entry names is replaced by the name, i.e. the top-level file or
+// directory of the stream is extracted as `dir/name`. Entries leaving the directory are
+// rejected, as are symbolic links which are absolute or go up, i.e. could be used to write
+// outside of it.
+func UntarFrom(r io.Reader, dir, name string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "can't read archive")
+		}
+
+		entry := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if entry == "" {
+			continue
+		}
+		pieces := strings.SplitN(entry, "/", 2)
+		pieces[0] = name
+		target := filepath.Join(dir, filepath.FromSlash(strings.Join(pieces, "/")))
+
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %s leaves the destination", header.Name)
+		}
+
+		mode := os.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr) // nolint:gosec // Size of the copy is under the control of the user
+			f.Close()
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if !localLink(header.Linkname) {
+				return fmt.Errorf("archive entry %s links outside the destination, to %s",
+					header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			// Devices, fifos, and the like are skipped.
+		}
+	}
+}
+
+// localLink returns true if the target of the symbolic link is relative, and below the
+// directory of the link.
+func localLink(target string) bool {
+	if target == "" || path.IsAbs(target) || filepath.IsAbs(target) {
+		return false
+	}
+	for _, element := range strings.Split(filepath.ToSlash(target), "/") {
+		if element == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package helpers_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/helpers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tar streams", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+
+		Expect(os.MkdirAll(filepath.Join(dir, "src", "sub"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "src", "a.txt"), []byte("a"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "src", "sub", "b.txt"), []byte("b"), 0600)).To(Succeed())
+	})
+
+	It("round trips a directory under a new name", func() {
+		stream := &bytes.Buffer{}
+		Expect(helpers.TarTo(stream, filepath.Join(dir, "src"), "config")).To(Succeed())
+
+		dest := filepath.Join(dir, "dest")
+		Expect(helpers.UntarFrom(stream, dest, "copy")).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(dest, "copy", "a.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("a"))
+
+		info, err := os.Stat(filepath.Join(dest, "copy", "sub", "b.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("round trips a single file", func() {
+		stream := &bytes.Buffer{}
+		Expect(helpers.TarTo(stream, filepath.Join(dir, "src", "a.txt"), "a.txt")).To(Succeed())
+		Expect(helpers.UntarFrom(stream, dir, "renamed.txt")).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(dir, "renamed.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("a"))
+	})
+
+	It("rejects symbolic links leaving the destination", func() {
+		for _, link := range []string{dir, "../..", "sub/../../.."} {
+			stream := &bytes.Buffer{}
+			tw := tar.NewWriter(stream)
+			Expect(tw.WriteHeader(&tar.Header{
+				Name:     "name/l",
+				Typeflag: tar.TypeSymlink,
+				Linkname: link,
+			})).To(Succeed())
+			Expect(tw.WriteHeader(&tar.Header{
+				Name:     "name/l/escaped.txt",
+				Typeflag: tar.TypeReg,
+				Mode:     0644,
+			})).To(Succeed())
+			Expect(tw.Close()).To(Succeed())
+
+			dest := filepath.Join(dir, "dest")
+			err := helpers.UntarFrom(stream, dest, "copy")
+			Expect(err).To(MatchError(ContainSubstring("links outside the destination")), link)
+			Expect(filepath.Join(dir, "escaped.txt")).ToNot(BeAnExistingFile())
+		}
+	})
+
+	It("keeps symbolic links within the destination", func() {
+		Expect(os.Symlink("sub/b.txt", filepath.Join(dir, "src", "b-link"))).To(Succeed())
+
+		stream := &bytes.Buffer{}
+		Expect(helpers.TarTo(stream, filepath.Join(dir, "src"), "config")).To(Succeed())
+
+		dest := filepath.Join(dir, "dest")
+		Expect(helpers.UntarFrom(stream, dest, "copy")).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(dest, "copy", "b-link"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("b"))
+	})
+})
@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// CatalogServiceAdd handles the API endpoint POST /admin/catalogservices
+// It adds a service to the catalog, after checking that its chart can be pulled.
+func (hc Controller) CatalogServiceAdd(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	var req models.CatalogServiceRequest
+	err := c.BindJSON(&req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	entry, catalogService, err := services.CatalogServiceEntry(req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	log.Info("add catalog service", "name", catalogService.Meta.Name)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, err = kubeServiceClient.GetCatalogService(ctx, catalogService.Meta.Name)
+	if err == nil {
+		return apierror.NewConflictError("catalog service", catalogService.Meta.Name)
+	}
+	if !k8serrors.IsNotFound(errors.Cause(err)) {
+		return apierror.InternalError(err)
+	}
+
+	apiErr := resolveChart(c, cluster, catalogService)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	err = kubeServiceClient.CreateCatalogService(ctx, entry)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// CatalogServiceUpdate handles the API endpoint PUT /admin/catalogservices/:catalogservice
+// It replaces the catalog service, after checking that its chart can be pulled. Existing
+// service instances are not changed.
+func (hc Controller) CatalogServiceUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	name := c.Param("catalogservice")
+
+	var req models.CatalogServiceRequest
+	err := c.BindJSON(&req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	entry, catalogService, err := services.CatalogServiceEntry(req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+	if catalogService.Meta.Name != name {
+		return apierror.NewBadRequestErrorf("the catalog service %s cannot be renamed to %s",
+			name, catalogService.Meta.Name)
+	}
+
+	log.Info("update catalog service", "name", name)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, err = kubeServiceClient.GetCatalogService(ctx, name)
+	if err != nil {
+		if k8serrors.IsNotFound(errors.Cause(err)) {
+			return apierror.NewNotFoundError("catalog service", name)
+		}
+		return apierror.InternalError(err)
+	}
+
+	apiErr := resolveChart(c, cluster, catalogService)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	err = kubeServiceClient.UpdateCatalogService(ctx, entry)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// CatalogServiceDelete handles the API endpoint DELETE /admin/catalogservices/:catalogservice
+// It removes the catalog service. Catalog services still used by service instances are
+// not removed.
+func (hc Controller) CatalogServiceDelete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	name := c.Param("catalogservice")
+
+	log.Info("delete catalog service", "name", name)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, err = kubeServiceClient.GetCatalogService(ctx, name)
+	if err != nil {
+		if k8serrors.IsNotFound(errors.Cause(err)) {
+			return apierror.NewNotFoundError("catalog service", name)
+		}
+		return apierror.InternalError(err)
+	}
+
+	instances, err := kubeServiceClient.ListAll(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	users := []string{}
+	for _, instance := range instances {
+		if instance.CatalogService == name {
+			users = append(users, instance.Meta.Namespace+"/"+instance.Meta.Name)
+		}
+	}
+	if len(users) > 0 {
+		return apierror.NewBadRequestErrorf("catalog service %s is still used", name).
+			WithDetailsf("used by the services %s", strings.Join(users, ", "))
+	}
+
+	err = kubeServiceClient.DeleteCatalogService(ctx, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// resolveChart checks that the chart of the catalog service can be pulled.
+func resolveChart(c *gin.Context, cluster *kubernetes.Cluster, catalogService *models.CatalogService) apierror.APIErrors {
+	log := requestctx.Logger(c.Request.Context())
+
+	err := helm.ResolveServiceChart(log, cluster, catalogService.HelmRepo.URL,
+		catalogService.HelmChart, catalogService.ChartVersion)
+	if err != nil {
+		return apierror.NewBadRequestError("the chart of the catalog service cannot be resolved").
+			WithDetails(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,7 @@
+// Package admin contains the API handlers for the maintenance of an Epinio installation.
+// The endpoints are restricted to admins.
+package admin
+
+// Controller represents all functionality of the API related to maintenance
+type Controller struct {
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/gc"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ImageGC handles the API endpoint POST /admin/gc/images
+// It garbage collects the application images in the Epinio registry, and reports the
+// deleted images. A dry run only reports the images to delete.
+func (hc Controller) ImageGC(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	var req models.ImageGCRequest
+	err := c.BindJSON(&req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if req.Keep < 0 {
+		return apierror.NewBadRequestError("the number of images to keep must not be negative")
+	}
+	if req.Keep == 0 {
+		req.Keep = viper.GetInt("image-gc-keep")
+	}
+
+	log.Info("image gc", "keep", req.Keep, "dryrun", req.DryRun)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result, err := gc.Images(ctx, cluster, req.Keep, req.DryRun)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
+
+// BlobPrune handles the API endpoint POST /admin/gc/blobs
+// It prunes the orphaned application source blobs from the S3 storage, and reports the
+// deleted blobs. A dry run only reports the blobs to delete.
+func (hc Controller) BlobPrune(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	var req models.BlobPruneRequest
+	err := c.BindJSON(&req)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	olderThan := viper.GetDuration("blob-prune-age")
+	if req.OlderThan != "" {
+		olderThan, err = time.ParseDuration(req.OlderThan)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+		if olderThan < 0 {
+			return apierror.NewBadRequestError("the age of the blobs to prune must not be negative")
+		}
+	}
+
+	log.Info("blob prune", "olderthan", olderThan, "dryrun", req.DryRun)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result, err := gc.Blobs(ctx, cluster, olderThan, req.DryRun)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
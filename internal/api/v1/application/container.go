@@ -0,0 +1,137 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// registryClient is used to resolve the tags of container images to digests.
+var registryClient = &http.Client{Timeout: 30 * time.Second}
+
+// pinContainerImage resolves the tag of the container image to a digest, using the pull
+// credentials of the namespace. It returns the image referenced by digest, and the digest.
+// Failure to resolve is not fatal. The image is returned unchanged, without digest. The
+// registry may be reachable by the cluster nodes, yet not by Epinio.
+func pinContainerImage(ctx context.Context, cluster *kubernetes.Cluster, namespace, imageURL string) (string, string) {
+	log := requestctx.Logger(ctx)
+
+	digest, err := resolveContainerImage(ctx, cluster, namespace, imageURL)
+	if err != nil {
+		log.Info("container image not pinned", "image", imageURL, "error", err.Error())
+		return imageURL, ""
+	}
+
+	pinned, err := registry.PinnedImage(imageURL, digest)
+	if err != nil {
+		log.Info("container image not pinned", "image", imageURL, "error", err.Error())
+		return imageURL, ""
+	}
+
+	return pinned, digest
+}
+
+// resolveContainerImage returns the digest the tag of the container image currently
+// refers to.
+func resolveContainerImage(ctx context.Context, cluster *kubernetes.Cluster, namespace, imageURL string) (string, error) {
+	details, err := registry.PullCredentials(ctx, cluster, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	registryURL, _, err := registry.ExtractImageParts(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	return registry.ResolveDigest(ctx, registryClient, imageURL, details.Lookup(registryURL))
+}
+
+// WatchContainerImages periodically checks the tags of the container images of all
+// applications tracking them, and redeploys the applications whose tag moved to a
+// different image. It returns when the context is canceled.
+func WatchContainerImages(ctx context.Context, logger logr.Logger, interval time.Duration) {
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkContainerImages(ctx)
+		}
+	}
+}
+
+// checkContainerImages is the helper for WatchContainerImages performing a single check of
+// all applications.
+func checkContainerImages(ctx context.Context) {
+	log := requestctx.Logger(ctx)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		log.Error(err, "container image check, no cluster access")
+		return
+	}
+
+	client, err := cluster.ClientApp()
+	if err != nil {
+		log.Error(err, "container image check, no application client")
+		return
+	}
+
+	list, err := client.Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error(err, "container image check, listing applications")
+		return
+	}
+
+	for i := range list.Items {
+		app := &list.Items[i]
+		if app.GetAnnotations()[models.EpinioContainerTrackAnnotation] != "true" {
+			continue
+		}
+
+		appRef := models.NewAppRef(app.GetName(), app.GetNamespace())
+
+		origin, err := application.Origin(app)
+		if err != nil || origin.Kind != models.OriginContainer {
+			continue
+		}
+
+		digest, err := resolveContainerImage(ctx, cluster, appRef.Namespace, origin.Container)
+		if err != nil {
+			log.Info("container image check failed", "namespace", appRef.Namespace, "app", appRef.Name,
+				"image", origin.Container, "error", err.Error())
+			continue
+		}
+		if digest == origin.Digest {
+			continue
+		}
+
+		log.Info("container image changed, redeploying", "namespace", appRef.Namespace, "app", appRef.Name,
+			"image", origin.Container, "digest", digest)
+
+		_, apierr := deployApp(ctx, cluster, models.DeployRequest{
+			App:      appRef,
+			ImageURL: origin.Container,
+			Origin:   origin,
+		}, app.GetAnnotations()[models.EpinioCreatedByAnnotation])
+		if apierr != nil {
+			log.Info("container image redeploy failed", "namespace", appRef.Namespace, "app", appRef.Name,
+				"errors", apierr.Errors())
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/internal/domain"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/routes"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -45,6 +46,21 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppAlreadyKnown(createRequest.Name)
 	}
 
+	// Enforce the quota of the namespace, if any.
+	quota, err := namespaces.Quota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if quota != nil && quota.MaxApps > 0 {
+		appRefs, err := application.ListAppRefs(ctx, cluster, namespace)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		if len(appRefs) >= quota.MaxApps {
+			return apierror.NamespaceQuotaExceeded(namespace, "applications", quota.MaxApps)
+		}
+	}
+
 	// Sanity check the configurations, if any. IOW anything to be bound
 	// has to exist now.  We will check again when the application
 	// is deployed, to guard against bound configurations being removed
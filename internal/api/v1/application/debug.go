@@ -0,0 +1,115 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/duration"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/gin-gonic/gin"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// Debug adds an ephemeral container running the debug image to an instance of the
+// application and attaches to it. The container shares the process namespace of the
+// application container. Images built by buildpacks often have no shell or tools, making
+// Exec of little use.
+func (hc Controller) Debug(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	instanceName := c.Query("instance")
+
+	image := c.Query("image")
+	if image == "" {
+		image = application.DefaultDebugImage
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	clientSetHTTP1, err := kubernetes.GetHTTP1Client(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	// app exists but has no workload to connect to
+	if app.Workload == nil {
+		return apierror.NewAPIError("Cannot connect to application without workload", http.StatusBadRequest)
+	}
+
+	workload := application.NewWorkload(cluster, app.Meta, app.Workload.DesiredReplicas)
+	podNames, err := workload.PodNames(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if len(podNames) < 1 {
+		return apierror.NewAPIError("couldn't find any Instances to connect to", http.StatusBadRequest)
+	}
+
+	podToConnect := ""
+	if instanceName != "" {
+		for _, podName := range podNames {
+			if podName == instanceName {
+				podToConnect = podName
+				break
+			}
+		}
+
+		if podToConnect == "" {
+			return apierror.NewAPIError("specified instance doesn't exist", http.StatusBadRequest)
+		}
+	} else {
+		podToConnect = podNames[0]
+	}
+
+	appData, err := workload.Get(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	pods := cluster.Kubectl.CoreV1().Pods(namespace)
+
+	container, err := application.AddDebugContainer(ctx, pods, podToConnect, appData.Name, image)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.WaitForDebugContainer(ctx, pods, podToConnect, container, duration.ToDeployment())
+	if err != nil {
+		return apierror.NewAPIError(err.Error(), http.StatusBadRequest)
+	}
+
+	// https://github.com/kubernetes/kubectl/blob/2acffc93b61e483bd26020df72b9aef64541bd56/pkg/cmd/attach/attach.go#L282
+	attachURL := clientSetHTTP1.CoreV1().RESTClient().
+		Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(podToConnect).
+		SubResource("attach").
+		VersionedParams(&v1.PodAttachOptions{
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    false, // Merged into stdout by the TTY.
+			TTY:       true,
+			Container: container,
+		}, scheme.ParameterCodec).URL()
+
+	proxyTo(c.Writer, c.Request, attachURL, clientSetHTTP1)
+
+	return nil
+}
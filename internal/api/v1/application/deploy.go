@@ -1,6 +1,8 @@
 package application
 
 import (
+	"context"
+
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -46,40 +48,52 @@ func (hc Controller) Deploy(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to get access to a kube client")
 	}
 
+	routes, apierr := deployApp(ctx, cluster, req, username)
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OKReturn(c, models.DeployResponse{
+		Routes: routes,
+	})
+	return nil
+}
+
+// deployApp deploys the image of the request for the application, and returns the
+// application's routes.
+func deployApp(ctx context.Context, cluster *kubernetes.Cluster, req models.DeployRequest, username string) ([]string, apierror.APIErrors) {
 	applicationCR, err := application.Get(ctx, cluster, req.App)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return apierror.AppIsNotKnown("cannot deploy app, application resource is missing")
+			return nil, apierror.AppIsNotKnown("cannot deploy app, application resource is missing")
 		}
-		return apierror.InternalError(err, "failed to get the application resource")
+		return nil, apierror.InternalError(err, "failed to get the application resource")
+	}
+
+	// Container images are deployed by digest, making the deployment independent of later
+	// changes to the tag. The digest is recorded in the origin.
+	if req.Origin.Kind == models.OriginContainer {
+		req.ImageURL, req.Origin.Digest = pinContainerImage(ctx, cluster, req.App.Namespace, req.ImageURL)
 	}
 
 	err = deploy.UpdateImageURL(ctx, cluster, applicationCR, req.ImageURL)
 	if err != nil {
-		return apierror.InternalError(err, "failed to set application's image url")
+		return nil, apierror.InternalError(err, "failed to set application's image url")
 	}
 
 	desiredRoutes, found, err := unstructured.NestedStringSlice(applicationCR.Object, "spec", "routes")
 	if err != nil {
-		return apierror.InternalError(err, "failed to get the application routes")
+		return nil, apierror.InternalError(err, "failed to get the application routes")
 	}
 	if !found {
 		// [NO-ROUTES] See other places bearing this marker for explanations.
 		desiredRoutes = []string{}
 	}
 
-	apierr := validateRoutes(ctx, cluster, name, namespace, desiredRoutes)
+	apierr := validateRoutes(ctx, cluster, req.App.Name, req.App.Namespace, desiredRoutes)
 	if apierr != nil {
-		return apierr
+		return nil, apierr
 	}
 
-	routes, apierr := deploy.DeployApp(ctx, cluster, req.App, username, req.Stage.ID, &req.Origin, nil)
-	if apierr != nil {
-		return apierr
-	}
-
-	response.OKReturn(c, models.DeployResponse{
-		Routes: routes,
-	})
-	return nil
+	return deploy.DeployApp(ctx, cluster, req.App, username, req.Stage.ID, &req.Origin, nil)
 }
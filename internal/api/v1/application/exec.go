@@ -3,6 +3,7 @@ package application
 import (
 	"net/http"
 	"net/http/httputil"
+	"net/url"
 	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -21,6 +22,21 @@ func (hc Controller) Exec(c *gin.Context) apierror.APIErrors {
 	appName := c.Param("app")
 	instanceName := c.Query("instance")
 
+	// Without command a shell is opened, with a TTY and stdin.
+	command := c.QueryArray("command")
+	tty := len(command) == 0
+	if c.Query("tty") != "" {
+		tty = c.Query("tty") == "true"
+	}
+	stdin := len(command) == 0
+	if c.Query("stdin") != "" {
+		stdin = c.Query("stdin") == "true"
+	}
+	if len(command) == 0 {
+		// https://github.com/rancher/dashboard/blob/37f40d7213ff32096bfefd02de77be6a0e7f40ab/components/nav/WindowManager/ContainerShell.vue#L22
+		command = []string{"/bin/sh", "-c", "TERM=xterm-256color; export TERM; exec /bin/bash"}
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -76,12 +92,13 @@ func (hc Controller) Exec(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	proxyRequest(c.Writer, c.Request, podToConnect, namespace, appData.Name, clientSetHTTP1)
+	proxyRequest(c.Writer, c.Request, podToConnect, namespace, appData.Name, command, stdin, tty, clientSetHTTP1)
 
 	return nil
 }
 
-func proxyRequest(rw http.ResponseWriter, req *http.Request, podName, namespace, container string, client thekubernetes.Interface) {
+func proxyRequest(rw http.ResponseWriter, req *http.Request, podName, namespace, container string,
+	command []string, stdin, tty bool, client thekubernetes.Interface) {
 	// https://github.com/kubernetes/kubectl/blob/2acffc93b61e483bd26020df72b9aef64541bd56/pkg/cmd/exec/exec.go#L352
 	attachURL := client.CoreV1().RESTClient().
 		Post().
@@ -90,20 +107,25 @@ func proxyRequest(rw http.ResponseWriter, req *http.Request, podName, namespace,
 		Name(podName).
 		SubResource("exec").
 		VersionedParams(&v1.PodExecOptions{
-			Stdin:     true,
+			Stdin:     stdin,
 			Stdout:    true,
 			Stderr:    true,
-			TTY:       true,
+			TTY:       tty,
 			Container: container,
-			// https://github.com/rancher/dashboard/blob/37f40d7213ff32096bfefd02de77be6a0e7f40ab/components/nav/WindowManager/ContainerShell.vue#L22
-			Command: []string{"/bin/sh", "-c", "TERM=xterm-256color; export TERM; exec /bin/bash"},
+			Command:   command,
 		}, scheme.ParameterCodec).URL()
 
+	proxyTo(rw, req, attachURL, client)
+}
+
+// proxyTo proxies the streaming request to the URL of the kube API, e.g. of the exec or
+// attach subresource of a pod.
+func proxyTo(rw http.ResponseWriter, req *http.Request, target *url.URL, client thekubernetes.Interface) {
 	httpClient := client.CoreV1().RESTClient().(*rest.RESTClient).Client
 	p := httputil.ReverseProxy{
 		Director: func(req *http.Request) {
-			req.URL = attachURL
-			req.Host = attachURL.Host
+			req.URL = target
+			req.Host = target.Host
 			// let kube authentication work
 			delete(req.Header, "Cookie")
 			delete(req.Header, "Authorization")
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/configurations"
+)
+
+// Keys recognized in a configuration holding git credentials.
+//
+// For HTTPS access either `token`, or `username` and `password` are used. For SSH access
+// `ssh-privatekey` is used, with optional `ssh-passphrase`, and `known_hosts` to verify
+// the key of the git host against. Without `known_hosts` access is refused, unless
+// `insecure-skip-host-key` is `true`. The `username` defaults to `git` for SSH access.
+const (
+	GitCredentialUsername   = "username"
+	GitCredentialPassword   = "password"
+	GitCredentialToken      = "token"
+	GitCredentialPrivateKey = "ssh-privatekey"
+	GitCredentialPassphrase = "ssh-passphrase"
+	GitCredentialKnownHosts = "known_hosts"
+	GitCredentialInsecure   = "insecure-skip-host-key"
+)
+
+// gitAuth returns the git authentication method described by the named configuration in
+// the namespace. No configuration (empty name) means anonymous access, and no
+// authentication method.
+func gitAuth(ctx context.Context, cluster *kubernetes.Cluster, namespace, credentials string) (transport.AuthMethod, error) {
+	if credentials == "" {
+		return nil, nil
+	}
+
+	configuration, err := configurations.Lookup(ctx, cluster, namespace, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := configuration.Details(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitAuthFrom(credentials, data)
+}
+
+// gitAuthFrom returns the git authentication method described by the data of the named
+// configuration.
+func gitAuthFrom(credentials string, data map[string]string) (transport.AuthMethod, error) {
+	if key, ok := data[GitCredentialPrivateKey]; ok {
+		username := data[GitCredentialUsername]
+		if username == "" {
+			username = "git"
+		}
+
+		auth, err := gitssh.NewPublicKeys(username, []byte(key), data[GitCredentialPassphrase])
+		if err != nil {
+			return nil, errors.Wrap(err, "bad ssh private key")
+		}
+
+		knownHosts, ok := data[GitCredentialKnownHosts]
+		if !ok {
+			if data[GitCredentialInsecure] != "true" {
+				return nil, errors.Errorf("the ssh credentials of configuration %s lack the %s of the git host, add them, or set %s to true",
+					credentials, GitCredentialKnownHosts, GitCredentialInsecure)
+			}
+			// nolint:gosec // Host key verification was explicitly disabled, see insecure-skip-host-key
+			auth.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+			return auth, nil
+		}
+
+		callback, err := knownHostsCallback(knownHosts)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad known hosts")
+		}
+		auth.HostKeyCallback = callback
+		return auth, nil
+	}
+
+	if token, ok := data[GitCredentialToken]; ok {
+		// Git hosting services ignore the user name when a token is used, but
+		// require it to be not empty.
+		username := data[GitCredentialUsername]
+		if username == "" {
+			username = "git"
+		}
+		return &githttp.BasicAuth{Username: username, Password: token}, nil
+	}
+
+	if password, ok := data[GitCredentialPassword]; ok {
+		return &githttp.BasicAuth{Username: data[GitCredentialUsername], Password: password}, nil
+	}
+
+	return nil, errors.New("configuration contains no git credentials")
+}
+
+// knownHostsCallback returns a host key callback checking against the given known hosts.
+// The underlying library reads them from files only, thus a temporary file is used.
+func knownHostsCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	file, err := os.CreateTemp("", "epinio-known-hosts")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(knownHosts)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The file is read completely by the constructor. Removing it afterward is fine.
+	return gitssh.NewKnownHostsCallback(file.Name())
+}
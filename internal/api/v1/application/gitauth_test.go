@@ -0,0 +1,39 @@
+package application
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Application git credentials unit tests", func() {
+	var privateKey string
+
+	BeforeEach(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+		privateKey = string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}))
+	})
+
+	It("refuses ssh keys without known hosts", func() {
+		_, err := gitAuthFrom("creds", map[string]string{GitCredentialPrivateKey: privateKey})
+		Expect(err).To(MatchError(ContainSubstring("lack the known_hosts")))
+	})
+
+	It("skips the host key verification only on request", func() {
+		auth, err := gitAuthFrom("creds", map[string]string{
+			GitCredentialPrivateKey: privateKey,
+			GitCredentialInsecure:   "true",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(auth.(*gitssh.PublicKeys).User).To(Equal("git"))
+	})
+})
@@ -67,6 +67,7 @@ import (
 	"github.com/gin-gonic/gin"
 	git "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-logr/logr"
 
 	"github.com/epinio/epinio/helpers"
@@ -81,28 +82,63 @@ import (
 
 // ImportGit handles the API endpoint /namespaces/:namespace/applications/:app/import-git.
 // It receives a Git repo url and revision, clones that (shallow clone), creates a tarball
-// of the repo and puts it on S3.
+// of the repo and puts it on S3. Optionally it receives the name of a configuration
+// holding credentials for the repository, and a flag requesting that submodules are
+// cloned as well.
 func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	log := requestctx.Logger(ctx)
+	username := requestctx.User(ctx).Username
 
 	namespace := c.Param("namespace")
 	name := c.Param("app")
 
-	url := c.PostForm("giturl")
-	revision := c.PostForm("gitrev")
+	gitRef := models.GitRef{
+		URL:         c.PostForm("giturl"),
+		Revision:    c.PostForm("gitrev"),
+		Credentials: c.PostForm("gitcredentials"),
+		Submodules:  c.PostForm("gitsubmodules") == "true",
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err, "failed to get access to a kube client")
+	}
+
+	blobUID, apierr := importGit(ctx, log, cluster, models.NewAppRef(name, namespace), gitRef, username)
+	if apierr != nil {
+		return apierr
+	}
+
+	// Return the id of the new blob
+	response.OKReturn(c, models.ImportGitResponse{
+		BlobUID: blobUID,
+	})
+	return nil
+}
+
+// importGit clones the referenced repository, creates a tarball of it and puts it on
+// S3. The id of the uploaded blob is returned.
+func importGit(ctx context.Context, log logr.Logger, cluster *kubernetes.Cluster,
+	app models.AppRef, gitRef models.GitRef, username string) (string, apierror.APIErrors) {
+
+	auth, err := gitAuth(ctx, cluster, app.Namespace, gitRef.Credentials)
+	if err != nil {
+		return "", apierror.NewBadRequestError(err.Error()).
+			WithDetailsf("git credentials '%s'", gitRef.Credentials)
+	}
 
 	gitRepo, err := os.MkdirTemp("", "epinio-app")
 	if err != nil {
-		return apierror.InternalError(err, "can't create temp directory")
+		return "", apierror.InternalError(err, "can't create temp directory")
 	}
 	defer os.RemoveAll(gitRepo)
 
 	// clone/fetch/checkout
-	err = getRepository(ctx, log, gitRepo, url, revision)
+	err = getRepository(ctx, log, gitRepo, gitRef, auth)
 	if err != nil {
-		return apierror.InternalError(err,
-			fmt.Sprintf("cloning the git repository: %s @ %s", url, revision))
+		return "", apierror.InternalError(err,
+			fmt.Sprintf("cloning the git repository: %s @ %s", gitRef.URL, gitRef.Revision))
 	}
 
 	// Create a tarball
@@ -113,51 +149,50 @@ func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 		}
 	}()
 	if err != nil {
-		return apierror.InternalError(err, "create a tarball from the git repository")
+		return "", apierror.InternalError(err, "create a tarball from the git repository")
 	}
 
 	// Upload to S3
-	cluster, err := kubernetes.GetCluster(ctx)
-	if err != nil {
-		return apierror.InternalError(err, "failed to get access to a kube client")
-	}
 	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), "epinio-s3-connection-details")
 	if err != nil {
-		return apierror.InternalError(err, "fetching the S3 connection details from the Kubernetes secret")
+		return "", apierror.InternalError(err, "fetching the S3 connection details from the Kubernetes secret")
 	}
 	manager, err := s3manager.New(connectionDetails)
 	if err != nil {
-		return apierror.InternalError(err, "creating an S3 manager")
+		return "", apierror.InternalError(err, "creating an S3 manager")
 	}
 
-	username := requestctx.User(ctx).Username
 	blobUID, err := manager.Upload(ctx, tarball, map[string]string{
-		"app": name, "namespace": namespace, "username": username,
+		"app": app.Name, "namespace": app.Namespace, "username": username,
 	})
 	if err != nil {
-		return apierror.InternalError(err, "uploading the application sources blob")
+		return "", apierror.InternalError(err, "uploading the application sources blob")
 	}
-	log.Info("uploaded app", "namespace", namespace, "app", name, "blobUID", blobUID)
+	log.Info("uploaded app", "namespace", app.Namespace, "app", app.Name, "blobUID", blobUID)
 
-	// Return the id of the new blob
-	response.OKReturn(c, models.ImportGitResponse{
-		BlobUID: blobUID,
-	})
-	return nil
+	return blobUID, nil
 }
 
-func getRepository(ctx context.Context, log logr.Logger, gitRepo, url, revision string) error {
+func getRepository(ctx context.Context, log logr.Logger, gitRepo string, gitRef models.GitRef, auth transport.AuthMethod) error {
+	url := gitRef.URL
+	revision := gitRef.Revision
+
+	submodules := git.NoRecurseSubmodules
+	if gitRef.Submodules {
+		submodules = git.DefaultSubmoduleRecursionDepth
+	}
+
 	if revision == "" {
 		// Input A: repository, no revision.
 		log.Info("importgit, cloning simple", "url", url)
-		_, err := shallowClone(ctx, gitRepo, url)
+		_, err := shallowClone(ctx, gitRepo, url, auth, submodules)
 		return err
 	}
 
 	// Input B or C: Attempt to treat as B (revision is branch name)
 
 	log.Info("importgit, cloning branch", "url", url, "revision", revision)
-	_, err := branchClone(ctx, gitRepo, url, revision)
+	_, err := branchClone(ctx, gitRepo, url, revision, auth, submodules)
 	if err == nil {
 		// Was branch name, done.
 		return nil
@@ -171,7 +206,7 @@ func getRepository(ctx context.Context, log logr.Logger, gitRepo, url, revision
 	// 2 stage process - A simple clone followed by a checkout
 
 	log.Info("importgit, cloning simple, commit id", "url", url)
-	repository, err := generalClone(ctx, gitRepo, url)
+	repository, err := generalClone(ctx, gitRepo, url, auth)
 	if err != nil {
 		return err
 	}
@@ -191,31 +226,54 @@ func getRepository(ctx context.Context, log logr.Logger, gitRepo, url, revision
 
 	log.Info("importgit, checking out", "url", url, "revision", hash)
 
-	return checkout.Checkout(&git.CheckoutOptions{
+	err = checkout.Checkout(&git.CheckoutOptions{
 		Hash:  *hash,
 		Force: true,
 	})
+	if err != nil || !gitRef.Submodules {
+		return err
+	}
+
+	// The submodules are updated only after the checkout, to match the chosen commit.
+
+	log.Info("importgit, updating submodules", "url", url, "revision", hash)
+
+	modules, err := checkout.Submodules()
+	if err != nil {
+		return err
+	}
+
+	return modules.UpdateContext(ctx, &git.SubmoduleUpdateOptions{
+		Init:              true,
+		RecurseSubmodules: submodules,
+		Auth:              auth,
+	})
 }
 
-func branchClone(ctx context.Context, gitRepo, url, revision string) (*git.Repository, error) {
+func branchClone(ctx context.Context, gitRepo, url, revision string, auth transport.AuthMethod, submodules git.SubmoduleRescursivity) (*git.Repository, error) {
 	// Note, it is shallow too
 	return git.PlainCloneContext(ctx, gitRepo, false, &git.CloneOptions{
-		URL:           url,
-		SingleBranch:  true,
-		ReferenceName: plumbing.NewBranchReferenceName(revision),
-		Depth:         1,
+		URL:               url,
+		Auth:              auth,
+		SingleBranch:      true,
+		ReferenceName:     plumbing.NewBranchReferenceName(revision),
+		Depth:             1,
+		RecurseSubmodules: submodules,
 	})
 }
 
-func shallowClone(ctx context.Context, gitRepo, url string) (*git.Repository, error) {
+func shallowClone(ctx context.Context, gitRepo, url string, auth transport.AuthMethod, submodules git.SubmoduleRescursivity) (*git.Repository, error) {
 	return git.PlainCloneContext(ctx, gitRepo, false, &git.CloneOptions{
-		URL:   url,
-		Depth: 1,
+		URL:               url,
+		Auth:              auth,
+		Depth:             1,
+		RecurseSubmodules: submodules,
 	})
 }
 
-func generalClone(ctx context.Context, gitRepo, url string) (*git.Repository, error) {
+func generalClone(ctx context.Context, gitRepo, url string, auth transport.AuthMethod) (*git.Repository, error) {
 	return git.PlainCloneContext(ctx, gitRepo, false, &git.CloneOptions{
-		URL: url,
+		URL:  url,
+		Auth: auth,
 	})
 }
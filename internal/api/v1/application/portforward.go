@@ -2,15 +2,11 @@ package application
 
 import (
 	"net/http"
-	"net/http/httputil"
-	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/application"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/gin-gonic/gin"
-	thekubernetes "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 )
 
 func (hc Controller) PortForward(c *gin.Context) apierror.APIErrors {
@@ -64,33 +60,7 @@ func (hc Controller) PortForward(c *gin.Context) apierror.APIErrors {
 		podToConnect = podNames[0]
 	}
 
-	forwardRequest(c.Writer, c.Request, podToConnect, namespace, cluster.Kubectl)
+	cluster.ProxyPortForward(c.Writer, c.Request, namespace, podToConnect)
 
 	return nil
 }
-
-func forwardRequest(rw http.ResponseWriter, req *http.Request, podName, namespace string, client thekubernetes.Interface) {
-	// https://github.com/kubernetes/kubectl/blob/2acffc93b61e483bd26020df72b9aef64541bd56/pkg/cmd/portforward/portforward.go#L409
-	forwardURL := client.CoreV1().RESTClient().
-		Post().
-		Resource("pods").
-		Namespace(namespace).
-		Name(podName).
-		SubResource("portforward").
-		URL()
-
-	httpClient := client.CoreV1().RESTClient().(*rest.RESTClient).Client
-	p := httputil.ReverseProxy{
-		Director: func(req *http.Request) {
-			req.URL = forwardURL
-			req.Host = forwardURL.Host
-			// let kube authentication work
-			delete(req.Header, "Cookie")
-			delete(req.Header, "Authorization")
-		},
-		Transport:     httpClient.Transport,
-		FlushInterval: time.Millisecond * 100,
-	}
-
-	p.ServeHTTP(rw, req)
-}
@@ -3,6 +3,9 @@ package application
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -38,6 +41,7 @@ type stageParam struct {
 	DownloadImage       string
 	UnpackImage         string
 	Environment         models.EnvVariableList
+	BuildEnvironment    models.EnvVariableList
 	Owner               metav1.OwnerReference
 	RegistryURL         string
 	S3ConnectionDetails s3manager.ConnectionDetails
@@ -94,7 +98,6 @@ func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, ar models.AppRe
 // It creates a Job resource to stage the app
 func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
-	log := requestctx.Logger(ctx)
 
 	namespace := c.Param("namespace")
 	name := c.Param("app")
@@ -116,25 +119,38 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to get access to a kube client")
 	}
 
+	stageResponse, apierr := stage(ctx, cluster, req, username)
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OKReturn(c, stageResponse)
+	return nil
+}
+
+// stage creates the Job resource staging the app described by the request.
+func stage(ctx context.Context, cluster *kubernetes.Cluster, req models.StageRequest, username string) (*models.StageResponse, apierror.APIErrors) {
+	log := requestctx.Logger(ctx)
+
 	// check application resource
 	app, err := application.Get(ctx, cluster, req.App)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return apierror.AppIsNotKnown("cannot stage app, application resource is missing")
+			return nil, apierror.AppIsNotKnown("cannot stage app, application resource is missing")
 		}
-		return apierror.InternalError(err, "failed to get the application resource")
+		return nil, apierror.InternalError(err, "failed to get the application resource")
 	}
 
 	config, err := cluster.GetConfigMap(ctx, helmchart.Namespace(), helmchart.EpinioStageScriptsName)
 	if err != nil {
-		return apierror.InternalError(err, "failed to retrieve staging image refs")
+		return nil, apierror.InternalError(err, "failed to retrieve staging image refs")
 	}
 
 	// get builder image from either request, application, or default as final fallback
 
 	builderImage, builderErr := getBuilderImage(req, app)
 	if builderErr != nil {
-		return builderErr
+		return nil, builderErr
 	}
 	if builderImage == "" {
 		builderImage = config.Data["builderImage"]
@@ -143,37 +159,49 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 	downloadImage := config.Data["downloadImage"]
 	unpackImage := config.Data["unpackImage"]
 
-	log.Info("staging app", "namespace", namespace, "app", req)
+	log.Info("staging app", "namespace", req.App.Namespace, "app", req)
 
 	staging, err := application.CurrentlyStaging(ctx, cluster, req.App.Namespace, req.App.Name)
 	if err != nil {
-		return apierror.InternalError(err)
+		return nil, apierror.InternalError(err)
 	}
 	if staging {
-		return apierror.NewBadRequestError("staging job for image ID still running")
+		return nil, apierror.NewBadRequestError("staging job for image ID still running")
 	}
 
 	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
 		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
 	if err != nil {
-		return apierror.InternalError(err, "failed to fetch the S3 connection details")
+		return nil, apierror.InternalError(err, "failed to fetch the S3 connection details")
 	}
 
 	blobUID, blobErr := getBlobUID(ctx, s3ConnectionDetails, req, app)
 	if blobErr != nil {
-		return blobErr
+		return nil, blobErr
 	}
 
 	// Create uid identifying the staging job to be
 
 	uid, err := randstr.Hex16()
 	if err != nil {
-		return apierror.InternalError(err, "failed to generate a uid")
+		return nil, apierror.InternalError(err, "failed to generate a uid")
 	}
 
 	environment, err := application.Environment(ctx, cluster, req.App)
 	if err != nil {
-		return apierror.InternalError(err, "failed to access application runtime environment")
+		return nil, apierror.InternalError(err, "failed to access application runtime environment")
+	}
+
+	buildEnvironment, err := application.BuildEnvironment(ctx, cluster, req.App)
+	if err != nil {
+		return nil, apierror.InternalError(err, "failed to access application build environment")
+	}
+
+	// Deprecated: staging sees the runtime environment, for the buildpack variables set
+	// before the build-only environment existed. They belong into the latter.
+	for _, name := range buildpackVariables(environment, buildEnvironment) {
+		log.Info("deprecated: buildpack variable taken from the runtime environment, set it as build-only variable",
+			"namespace", req.App.Namespace, "app", req.App.Name, "variable", name)
 	}
 
 	owner := metav1.OwnerReference{
@@ -187,7 +215,7 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 	// From the view of the new build we are about to create this is the previous id.
 	previousID, err := application.StageID(app)
 	if err != nil {
-		return apierror.InternalError(err, "failed to determine application stage id")
+		return nil, apierror.InternalError(err, "failed to determine application stage id")
 	}
 	if previousID == "" {
 		previousID = uid
@@ -195,7 +223,7 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 
 	registryPublicURL, err := getRegistryURL(ctx, cluster)
 	if err != nil {
-		return apierror.InternalError(err, "getting the Epinio registry public URL")
+		return nil, apierror.InternalError(err, "getting the Epinio registry public URL")
 	}
 
 	registryCertificateSecret := viper.GetString("registry-certificate-secret")
@@ -203,7 +231,7 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 	if registryCertificateSecret != "" {
 		registryCertificateHash, err = getRegistryCertificateHash(ctx, cluster, helmchart.Namespace(), registryCertificateSecret)
 		if err != nil {
-			return apierror.InternalError(err, "cannot calculate Certificate hash")
+			return nil, apierror.InternalError(err, "cannot calculate Certificate hash")
 		}
 	}
 
@@ -214,6 +242,7 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 		UnpackImage:         unpackImage,
 		BlobUID:             blobUID,
 		Environment:         environment.List(),
+		BuildEnvironment:    buildEnvironment.List(),
 		Owner:               owner,
 		RegistryURL:         registryPublicURL,
 		S3ConnectionDetails: s3ConnectionDetails,
@@ -226,7 +255,7 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 
 	err = ensurePVC(ctx, cluster, req.App)
 	if err != nil {
-		return apierror.InternalError(err, "failed to ensure a PersistenVolumeClaim for the application source and cache")
+		return nil, apierror.InternalError(err, "failed to ensure a PersistenVolumeClaim for the application source and cache")
 	}
 
 	job, jobenv := newJobRun(params)
@@ -234,27 +263,26 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 	// Note: The secret is deleted with the job in function `Unstage()`.
 	err = cluster.CreateSecret(ctx, helmchart.Namespace(), *jobenv)
 	if err != nil {
-		return apierror.InternalError(err, fmt.Sprintf("failed to create job env: %#v", jobenv))
+		return nil, apierror.InternalError(err, fmt.Sprintf("failed to create job env: %#v", jobenv))
 	}
 
 	err = cluster.CreateJob(ctx, helmchart.Namespace(), job)
 	if err != nil {
-		return apierror.InternalError(err, fmt.Sprintf("failed to create job run: %#v", job))
+		return nil, apierror.InternalError(err, fmt.Sprintf("failed to create job run: %#v", job))
 	}
 
 	if err := updateApp(ctx, cluster, app, params); err != nil {
-		return apierror.InternalError(err, "updating application CR with staging information")
+		return nil, apierror.InternalError(err, "updating application CR with staging information")
 	}
 
 	imageURL := params.ImageURL(params.RegistryURL)
 
 	log.Info("staged app", "namespace", helmchart.Namespace(), "app", params.AppRef, "uid", uid, "image", imageURL)
 
-	response.OKReturn(c, models.StageResponse{
+	return &models.StageResponse{
 		Stage:    models.NewStage(uid),
 		ImageURL: imageURL,
-	})
-	return nil
+	}, nil
 }
 
 // Staged handles the API endpoint /namespaces/:namespace/staging/:stage_id/complete
@@ -270,7 +298,18 @@ func (hc Controller) Staged(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	// Wait for the staging to be done, then check if it ended in failure.
+	apierr := waitForStaging(ctx, cluster, namespace, id)
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// waitForStaging waits for the staging Job of stage `id` in the namespace to be done, then
+// checks if it ended in failure.
+func waitForStaging(ctx context.Context, cluster *kubernetes.Cluster, namespace, id string) apierror.APIErrors {
 	// Select the job for this stage `id`.
 	selector := fmt.Sprintf("app.kubernetes.io/component=staging,app.kubernetes.io/part-of=%s,epinio.io/stage-id=%s",
 		namespace, id)
@@ -300,7 +339,6 @@ func (hc Controller) Staged(c *gin.Context) apierror.APIErrors {
 		}
 	}
 
-	response.OK(c)
 	return nil
 }
 
@@ -340,7 +378,8 @@ func validateBlob(ctx context.Context, blobUID string, app models.AppRef, s3Conn
 // newJobRun is a helper which creates the Job related resources from
 // the given staging params. That is the job itself, and a secret
 // holding the job's environment. Which is a copy of the app
-// environment + standard variables.
+// environment + build-only environment + standard variables. The
+// build-only environment has precedence over the app environment.
 func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 
 	jobName := names.GenerateResourceName("stage", app.Namespace, app.Name, app.Stage.ID)
@@ -495,13 +534,18 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	volumes, volumeMounts = mountS3Certs(volumes, volumeMounts)
 	volumes, volumeMounts = mountRegistryCerts(app, volumes, volumeMounts)
 
-	// Create job environment as a copy of the app environment, plus standard variable.
+	// Create job environment as a copy of the app environment, plus build-only environment,
+	// plus standard variable. As this secret is mounted by the staging job only the build-only
+	// variables are never seen by the application workload.
 	env := make(map[string][]byte)
 
 	env["CNB_PLATFORM_API"] = []byte("0.4")
 	for _, ev := range app.Environment {
 		env[ev.Name] = []byte(ev.Value)
 	}
+	for _, ev := range app.BuildEnvironment {
+		env[ev.Name] = []byte(ev.Value)
+	}
 
 	jobenv := &corev1.Secret{
 		Data: env,
@@ -715,6 +759,9 @@ func updateApp(ctx context.Context, cluster *kubernetes.Cluster, app *unstructur
 	if err := unstructured.SetNestedField(app.Object, params.BuilderImage, "spec", "builderimage"); err != nil {
 		return err
 	}
+	if err := application.RecordStageTime(app, params.Stage.ID, time.Now()); err != nil {
+		return err
+	}
 
 	client, err := cluster.ClientApp()
 	if err != nil {
@@ -776,3 +823,20 @@ func mountRegistryCerts(app stageParam, volumes []corev1.Volume, volumeMounts []
 
 	return volumes, volumeMounts
 }
+
+// buildpackVariables returns the sorted names of the buildpack variables, i.e. `BP_*`,
+// `BPL_*` and `BPE_*`, of the runtime environment which the build-only environment does not
+// override.
+func buildpackVariables(environment, buildEnvironment models.EnvVariableMap) []string {
+	names := []string{}
+	for name := range environment {
+		if _, ok := buildEnvironment[name]; ok {
+			continue
+		}
+		if strings.HasPrefix(name, "BP_") || strings.HasPrefix(name, "BPL_") || strings.HasPrefix(name, "BPE_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
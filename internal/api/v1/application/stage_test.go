@@ -0,0 +1,33 @@
+package application
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Application staging unit tests", func() {
+	environment := models.EnvVariableMap{"BP_NODE_VERSION": "16", "BPL_DEBUG": "1", "MODE": "prod"}
+	buildEnvironment := models.EnvVariableMap{"BP_NODE_VERSION": "18", "NPM_TOKEN": "t"}
+
+	It("stages with the runtime environment, overridden by the build-only environment", func() {
+		_, secret := newJobRun(stageParam{
+			AppRef:           models.NewAppRef("web", "workspace"),
+			Stage:            models.NewStage("123"),
+			Environment:      environment.List(),
+			BuildEnvironment: buildEnvironment.List(),
+		})
+
+		Expect(secret.Data).To(Equal(map[string][]byte{
+			"CNB_PLATFORM_API": []byte("0.4"),
+			"BP_NODE_VERSION":  []byte("18"),
+			"BPL_DEBUG":        []byte("1"),
+			"MODE":             []byte("prod"),
+			"NPM_TOKEN":        []byte("t"),
+		}))
+	})
+
+	It("finds the buildpack variables taken from the runtime environment", func() {
+		Expect(buildpackVariables(environment, buildEnvironment)).To(Equal([]string{"BPL_DEBUG"}))
+	})
+})
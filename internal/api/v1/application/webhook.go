@@ -0,0 +1,278 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// maxWebhookPayload limits the size of the git webhook notifications accepted by GitPush.
+const maxWebhookPayload = 10 * 1024 * 1024
+
+// webhookRebuilds coalesces the rebuilds triggered by git webhooks, per application.
+var webhookRebuilds = newRebuildQueue()
+
+// gitPushEvent is the part of a git hosting service's push notification used by GitPush.
+// GitHub, GitLab and Gitea all provide the pushed reference under this name.
+type gitPushEvent struct {
+	Ref string `json:"ref"`
+}
+
+// Webhook handles the API endpoint /namespaces/:namespace/applications/:app/webhook
+// It returns the secret used to sign the git webhook notifications for the application,
+// generating it if necessary.
+func (hc Controller) Webhook(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app := models.NewAppRef(appName, namespace)
+
+	exists, err := application.Exists(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	secret, err := application.WebhookSecret(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.AppWebhookResponse{
+		Secret: secret,
+	})
+	return nil
+}
+
+// GitPush handles the hook endpoint /namespaces/:namespace/applications/:app/git (POST)
+// It receives the push notifications of a git hosting service for the application's
+// repository. The endpoint is not authenticated. Instead the notification has to be signed
+// with the application's webhook secret. A push to the tracked branch re-runs import,
+// staging and deployment of the application in the background. Only one rebuild of an
+// application runs at a time. Pushes during a rebuild are coalesced into a single rebuild
+// after it, of the then latest revision.
+func (hc Controller) GitPush(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookPayload))
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error()).WithDetails("failed to read the notification")
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+
+	// Without a secret the application has no webhook. Report as unknown, to not leak
+	// information to unauthenticated callers.
+	secret, err := application.WebhookSecretLookup(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if secret == "" {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	if !validWebhookSignature(c.Request.Header, payload, secret) {
+		return apierror.NewAPIError("bad webhook signature", http.StatusUnauthorized)
+	}
+
+	// Connection checks done by the hosting service when the hook is configured.
+	if c.GetHeader("X-GitHub-Event") == "ping" || c.GetHeader("X-Gitea-Event") == "ping" {
+		response.OK(c)
+		return nil
+	}
+
+	app, err := application.Get(ctx, cluster, appRef)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return apierror.AppIsNotKnown(appName)
+		}
+		return apierror.InternalError(err)
+	}
+
+	origin, err := application.Origin(app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if origin.Kind != models.OriginGit {
+		return apierror.NewBadRequestError("application is not imported from git")
+	}
+
+	var event gitPushEvent
+	err = json.Unmarshal(payload, &event)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error()).WithDetails("failed to parse the notification")
+	}
+
+	if !trackedRef(origin.Git.Revision, event.Ref) {
+		log.Info("webhook, ignoring push", "namespace", namespace, "app", appName, "ref", event.Ref)
+		response.OK(c)
+		return nil
+	}
+
+	// The rebuild outlives the request. Hosting services do not wait for minutes.
+	username := app.GetAnnotations()[models.EpinioCreatedByAnnotation]
+	bgLog := log.WithName("webhook")
+	bgCtx := requestctx.WithLogger(context.Background(), bgLog)
+
+	started := webhookRebuilds.trigger(appRef, func() {
+		bgLog.Info("webhook, rebuilding", "namespace", namespace, "app", appName)
+		apierr := rebuild(bgCtx, cluster, appRef, origin, username)
+		if apierr != nil {
+			bgLog.Info("webhook, rebuild failed", "namespace", namespace, "app", appName,
+				"errors", apierr.Errors())
+			return
+		}
+		bgLog.Info("webhook, rebuild done", "namespace", namespace, "app", appName)
+	})
+
+	if started {
+		log.Info("webhook, rebuild started", "namespace", namespace, "app", appName, "ref", event.Ref)
+	} else {
+		log.Info("webhook, rebuild queued behind the running one", "namespace", namespace, "app", appName, "ref", event.Ref)
+	}
+	response.OK(c)
+	return nil
+}
+
+// rebuildQueue runs at most one rebuild per application at a time. For each application
+// it records whether a rebuild is running, and whether another was requested meanwhile.
+type rebuildQueue struct {
+	mutex sync.Mutex
+	again map[models.AppRef]bool
+}
+
+// newRebuildQueue returns an empty queue.
+func newRebuildQueue() *rebuildQueue {
+	return &rebuildQueue{again: map[models.AppRef]bool{}}
+}
+
+// trigger runs the rebuild of the application in the background, and returns true. If a
+// rebuild of the application is already running it instead requests that it is run once
+// more afterward, and returns false. Any number of such requests result in a single run.
+func (q *rebuildQueue) trigger(appRef models.AppRef, run func()) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, running := q.again[appRef]; running {
+		q.again[appRef] = true
+		return false
+	}
+	q.again[appRef] = false
+
+	go func() {
+		for {
+			run()
+
+			q.mutex.Lock()
+			if !q.again[appRef] {
+				delete(q.again, appRef)
+				q.mutex.Unlock()
+				return
+			}
+			q.again[appRef] = false
+			q.mutex.Unlock()
+		}
+	}()
+
+	return true
+}
+
+// validWebhookSignature checks the signature of the notification against the secret.
+// Both GitHub/Gitea style HMAC signatures and GitLab style secret tokens are supported.
+func validWebhookSignature(header http.Header, payload []byte, secret string) bool {
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		received, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+		if err != nil {
+			return false
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		return hmac.Equal(received, mac.Sum(nil))
+	}
+
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+
+	return false
+}
+
+// trackedRef returns true if a push to the git reference has to trigger a rebuild of an
+// application tracking the revision. Without a revision all pushes are accepted, as the
+// default branch of the repository is not known. A revision which is not a branch name
+// (i.e. a commit id) does not match any push.
+func trackedRef(revision, ref string) bool {
+	if revision == "" {
+		return true
+	}
+	return ref == "refs/heads/"+revision
+}
+
+// rebuild imports the application's sources from git, stages and deploys them.
+func rebuild(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, origin models.ApplicationOrigin, username string) apierror.APIErrors {
+	log := requestctx.Logger(ctx)
+
+	blobUID, apierr := importGit(ctx, log, cluster, appRef, *origin.Git, username)
+	if apierr != nil {
+		return apierr
+	}
+
+	stageResponse, apierr := stage(ctx, cluster, models.StageRequest{
+		App:     appRef,
+		BlobUID: blobUID,
+	}, username)
+	if apierr != nil {
+		return apierr
+	}
+
+	apierr = waitForStaging(ctx, cluster, appRef.Namespace, stageResponse.Stage.ID)
+	if apierr != nil {
+		return apierr
+	}
+
+	_, apierr = deployApp(ctx, cluster, models.DeployRequest{
+		App:      appRef,
+		Stage:    stageResponse.Stage,
+		ImageURL: stageResponse.ImageURL,
+		Origin:   origin,
+	}, username)
+
+	return apierr
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Application git webhook unit tests", func() {
+	payload := []byte(`{"ref":"refs/heads/main"}`)
+	secret := "s3cr3t"
+
+	sign := func(key string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(payload)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	Describe("validWebhookSignature", func() {
+		var header http.Header
+
+		BeforeEach(func() {
+			header = http.Header{}
+		})
+
+		It("accepts a payload signed with the secret", func() {
+			header.Set("X-Hub-Signature-256", sign(secret))
+			Expect(validWebhookSignature(header, payload, secret)).To(BeTrue())
+		})
+
+		It("rejects a payload signed with another key", func() {
+			header.Set("X-Hub-Signature-256", sign("other"))
+			Expect(validWebhookSignature(header, payload, secret)).To(BeFalse())
+		})
+
+		It("rejects a malformed signature", func() {
+			header.Set("X-Hub-Signature-256", "sha256=zz")
+			Expect(validWebhookSignature(header, payload, secret)).To(BeFalse())
+		})
+
+		It("accepts a matching gitlab token", func() {
+			header.Set("X-Gitlab-Token", secret)
+			Expect(validWebhookSignature(header, payload, secret)).To(BeTrue())
+		})
+
+		It("rejects a mismatching gitlab token", func() {
+			header.Set("X-Gitlab-Token", "other")
+			Expect(validWebhookSignature(header, payload, secret)).To(BeFalse())
+		})
+
+		It("rejects an unsigned payload", func() {
+			Expect(validWebhookSignature(header, payload, secret)).To(BeFalse())
+		})
+	})
+
+	Describe("trackedRef", func() {
+		It("accepts all pushes without revision", func() {
+			Expect(trackedRef("", "refs/heads/anything")).To(BeTrue())
+		})
+
+		It("accepts pushes to the tracked branch", func() {
+			Expect(trackedRef("main", "refs/heads/main")).To(BeTrue())
+		})
+
+		It("ignores pushes to other branches", func() {
+			Expect(trackedRef("main", "refs/heads/feature")).To(BeFalse())
+		})
+
+		It("ignores pushes for a commit id revision", func() {
+			Expect(trackedRef("6b1eb4e2a6c3ffe1a1e6a6b51aca1a5b7a4b8d5f", "refs/heads/main")).To(BeFalse())
+		})
+	})
+
+	Describe("rebuildQueue", func() {
+		It("coalesces the rebuilds requested while one runs", func() {
+			queue := newRebuildQueue()
+			appRef := models.NewAppRef("app", "workspace")
+
+			var runs int32
+			release := make(chan struct{})
+			run := func() {
+				if atomic.AddInt32(&runs, 1) == 1 {
+					<-release
+				}
+			}
+
+			Expect(queue.trigger(appRef, run)).To(BeTrue())
+			Eventually(func() int32 { return atomic.LoadInt32(&runs) }).Should(Equal(int32(1)))
+
+			Expect(queue.trigger(appRef, run)).To(BeFalse())
+			Expect(queue.trigger(appRef, run)).To(BeFalse())
+			close(release)
+
+			Eventually(func() int32 { return atomic.LoadInt32(&runs) }).Should(Equal(int32(2)))
+			Consistently(func() int32 { return atomic.LoadInt32(&runs) }, "200ms").Should(Equal(int32(2)))
+
+			Eventually(func() bool {
+				return queue.trigger(appRef, func() {})
+			}).Should(BeTrue())
+		})
+
+		It("runs the rebuilds of different applications independently", func() {
+			queue := newRebuildQueue()
+			release := make(chan struct{})
+			defer close(release)
+
+			Expect(queue.trigger(models.NewAppRef("a", "workspace"), func() { <-release })).To(BeTrue())
+			Expect(queue.trigger(models.NewAppRef("b", "workspace"), func() { <-release })).To(BeTrue())
+		})
+	})
+})
@@ -19,6 +19,7 @@ func AuthorizationMiddleware(c *gin.Context) {
 
 	method := c.Request.Method
 	path := c.Request.URL.Path
+	route := c.FullPath()
 	namespace := c.Param("namespace")
 
 	logger.Info(fmt.Sprintf("authorization request from user [%s] with role [%s] for [%s - %s]", user.Username, user.Role, method, path))
@@ -28,7 +29,7 @@ func AuthorizationMiddleware(c *gin.Context) {
 	case "admin":
 		authorized = authorizeAdmin(logger)
 	case "user":
-		authorized = authorizeUser(logger, user, path, namespace)
+		authorized = authorizeUser(logger, user, path, route, namespace)
 	}
 
 	logger.Info(fmt.Sprintf("user [%s] with role [%s] authorized [%t] for namespace [%s]", user.Username, user.Role, authorized, namespace))
@@ -45,14 +46,19 @@ func authorizeAdmin(logger logr.Logger) bool {
 	return true
 }
 
-func authorizeUser(logger logr.Logger, user auth.User, path, namespace string) bool {
+func authorizeUser(logger logr.Logger, user auth.User, path, route, namespace string) bool {
 	logger = logger.V(1).WithName("authorizeUser")
 
-	// check if the requested path is restricted
+	// check if the requested path is restricted. Routes with parameters are listed by
+	// their pattern.
 	if _, found := AdminRoutes[path]; found {
 		logger.Info(fmt.Sprintf("path [%s] is an admin route, user unauthorized", path))
 		return false
 	}
+	if _, found := AdminRoutes[route]; found {
+		logger.Info(fmt.Sprintf("route [%s] is an admin route, user unauthorized", route))
+		return false
+	}
 
 	// check if the user has permission on the requested namespace
 	if namespace != "" {
@@ -64,6 +64,24 @@ var _ = Describe("Authorization Middleware", func() {
 			})
 		})
 
+		When("url matches a restricted route", func() {
+			var router *gin.Engine
+
+			BeforeEach(func() {
+				v1.AdminRoutes = map[string]struct{}{
+					"/restricted/:name": {},
+				}
+				router = gin.New()
+				router.GET("/restricted/:name", v1.AuthorizationMiddleware)
+				url = "http://url.com/restricted/something"
+			})
+
+			It("returns status code 403", func() {
+				router.ServeHTTP(w, c.Request)
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
 		When("url is namespaced", func() {
 			It("returns status code 403 for another namespace", func() {
 				c.Params = []gin.Param{{Key: "namespace", Value: "another-workspace"}}
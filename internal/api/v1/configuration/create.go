@@ -27,10 +27,20 @@ func (sc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestError("cannot create configuration without a name")
 	}
 
-	if len(createRequest.Data) < 1 {
+	if len(createRequest.Data)+len(createRequest.External) < 1 {
 		return apierror.NewBadRequestError("cannot create configuration without data")
 	}
 
+	if err := createRequest.Restart.Validate(); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	for key := range createRequest.External {
+		if _, ok := createRequest.Data[key]; ok {
+			return apierror.NewBadRequestErrorf("the key %s has both a value and an external reference", key)
+		}
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -48,8 +58,19 @@ func (sc Controller) Create(c *gin.Context) apierror.APIErrors {
 	}
 	// any error here is `configuration not found`, and we can continue
 
+	// Resolve the external references into the values of their keys.
+	data, err := configurations.ResolveExternal(ctx, namespace, createRequest.External)
+	if err != nil {
+		return apierror.NewBadRequestError("cannot resolve the external references").
+			WithDetails(err.Error())
+	}
+	for key, value := range createRequest.Data {
+		data[key] = value
+	}
+
 	// Create the new configuration. At last.
-	_, err = configurations.CreateConfiguration(ctx, cluster, createRequest.Name, namespace, username, createRequest.Data)
+	_, err = configurations.CreateConfiguration(ctx, cluster, createRequest.Name, namespace, username,
+		data, createRequest.External, createRequest.Restart)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -57,6 +57,11 @@ func (sc Controller) Delete(c *gin.Context) apierror.APIErrors {
 		// [SERVICE] Reject operations on configurations belonging to a service. Their manipulation
 		// has to be done through service commands to keep the system in a consistent state.
 
+		if configuration.OriginNamespace != "" {
+			// [BELONG] keep in sync with same marker in the client
+			return apierror.NewBadRequestErrorf("Configuration belongs to service '%s' of namespace '%s', use service requests",
+				configuration.Origin, configuration.OriginNamespace)
+		}
 		if configuration.Origin != "" {
 			// [BELONG] keep in sync with same marker in the client
 			return apierror.NewBadRequestErrorf("Configuration belongs to service '%s', use service requests",
@@ -51,12 +51,13 @@ func makeResponse(ctx context.Context, appsOf map[string][]string, configuration
 	// All the possible siblings of a configuration are present in the configuration list.
 	//
 	// Simply iterate and sort them into buckets by service origin. Note that the namespace has
-	// to be part of the key. Non-service configurations are ignored.
+	// to be part of the key, and the namespace of the service for the configurations of shared
+	// services. Non-service configurations are ignored.
 
 	siblingMap := map[string][]string{}
 	for _, configuration := range configurations {
 		if configuration.Origin != "" {
-			key := fmt.Sprintf("n%s/o%s", configuration.Namespace(), configuration.Origin)
+			key := fmt.Sprintf("n%s/o%s/s%s", configuration.Namespace(), configuration.Origin, configuration.OriginNamespace)
 			siblingMap[key] = append(siblingMap[key], configuration.Name)
 		}
 	}
@@ -78,7 +79,7 @@ func makeResponse(ctx context.Context, appsOf map[string][]string, configuration
 
 		siblings := []string{}
 		if configuration.Origin != "" {
-			key := fmt.Sprintf("n%s/o%s", configuration.Namespace(), configuration.Origin)
+			key := fmt.Sprintf("n%s/o%s/s%s", configuration.Namespace(), configuration.Origin, configuration.OriginNamespace)
 			for _, maybeSibling := range siblingMap[key] {
 				if maybeSibling != configuration.Name {
 					siblings = append(siblings, maybeSibling)
@@ -101,6 +102,10 @@ func makeResponse(ctx context.Context, appsOf map[string][]string, configuration
 				Type:      configuration.Type,
 				Origin:    configuration.Origin,
 				Siblings:  siblings,
+
+				OriginNamespace: configuration.OriginNamespace,
+				External:        configuration.External,
+				Restart:         configuration.Restart,
 			},
 		})
 	}
@@ -1,13 +1,8 @@
 package configuration
 
 import (
-	"time"
-
 	"github.com/epinio/epinio/helpers/kubernetes"
-	"github.com/epinio/epinio/internal/api/v1/deploy"
 	"github.com/epinio/epinio/internal/api/v1/response"
-	"github.com/epinio/epinio/internal/application"
-	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/configurations"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -36,6 +31,12 @@ func (sc Controller) Replace(c *gin.Context) apierror.APIErrors { // nolint:gocy
 		}
 	}
 
+	// The configurations of a shared service are read-only. They follow the service.
+	if configuration.OriginNamespace != "" {
+		return apierror.NewBadRequestErrorf("Configuration belongs to service '%s' of namespace '%s', use service requests",
+			configuration.Origin, configuration.OriginNamespace)
+	}
+
 	var replaceRequest models.ConfigurationReplaceRequest
 	err = c.BindJSON(&replaceRequest)
 	if err != nil {
@@ -47,41 +48,20 @@ func (sc Controller) Replace(c *gin.Context) apierror.APIErrors { // nolint:gocy
 		return apierror.InternalError(err)
 	}
 
-	// Perform restart on the candidates which are actually running
+	// Restart the bound apps which are actually running, as per their restart policies.
+	restarted := []string{}
 	if restart {
-		username := requestctx.User(ctx).Username
-
-		// Determine bound apps, as candidates for restart.
-		appNames, err := application.BoundAppsNamesFor(ctx, cluster, namespace, configurationName)
-		if err != nil {
-			return apierror.InternalError(err)
-		}
-
-		for _, appName := range appNames {
-			app, err := application.Lookup(ctx, cluster, namespace, appName)
-			if err != nil {
-				return apierror.InternalError(err)
-			}
-
-			// Restart workload, if any
-			if app.Workload != nil {
-				// TODO :: This plain restart is different from all other restarts
-				// (scaling, ev change, bound configurations change) ... The deployment
-				// actually does not change, at all. A resource the deployment
-				// references/uses changed, i.e. the configuration. We still have to
-				// trigger the restart somehow, so that the pod mounting the
-				// configuration remounts it for the new/changed keys.
-				nano := time.Now().UnixNano()
-				_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
-				if apierr != nil {
-					return apierr
-				}
-			}
+		var apierr apierror.APIErrors
+		restarted, apierr = restartBoundApps(ctx, cluster, configuration)
+		if apierr != nil {
+			return apierr
 		}
 	}
 
 	// Done
 
-	response.OK(c)
+	response.OKReturn(c, models.ConfigurationUpdateResponse{
+		RestartedApps: restarted,
+	})
 	return nil
 }
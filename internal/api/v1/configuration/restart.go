@@ -0,0 +1,171 @@
+package configuration
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// restartBoundApps restarts the running applications bound to the changed configuration,
+// as per the restart policies of their bindings, falling back to the policy of the
+// configuration. It returns the names of the restarted applications.
+//
+// Applications restarted `parallel` are restarted at once. Applications restarted
+// `always`, the default, are restarted after these, one after the other, followed by the
+// applications restarted `rolling`. As a deployment waits for the application to be ready,
+// each waits for the previous one. The first failure stops the rollout.
+func restartBoundApps(ctx context.Context, cluster *kubernetes.Cluster, configuration *configurations.Configuration) ([]string, apierror.APIErrors) {
+	logger := requestctx.Logger(ctx).WithName("RestartBoundApps")
+	username := requestctx.User(ctx).Username
+	namespace := configuration.Namespace()
+
+	// Determine bound apps, as candidates for restart.
+	appNames, err := application.BoundAppsNamesFor(ctx, cluster, namespace, configuration.Name)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	var atOnce, sequential, rolling []*models.App
+	for _, appName := range appNames {
+		app, err := application.Lookup(ctx, cluster, namespace, appName)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		// Restart workload, if any
+		if app == nil || app.Workload == nil {
+			continue
+		}
+
+		policy, err := application.BoundConfigurationRestart(ctx, cluster, app.Meta, configuration.Name)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		if policy == "" {
+			policy = configuration.Restart
+		}
+
+		switch policy {
+		case models.ConfigurationRestartNever:
+			logger.Info("not restarting app", "namespace", namespace, "app", appName)
+		case models.ConfigurationRestartParallel:
+			atOnce = append(atOnce, app)
+		case models.ConfigurationRestartRolling:
+			rolling = append(rolling, app)
+		default:
+			sequential = append(sequential, app)
+		}
+	}
+
+	// TODO :: This plain restart is different from all other restarts
+	// (scaling, ev change, bound configurations change) ... The deployment
+	// actually does not change, at all. A resource the deployment
+	// references/uses changed, i.e. the configuration. We still have to
+	// trigger the restart somehow, so that the pod mounting the
+	// configuration remounts it for the new/changed keys.
+	restart := func(app *models.App) apierror.APIErrors {
+		logger.Info("restarting app", "namespace", namespace, "app", app.Meta.Name)
+
+		nano := time.Now().UnixNano()
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
+		return apierr
+	}
+
+	restarted := []string{}
+
+	var wg sync.WaitGroup
+	apierrs := make([]apierror.APIErrors, len(atOnce))
+	for i, app := range atOnce {
+		wg.Add(1)
+		go func(i int, app *models.App) {
+			defer wg.Done()
+			apierrs[i] = restart(app)
+		}(i, app)
+	}
+	wg.Wait()
+
+	for i, app := range atOnce {
+		if apierrs[i] != nil {
+			return nil, apierrs[i]
+		}
+		restarted = append(restarted, app.Meta.Name)
+	}
+
+	for _, app := range append(sequential, rolling...) {
+		apierr := restart(app)
+		if apierr != nil {
+			return nil, apierr
+		}
+		restarted = append(restarted, app.Meta.Name)
+	}
+
+	sort.Strings(restarted)
+	return restarted, nil
+}
+
+// RestartEnvBoundApps restarts the running applications taking values of the named
+// configuration from their environment. It is called after the values changed outside of
+// a configuration update, i.e. by the synchronization of external values. Mounted values
+// reach the applications without restart. Bindings with restart policy `never` are left
+// alone. The applications are restarted one after the other, failures are logged.
+func RestartEnvBoundApps(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) {
+	logger := requestctx.Logger(ctx).WithName("RestartEnvBoundApps")
+
+	configuration, err := configurations.Lookup(ctx, cluster, namespace, name)
+	if err != nil {
+		logger.Error(err, "looking up configuration", "namespace", namespace, "configuration", name)
+		return
+	}
+
+	appNames, err := application.BoundAppsNamesFor(ctx, cluster, namespace, name)
+	if err != nil {
+		logger.Error(err, "looking up bound apps", "namespace", namespace, "configuration", name)
+		return
+	}
+
+	for _, appName := range appNames {
+		app, err := application.Lookup(ctx, cluster, namespace, appName)
+		if err != nil {
+			logger.Error(err, "looking up app", "namespace", namespace, "app", appName)
+			continue
+		}
+		if app == nil || app.Workload == nil {
+			continue
+		}
+
+		bindings, err := application.BoundConfigurationsOptions(ctx, cluster, app.Meta)
+		if err != nil {
+			logger.Error(err, "looking up bindings", "namespace", namespace, "app", appName)
+			continue
+		}
+		binding := bindings[name]
+		if binding.Env == nil {
+			continue
+		}
+
+		policy := binding.Restart
+		if policy == "" {
+			policy = configuration.Restart
+		}
+		if policy == models.ConfigurationRestartNever {
+			logger.Info("not restarting app", "namespace", namespace, "app", appName)
+			continue
+		}
+
+		logger.Info("restarting app", "namespace", namespace, "app", appName, "configuration", name)
+
+		nano := time.Now().UnixNano()
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, app.Workload.Username, "", nil, &nano)
+		if apierr != nil {
+			logger.Info("restart failed", "namespace", namespace, "app", appName, "errors", apierr.Errors())
+		}
+	}
+}
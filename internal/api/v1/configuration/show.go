@@ -44,8 +44,28 @@ func (sc Controller) Show(c *gin.Context) apierror.APIErrors {
 	}
 
 	// For service-based configuration, fetch and record siblings. Itself excluded, of course.
+	// The siblings of a shared service's configuration are the other projections of the
+	// service into this namespace.
 	siblings := []string{}
-	if configuration.Origin != "" {
+	if configuration.OriginNamespace != "" {
+		service := &models.Service{
+			Meta: models.Meta{
+				Name:      configuration.Origin,
+				Namespace: configuration.OriginNamespace,
+			},
+		}
+
+		sharedConfigurations, err := configurations.ForServiceShare(ctx, cluster, service, namespace)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		for _, secret := range sharedConfigurations {
+			if secret.Name != configuration.Name {
+				siblings = append(siblings, secret.Name)
+			}
+		}
+	} else if configuration.Origin != "" {
 		kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
 		if err != nil {
 			return apierror.InternalError(err)
@@ -83,6 +103,10 @@ func (sc Controller) Show(c *gin.Context) apierror.APIErrors {
 			Type:      configuration.Type,
 			Origin:    configuration.Origin,
 			Siblings:  siblings,
+
+			OriginNamespace: configuration.OriginNamespace,
+			External:        configuration.External,
+			Restart:         configuration.Restart,
 		},
 	})
 	return nil
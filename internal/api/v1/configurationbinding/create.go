@@ -43,6 +43,15 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		}
 	}
 
+	if err := bindRequest.Restart.Validate(); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+	if bindRequest.Env != nil {
+		if err := bindRequest.Env.Validate(); err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -56,7 +65,19 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
-	boundedConfigs, errors := CreateConfigurationBinding(ctx, cluster, namespace, *app, bindRequest.Names)
+	if bindRequest.Env != nil && len(bindRequest.Env.Keys) > 0 {
+		apierr := checkEnvKeys(ctx, cluster, namespace, bindRequest.Names, bindRequest.Env)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	options := application.BindingOptions{
+		Restart: bindRequest.Restart,
+		Env:     bindRequest.Env,
+	}
+
+	boundedConfigs, errors := CreateConfigurationBinding(ctx, cluster, namespace, *app, bindRequest.Names, options)
 	if errors != nil {
 		return errors
 	}
@@ -76,6 +97,7 @@ func CreateConfigurationBinding(
 	namespace string,
 	app models.App,
 	configurationNames []string,
+	options application.BindingOptions,
 ) ([]string, apierror.APIErrors) {
 	logger := requestctx.Logger(ctx).WithName("CreateConfigurationBinding")
 
@@ -133,7 +155,24 @@ func CreateConfigurationBinding(
 			theIssues = append([]apierror.APIError{apierror.InternalError(err)}, theIssues...)
 			return nil, apierror.NewMultiError(theIssues)
 		}
+	}
 
+	// Record the options of the new and the already bound configurations. The restart
+	// policy applies to future changes of the configurations. Exposing keys as environment
+	// variables changes the workload.
+	changedEnv := false
+	if options.Restart != "" || options.Env != nil {
+		logger.Info("BoundConfigurationsOptionsSet")
+		err := application.BoundConfigurationsOptionsSet(ctx, cluster, app.Meta,
+			append(okToBind, boundedConfigs...), options)
+		if err != nil {
+			theIssues = append([]apierror.APIError{apierror.InternalError(err)}, theIssues...)
+			return nil, apierror.NewMultiError(theIssues)
+		}
+		changedEnv = options.Env != nil && len(boundedConfigs) > 0
+	}
+
+	if len(okToBind) > 0 || changedEnv {
 		logger.Info("DeployApp")
 
 		// Update the workload, if there is any.
@@ -151,3 +190,32 @@ func CreateConfigurationBinding(
 
 	return boundedConfigs, nil
 }
+
+// checkEnvKeys checks that the keys selected for environment variables exist in the
+// configurations. Unknown configurations are left to the binding to report.
+func checkEnvKeys(ctx context.Context, cluster *kubernetes.Cluster, namespace string,
+	configurationNames []string, env *models.ConfigurationEnvMapping) apierror.APIErrors {
+
+	for _, configurationName := range configurationNames {
+		configuration, err := configurations.Lookup(ctx, cluster, namespace, configurationName)
+		if err != nil {
+			if err.Error() == "configuration not found" {
+				continue
+			}
+			return apierror.InternalError(err)
+		}
+
+		details, err := configuration.Details(ctx)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		for key := range env.Keys {
+			if _, ok := details[key]; !ok {
+				return apierror.NewBadRequestErrorf("configuration %s has no key %s", configurationName, key)
+			}
+		}
+	}
+
+	return nil
+}
@@ -31,7 +31,9 @@ func (hc Controller) Delete(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	if config.Origin != "" {
+	// The configurations of a shared service are bound and unbound like plain
+	// configurations, as the service is not in the application's namespace.
+	if config.Origin != "" && config.OriginNamespace == "" {
 		return apierror.NewBadRequestErrorf("Configuration belongs to service '%s', use service requests",
 			config.Origin)
 	}
@@ -48,8 +48,14 @@ func DeployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 	// (**) See below for explanation
 	sort.Strings(appObj.Configuration.Configurations)
 
-	bound := []helm.ConfigParameter{} // Configurations and their mount paths
-	service := map[string]int{}       // Seen services, and count of their configurations
+	bound := []helm.ConfigParameter{}       // Configurations and their mount paths
+	boundEnv := []helm.ConfigEnvParameter{} // Configurations exposed as environment variables
+	service := map[string]int{}             // Seen services, and count of their configurations
+
+	bindings, err := application.BoundConfigurationsOptions(ctx, cluster, app)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
 
 	for _, configName := range appObj.Configuration.Configurations {
 		config, err := configurations.Lookup(ctx, cluster, app.Namespace, configName)
@@ -93,6 +99,10 @@ func DeployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 			Name: configName,
 			Path: path,
 		})
+
+		if env := bindings[configName].Env; env != nil {
+			boundEnv = append(boundEnv, helm.NewConfigEnvParameter(configName, *env))
+		}
 	}
 
 	imageURL := appObj.ImageURL
@@ -114,6 +124,7 @@ func DeployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		Chart:          chartName,
 		Environment:    appObj.Configuration.Environment,
 		Configurations: bound,
+		ConfigEnv:      boundEnv,
 		Instances:      *appObj.Configuration.Instances,
 		ImageURL:       imageURL,
 		Username:       username,
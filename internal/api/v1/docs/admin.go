@@ -0,0 +1,94 @@
+package docs
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+//go:generate swagger generate spec
+
+// swagger:route POST /admin/gc/images admin ImageGC
+// Garbage collect the application images in the Epinio registry. Restricted to admins.
+// responses:
+//   200: ImageGCResponse
+
+// swagger:parameters ImageGC
+type ImageGCParam struct {
+	// in: body
+	Body models.ImageGCRequest
+}
+
+// swagger:response ImageGCResponse
+type ImageGCResponse struct {
+	// in: body
+	Body models.ImageGCResponse
+}
+
+// swagger:route POST /admin/gc/blobs admin BlobPrune
+// Prune the orphaned application source blobs from the S3 storage. Restricted to admins.
+// responses:
+//   200: BlobPruneResponse
+
+// swagger:parameters BlobPrune
+type BlobPruneParam struct {
+	// in: body
+	Body models.BlobPruneRequest
+}
+
+// swagger:response BlobPruneResponse
+type BlobPruneResponse struct {
+	// in: body
+	Body models.BlobPruneResponse
+}
+
+// swagger:route POST /admin/catalogservices admin CatalogServiceAdd
+// Add a service to the catalog. The body is the `Service` custom resource of the catalog.
+// Restricted to admins.
+// responses:
+//   200: CatalogServiceAddResponse
+
+// swagger:parameters CatalogServiceAdd
+type CatalogServiceAddParam struct {
+	// in: body
+	Body models.CatalogServiceRequest
+}
+
+// swagger:response CatalogServiceAddResponse
+type CatalogServiceAddResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route PUT /admin/catalogservices/{CatalogService} admin CatalogServiceUpdate
+// Replace the named `CatalogService`. The body is the `Service` custom resource of the
+// catalog. Restricted to admins.
+// responses:
+//   200: CatalogServiceUpdateResponse
+
+// swagger:parameters CatalogServiceUpdate
+type CatalogServiceUpdateParam struct {
+	// in: path
+	CatalogService string
+	// in: body
+	Body models.CatalogServiceRequest
+}
+
+// swagger:response CatalogServiceUpdateResponse
+type CatalogServiceUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /admin/catalogservices/{CatalogService} admin CatalogServiceDelete
+// Remove the named `CatalogService` from the catalog. Restricted to admins.
+// responses:
+//   200: CatalogServiceDeleteResponse
+
+// swagger:parameters CatalogServiceDelete
+type CatalogServiceDeleteParam struct {
+	// in: path
+	CatalogService string
+}
+
+// swagger:response CatalogServiceDeleteResponse
+type CatalogServiceDeleteResponse struct {
+	// in: body
+	Body models.Response
+}
@@ -126,7 +126,7 @@ type AppLogsParam struct {
 type AppLogsResponse struct{}
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/exec application AppExec
-// Get a shell to the `App` in the `Namespace`.
+// Get a shell to the `App` in the `Namespace`, or run the `Command` in it.
 // responses:
 //   200: AppExecResponse
 
@@ -138,11 +138,40 @@ type AppExecParam struct {
 	App string
 	// in: query
 	Instance string
+	// Command to run instead of a shell, one element per parameter.
+	// in: query
+	Command []string
+	// Whether to allocate a TTY. Defaults to true for a shell, false for a command.
+	// in: query
+	Tty string
+	// Whether the client streams stdin. Defaults to true for a shell, false for a command.
+	// in: query
+	Stdin string
 }
 
 // swagger:response AppExecResponse
 type AppExecResponse struct{}
 
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/debug application AppDebug
+// Attach a debug container running the `Image` to an instance of the `App` in the `Namespace`.
+// responses:
+//   200: AppDebugResponse
+
+// swagger:parameters AppDebug
+type AppDebugParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	Instance string
+	// in: query
+	Image string
+}
+
+// swagger:response AppDebugResponse
+type AppDebugResponse struct{}
+
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/portforward application AppPortForward
 // Get a shell to the `App` in the `Namespace`.
 // responses:
@@ -276,9 +305,32 @@ type AppImportGitParam struct {
 	// in: path
 	Namespace string
 	// in: path
-	App    string
-	GitUrl string
-	GitRev string
+	App            string
+	GitUrl         string
+	GitRev         string
+	GitCredentials string
+	GitSubmodules  bool
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/webhook application AppWebhook
+// Return the secret used to sign the git webhook notifications for the named `App` in the
+// `Namespace`. The secret is generated on first use. The notifications are sent to
+// `/hooks/v1/namespaces/{Namespace}/applications/{App}/git`.
+// responses:
+//   200: AppWebhookResponse
+
+// swagger:parameters AppWebhook
+type AppWebhookParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppWebhookResponse
+type AppWebhookResponse struct {
+	// in: body
+	Body models.AppWebhookResponse
 }
 
 // swagger:response AppImportGitResponse
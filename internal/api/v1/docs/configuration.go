@@ -149,7 +149,7 @@ type ConfigurationUpdateParam struct {
 // swagger:response ConfigurationUpdateResponse
 type ConfigurationUpdateResponse struct {
 	// in: body
-	Body models.Response
+	Body models.ConfigurationUpdateResponse
 }
 
 // swagger:route PUT /namespaces/{Namespace}/configurations/{Configuration} configuration ConfigurationReplace
@@ -170,7 +170,7 @@ type ConfigurationReplaceParam struct {
 // swagger:response ConfigurationReplaceResponse
 type ConfigurationReplaceResponse struct {
 	// in: body
-	Body models.Response
+	Body models.ConfigurationUpdateResponse
 }
 
 // swagger:route GET /configurations configuration AllConfigurations
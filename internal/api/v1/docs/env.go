@@ -123,3 +123,66 @@ type EnvUnsetResponse struct {
 	// in: body
 	Body models.Response
 }
+
+// Build Env -- Application Build-only Environment
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/buildenvironment app-env BuildEnvList
+// Return the build-only environment variable assignments for the `App` in the `Namespace`.
+// responses:
+//   200: BuildEnvListResponse
+
+// swagger:parameters BuildEnvList
+type BuildEnvListParams struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response BuildEnvListResponse
+type BuildEnvListResponse struct {
+	// in: body
+	Body models.EnvVariableMap
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/buildenvironment app-env BuildEnvSet
+// Create/modify the posted build-only environment variable assignments for the `App` in the `Namespace`.
+// responses:
+//   200: BuildEnvSetResponse
+
+// swagger:parameters BuildEnvSet
+type BuildEnvSetParams struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Body models.EnvVariableMap
+}
+
+// swagger:response BuildEnvSetResponse
+type BuildEnvSetResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/applications/{App}/buildenvironment/{Env} app-env BuildEnvUnset
+// Remove the named build-only `Env` variable from the `App` in the `Namespace`.
+// responses:
+//   200: BuildEnvUnsetResponse
+
+// swagger:parameters BuildEnvUnset
+type BuildEnvUnsetParams struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	Env string
+}
+
+// swagger:response BuildEnvUnsetResponse
+type BuildEnvUnsetResponse struct {
+	// in: body
+	Body models.Response
+}
@@ -66,6 +66,63 @@ type NamespaceShowResponse struct {
 	Body models.Namespace
 }
 
+// swagger:route PUT /namespaces/{Namespace}/quota namespace NamespaceQuotaUpdate
+// Replace the quota of the named `Namespace`. Restricted to admins.
+// responses:
+//   200: NamespaceQuotaUpdateResponse
+
+// swagger:parameters NamespaceQuotaUpdate
+type NamespaceQuotaUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Quota models.NamespaceQuota
+}
+
+// swagger:response NamespaceQuotaUpdateResponse
+type NamespaceQuotaUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route POST /namespaces/{Namespace}/members namespace NamespaceMemberAdd
+// Give the user in the body access to the named `Namespace`, as member or owner.
+// responses:
+//   200: NamespaceMemberAddResponse
+
+// swagger:parameters NamespaceMemberAdd
+type NamespaceMemberAddParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Configuration models.NamespaceMemberRequest
+}
+
+// swagger:response NamespaceMemberAddResponse
+type NamespaceMemberAddResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/members/{Username} namespace NamespaceMemberRemove
+// Remove the access of the named user to the named `Namespace`.
+// responses:
+//   200: NamespaceMemberRemoveResponse
+
+// swagger:parameters NamespaceMemberRemove
+type NamespaceMemberRemoveParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Username string
+}
+
+// swagger:response NamespaceMemberRemoveResponse
+type NamespaceMemberRemoveResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route GET /namespacematches/{Pattern} namespace NamespaceMatch
 // Return list of names for all controlled namespaces whose name matches the prefix `Pattern`.
 // responses:
@@ -92,3 +149,76 @@ type NamespaceMatchResponse struct {
 type NamespaceMatch0Param struct{}
 
 // response: See NamespaceMatch.
+
+// swagger:route GET /namespaces/{Namespace}/registries namespace RegistryLogins
+// Return list of the container registries the `Namespace` has pull credentials for.
+// responses:
+//   200: RegistryLoginsResponse
+
+// swagger:parameters RegistryLogins
+type RegistryLoginsParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response RegistryLoginsResponse
+type RegistryLoginsResponse struct {
+	// in: body
+	Body models.RegistryLoginList
+}
+
+// swagger:route POST /namespaces/{Namespace}/registries namespace RegistryLogin
+// Store the posted pull credentials for a container registry in the `Namespace`.
+// responses:
+//   200: RegistryLoginResponse
+
+// swagger:parameters RegistryLogin
+type RegistryLoginParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.RegistryLoginRequest
+}
+
+// swagger:response RegistryLoginResponse
+type RegistryLoginResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/registries/{Registry} namespace RegistryLogout
+// Remove the pull credentials for the container `Registry` from the `Namespace`.
+// responses:
+//   200: RegistryLogoutResponse
+
+// swagger:parameters RegistryLogout
+type RegistryLogoutParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Registry string
+}
+
+// swagger:response RegistryLogoutResponse
+type RegistryLogoutResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route GET /namespaces/{Namespace}/events namespace NamespaceEvents
+// Return the events of the `Namespace` streamed over a websocket, i.e. changes of its
+// applications, their staging and instances, services and configurations.
+// responses:
+//   200: NamespaceEventsResponse
+
+// swagger:parameters NamespaceEvents
+type NamespaceEventsParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response NamespaceEventsResponse
+type NamespaceEventsResponse struct {
+	// in: body
+	Body models.Event
+}
@@ -131,6 +131,107 @@ type ServiceMatchResponse struct {
 	Body models.ServiceMatchResponse
 }
 
+// swagger:route PATCH /namespaces/{Namespace}/services/{Service} service ServiceUpdate
+// Change the settings of the named `Service` in the `Namespace`, and/or upgrade it to another chart version.
+// responses:
+//   200: ServiceUpdateResponse
+
+// swagger:parameters ServiceUpdate
+type ServiceUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Body models.ServiceUpdateRequest
+}
+
+// swagger:response ServiceUpdateResponse
+type ServiceUpdateResponse struct {
+	// in: body
+	Body models.ServiceUpdateResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/backups service ServiceBackup
+// Back up the data of the named `Service` in the `Namespace` into the S3 storage.
+// responses:
+//   200: ServiceBackupResponse
+
+// swagger:parameters ServiceBackup
+type ServiceBackupParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceBackupResponse
+type ServiceBackupResponse struct {
+	// in: body
+	Body models.ServiceBackup
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/backups service ServiceBackups
+// Return the backups of the named `Service` in the `Namespace`, newest first.
+// responses:
+//   200: ServiceBackupsResponse
+
+// swagger:parameters ServiceBackups
+type ServiceBackupsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceBackupsResponse
+type ServiceBackupsResponse struct {
+	// in: body
+	Body models.ServiceBackupList
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/backups/{Backup}/restore service ServiceRestore
+// Restore the data of the named `Service` in the `Namespace` from the `Backup`.
+// responses:
+//   200: ServiceRestoreResponse
+
+// swagger:parameters ServiceRestore
+type ServiceRestoreParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: path
+	Backup string
+}
+
+// swagger:response ServiceRestoreResponse
+type ServiceRestoreResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route PUT /namespaces/{Namespace}/services/{Service}/backupschedule service ServiceBackupSchedule
+// Set the schedule of the backups of the named `Service` in the `Namespace`.
+// responses:
+//   200: ServiceBackupScheduleResponse
+
+// swagger:parameters ServiceBackupSchedule
+type ServiceBackupScheduleParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Body models.ServiceBackupScheduleRequest
+}
+
+// swagger:response ServiceBackupScheduleResponse
+type ServiceBackupScheduleResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route DELETE /namespaces/{Namespace}/services/{Service} service ServiceDelete
 // Delete the named `Service` in the `Namespace`.
 // responses:
@@ -165,6 +266,86 @@ type ServiceDeleteResponse struct {
 	Body models.ServiceDeleteResponse
 }
 
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/ready service ServiceReady
+// Wait for the provisioning of the named `Service` in the `Namespace` to complete.
+// responses:
+//   200: ServiceReadyResponse
+
+// swagger:parameters ServiceReady
+type ServiceReadyParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceReadyResponse
+type ServiceReadyResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/rotate-credentials service ServiceRotateCredentials
+// Rotate the credentials of the named `Service` in the `Namespace`, and restart the bound applications.
+// responses:
+//   200: ServiceRotateCredentialsResponse
+
+// swagger:parameters ServiceRotateCredentials
+type ServiceRotateCredentialsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceRotateCredentialsResponse
+type ServiceRotateCredentialsResponse struct {
+	// in: body
+	Body models.ServiceRotateCredentialsResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/shares service ServiceShare
+// Share the named `Service` in the `Namespace` with another namespace, projecting its configurations into that namespace.
+// responses:
+//   200: ServiceShareResponse
+
+// swagger:parameters ServiceShare
+type ServiceShareParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Configuration models.ServiceShareRequest
+}
+
+// swagger:response ServiceShareResponse
+type ServiceShareResponse struct {
+	// in: body
+	Body models.ServiceShareResponse
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/services/{Service}/shares/{Target} service ServiceUnshare
+// Stop sharing the named `Service` in the `Namespace` with the `Target` namespace, removing the projected configurations.
+// responses:
+//   200: ServiceUnshareResponse
+
+// swagger:parameters ServiceUnshare
+type ServiceUnshareParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: path
+	Target string
+}
+
+// swagger:response ServiceUnshareResponse
+type ServiceUnshareResponse struct {
+	// in: body
+	Body models.ServiceUnshareResponse
+}
+
 // swagger:route GET /namespaces/{Namespace}/serviceapps service ServiceApps
 // Return map from services in the `Namespace`, to the apps in the same.
 // responses:
@@ -223,3 +404,43 @@ type ServiceUnbindResponse struct {
 	// in: body
 	Body models.Response
 }
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/portforward/target service ServicePortForwardTarget
+// Return the running pod of the named `Service` in the `Namespace` ports are forwarded to,
+// and the ports of the service mapped to the ports of that pod.
+// responses:
+//   200: ServicePortForwardTargetResponse
+
+// swagger:parameters ServicePortForwardTarget
+type ServicePortForwardTargetParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: query
+	Instance string
+}
+
+// swagger:response ServicePortForwardTargetResponse
+type ServicePortForwardTargetResponse struct {
+	// in: body
+	Body models.ServicePortForwardTargetResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/portforward service ServicePortForward
+// Forward ports to a running pod of the named `Service` in the `Namespace`.
+// responses:
+//   200: ServicePortForwardResponse
+
+// swagger:parameters ServicePortForward
+type ServicePortForwardParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: query
+	Instance string
+}
+
+// swagger:response ServicePortForwardResponse
+type ServicePortForwardResponse struct{}
@@ -0,0 +1,133 @@
+package env
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BuildIndex handles the API endpoint /namespaces/:namespace/applications/:app/buildenvironment
+// It receives the namespace, application name and returns the build-only environment
+// associated with that application
+func (hc Controller) BuildIndex(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespaceName := c.Param("namespace")
+	appName := c.Param("app")
+
+	log.Info("returning build environment", "namespace", namespaceName, "app", appName)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app := models.NewAppRef(appName, namespaceName)
+
+	exists, err := application.Exists(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	environment, err := application.BuildEnvironment(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, environment)
+	return nil
+}
+
+// BuildSet handles the API endpoint /namespaces/:namespace/applications/:app/buildenvironment (POST)
+// It receives the namespace, application name, var name and value, and add/modifies the
+// variable in the application's build-only environment. The workload is not restarted, as
+// the variables are only seen by the next staging.
+func (hc Controller) BuildSet(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespaceName := c.Param("namespace")
+	appName := c.Param("app")
+
+	log.Info("processing build environment variable assignment",
+		"namespace", namespaceName, "app", appName)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app := models.NewAppRef(appName, namespaceName)
+
+	exists, err := application.Exists(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	var setRequest models.EnvVariableMap
+	err = c.BindJSON(&setRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	err = application.BuildEnvironmentSet(ctx, cluster, app, setRequest, false)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// BuildUnset handles the API endpoint /namespaces/:namespace/applications/:app/buildenvironment/:env (DELETE)
+// It receives the namespace, application name, var name, and removes the variable from the
+// application's build-only environment.
+func (hc Controller) BuildUnset(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespaceName := c.Param("namespace")
+	appName := c.Param("app")
+	varName := c.Param("env")
+
+	log.Info("processing build environment variable removal",
+		"namespace", namespaceName, "app", appName, "var", varName)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app := models.NewAppRef(appName, namespaceName)
+
+	exists, err := application.Exists(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	err = application.BuildEnvironmentUnset(ctx, cluster, app, varName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -3,6 +3,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
@@ -17,7 +18,7 @@ import (
 )
 
 // Create handles the API endpoint /namespaces (POST).
-// It creates a namespace with the specified name.
+// It creates a namespace with the specified name. Only admins can limit it by a quota.
 func (oc Controller) Create(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 
@@ -37,6 +38,15 @@ func (oc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestError("name of namespace to create not found")
 	}
 
+	if request.Quota != nil {
+		if requestctx.User(ctx).Role != "admin" {
+			return apierror.NewAPIError("only admins can set the quota of a namespace", http.StatusForbidden)
+		}
+		if err := namespaces.ValidateQuota(*request.Quota); err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
 	exists, err := namespaces.Exists(ctx, cluster, namespaceName)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -50,11 +60,24 @@ func (oc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	if request.Quota != nil {
+		err = namespaces.SetQuota(ctx, cluster, namespaceName, *request.Quota)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	err = addNamespaceToUser(ctx, namespaceName)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
+	// The creator owns the namespace, and manages its members.
+	err = namespaces.SetOwner(ctx, cluster, namespaceName, requestctx.User(ctx).Username, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
 	response.Created(c)
 	return nil
 }
@@ -8,8 +8,10 @@ import (
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/api/v1/service"
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/services"
@@ -26,8 +28,11 @@ import (
 // Delete handles the API endpoint /namespaces/:namespace (DELETE).
 // It destroys the namespace specified by its name.
 // This includes all the applications and configurations in it.
+// The shares of services with other namespaces, in either direction, are removed first.
 func (oc Controller) Delete(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("NamespaceDelete")
+	username := requestctx.User(ctx).Username
 	namespace := c.Param("namespace")
 
 	cluster, err := kubernetes.GetCluster(ctx)
@@ -35,6 +40,11 @@ func (oc Controller) Delete(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	apiErr := service.UnshareNamespace(ctx, cluster, logger, username, namespace)
+	if apiErr != nil {
+		return apiErr
+	}
+
 	err = deleteApps(ctx, cluster, namespace)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -125,14 +135,20 @@ func deleteApps(ctx context.Context, cluster *kubernetes.Cluster, namespace stri
 	return nil
 }
 
-// deleteServices removes all provisioned services when a Namespace is deleted
+// deleteServices removes all provisioned services when a Namespace is deleted, together
+// with their backups
 func deleteServices(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
 	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	return kubeServiceClient.DeleteAll(ctx, namespace)
+	err = kubeServiceClient.DeleteAll(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	return kubeServiceClient.DeleteNamespaceBackups(ctx, namespace)
 }
 
 // deleteNamespaceFromUsers will delete the namespace from all the Users
@@ -0,0 +1,96 @@
+package namespace
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/events"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+)
+
+// Events handles the API endpoint GET /namespaces/:namespace/events
+// It streams the events of the namespace over a websocket, as JSON encoded models.Event,
+// until the client closes the connection. Only changes after the connection was made are
+// streamed, clients query the current state through the regular endpoints.
+// The connections to a namespace share a single set of informers.
+func (oc Controller) Events(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	watcher, err := events.NewWatcher(cluster, namespace)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	log.Info("upgrade to web socket")
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: application.CheckOriginFunc(viper.GetStringSlice("access-control-allow-origin")),
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+	defer conn.Close()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The client sends nothing. Reading detects when it closes the connection.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	eventChan := make(chan models.Event)
+	go func() {
+		defer cancel()
+		if err := watcher.Watch(watchCtx, eventChan); err != nil {
+			log.Error(err, "watching the namespace failed")
+		}
+	}()
+
+	log.Info("streaming begin")
+
+	for {
+		select {
+		case <-watchCtx.Done():
+			log.Info("streaming completed")
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Time{})
+			return
+		case event := <-eventChan:
+			msg, err := json.Marshal(event)
+			if err != nil {
+				log.Error(err, "encoding the event")
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				log.V(1).Error(err, "failed to write to websockets")
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package namespace
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// MemberAdd handles the API endpoint /namespaces/:namespace/members (POST)
+// It gives the user access to the namespace. Owners may also manage the members of the
+// namespace. Adding a member again changes its role.
+func (oc Controller) MemberAdd(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("MemberAdd")
+	namespace := c.Param("namespace")
+
+	var request models.NamespaceMemberRequest
+	err := c.BindJSON(&request)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+	if request.Username == "" {
+		return apierror.NewBadRequestError("name of the user to add not found")
+	}
+	if request.Role == "" {
+		request.Role = models.NamespaceRoleMember
+	}
+	if request.Role != models.NamespaceRoleMember && request.Role != models.NamespaceRoleOwner {
+		return apierror.NewBadRequestErrorf("unknown role %s, expected one of member, owner", request.Role)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apiErr := checkOwner(ctx, cluster, namespace)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	authService, err := auth.NewAuthServiceFromContext(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	logger.Info("adding member", "namespace", namespace, "user", request.Username, "role", request.Role)
+
+	err = authService.AddNamespaceToUser(ctx, request.Username, namespace)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return apierror.NewNotFoundError("user", request.Username)
+		}
+		return apierror.InternalError(err)
+	}
+
+	err = namespaces.SetOwner(ctx, cluster, namespace, request.Username, request.Role == models.NamespaceRoleOwner)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// MemberRemove handles the API endpoint /namespaces/:namespace/members/:username (DELETE)
+// It removes the access of the user to the namespace, and the ownership, if any.
+func (oc Controller) MemberRemove(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("MemberRemove")
+	namespace := c.Param("namespace")
+	username := c.Param("username")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apiErr := checkOwner(ctx, cluster, namespace)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	authService, err := auth.NewAuthServiceFromContext(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	logger.Info("removing member", "namespace", namespace, "user", username)
+
+	removed, err := authService.RemoveNamespaceFromUser(ctx, username, namespace)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return apierror.NewNotFoundError("user", username)
+		}
+		return apierror.InternalError(err)
+	}
+
+	space, err := namespaces.Get(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if space == nil {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+	if !removed && !space.IsOwner(username) {
+		return apierror.NewNotFoundError("namespace member", username)
+	}
+
+	err = namespaces.SetOwner(ctx, cluster, namespace, username, false)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// checkOwner returns an error if the user of the request is neither an admin nor an owner
+// of the namespace. The authorization middleware only checked the access.
+func checkOwner(ctx context.Context, cluster *kubernetes.Cluster, namespace string) apierror.APIErrors {
+	user := requestctx.User(ctx)
+	if user.Role == "admin" {
+		return nil
+	}
+
+	space, err := namespaces.Get(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if space == nil {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+	if !space.IsOwner(user.Username) {
+		return apierror.NewAPIError("user unauthorized", http.StatusForbidden).
+			WithDetailsf("only the owners of namespace %s manage its members", namespace)
+	}
+
+	return nil
+}
+
+// namespaceMembers returns the users with access to the namespace who do not own it.
+func namespaceMembers(ctx context.Context, namespace string, owners []string) ([]string, error) {
+	authService, err := auth.NewAuthServiceFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := authService.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := map[string]bool{}
+	for _, name := range owners {
+		owner[name] = true
+	}
+
+	members := []string{}
+	for _, user := range users {
+		if owner[user.Username] {
+			continue
+		}
+		for _, ns := range user.Namespaces {
+			if ns == namespace {
+				members = append(members, user.Username)
+				break
+			}
+		}
+	}
+
+	sort.Strings(members)
+	return members, nil
+}
@@ -0,0 +1,41 @@
+package namespace
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaUpdate handles the API endpoint PUT /namespaces/:namespace/quota
+// It replaces the quota of the namespace. The parts of the quota which are not set remove
+// the respective limits. The endpoint is restricted to admins, see AdminRoutes.
+func (oc Controller) QuotaUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var quota models.NamespaceQuota
+	err := c.BindJSON(&quota)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if err := namespaces.ValidateQuota(quota); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = namespaces.SetQuota(ctx, cluster, namespace, quota)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -0,0 +1,118 @@
+package namespace
+
+import (
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/registry"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegistryIndex handles the API endpoint GET /namespaces/:namespace/registries
+// It returns the container registries the namespace has pull credentials for.
+func (hc Controller) RegistryIndex(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	details, err := registry.PullCredentials(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	logins := models.RegistryLoginList{}
+	for _, credentials := range details.RegistryCredentials {
+		logins = append(logins, models.RegistryLogin{
+			URL:      credentials.URL,
+			Username: credentials.Username,
+		})
+	}
+	sort.Slice(logins, func(i, j int) bool {
+		return logins[i].URL < logins[j].URL
+	})
+
+	response.OKReturn(c, logins)
+	return nil
+}
+
+// RegistryLogin handles the API endpoint POST /namespaces/:namespace/registries
+// It stores the pull credentials for a container registry in the namespace. The
+// application workloads of the namespace use them to pull their images.
+func (hc Controller) RegistryLogin(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+
+	var request models.RegistryLoginRequest
+	err := c.BindJSON(&request)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if registry.NormalizeURL(request.URL) == "" {
+		return apierror.NewBadRequestError("registry url must not be empty")
+	}
+	if request.Username == "" || request.Password == "" {
+		return apierror.NewBadRequestError("username and password must not be empty")
+	}
+
+	log.Info("registry login", "namespace", namespace, "registry", request.URL, "username", request.Username)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = registry.Login(ctx, cluster, namespace, registry.RegistryCredentials{
+		URL:      request.URL,
+		Username: request.Username,
+		Password: request.Password,
+	})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// RegistryLogout handles the API endpoint DELETE /namespaces/:namespace/registries/:registry
+// It removes the pull credentials for the container registry from the namespace.
+func (hc Controller) RegistryLogout(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+	namespace := c.Param("namespace")
+	registryURL := c.Param("registry")
+
+	log.Info("registry logout", "namespace", namespace, "registry", registryURL)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	details, err := registry.PullCredentials(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if details.Lookup(registryURL) == nil {
+		return apierror.NewNotFoundError("registry login", registryURL)
+	}
+
+	err = registry.Logout(ctx, cluster, namespace, registryURL)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
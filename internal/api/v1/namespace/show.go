@@ -8,6 +8,7 @@ import (
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/services"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
@@ -40,6 +41,25 @@ func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	quota, err := namespaces.Quota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Report the usage only for limited namespaces.
+	var usage *models.NamespaceUsage
+	if quota != nil {
+		usage, err = namespaceUsage(ctx, cluster, namespace, len(appNames))
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	members, err := namespaceMembers(ctx, namespace, space.Owners)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
 	response.OKReturn(c, models.Namespace{
 		Meta: models.MetaLite{
 			Name:      namespace,
@@ -47,10 +67,37 @@ func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 		},
 		Apps:           appNames,
 		Configurations: configurationNames,
+		Quota:          quota,
+		Usage:          usage,
+		Owners:         space.Owners,
+		Members:        members,
 	})
 	return nil
 }
 
+func namespaceUsage(ctx context.Context, cluster *kubernetes.Cluster, namespace string, apps int) (*models.NamespaceUsage, error) {
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+	serviceList, err := kubeServiceClient.ListInNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	memory, cpu, err := namespaces.ResourceUsage(ctx, cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.NamespaceUsage{
+		Apps:     apps,
+		Services: len(serviceList),
+		Memory:   memory,
+		CPU:      cpu,
+	}, nil
+}
+
 func namespaceApps(ctx context.Context, cluster *kubernetes.Cluster, namespace string) ([]string, error) {
 	// Retrieve app references for namespace, and reduce to their names.
 	appRefs, err := application.ListAppRefs(ctx, cluster, namespace)
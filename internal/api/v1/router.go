@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/epinio/epinio/helpers/routes"
+	"github.com/epinio/epinio/internal/api/v1/admin"
 	"github.com/epinio/epinio/internal/api/v1/appchart"
 	"github.com/epinio/epinio/internal/api/v1/application"
 	"github.com/epinio/epinio/internal/api/v1/configuration"
@@ -26,6 +27,8 @@ const (
 	Root = "/api/v1"
 	// WsRoot is the url path prefix for all websocket API endpoints.
 	WsRoot = "/wapi/v1"
+	// HookRoot is the url path prefix for all webhook endpoints. These are not authenticated.
+	HookRoot = "/hooks/v1"
 )
 
 // APIActionFunc is matched by all actions. Actions can return a list of errors.
@@ -70,7 +73,13 @@ func put(path string, h gin.HandlerFunc) routes.Route {
 }
 
 // AdminRoutes is the list of restricted routes, only accessible by admins
-var AdminRoutes map[string]struct{} = map[string]struct{}{}
+var AdminRoutes map[string]struct{} = map[string]struct{}{
+	Root + "/admin/gc/images":                       {},
+	Root + "/admin/gc/blobs":                        {},
+	Root + "/admin/catalogservices":                 {},
+	Root + "/admin/catalogservices/:catalogservice": {},
+	Root + "/namespaces/:namespace/quota":           {},
+}
 
 var Routes = routes.NamedRoutes{
 	"Info":      get("/info", errorHandler(Info)),
@@ -94,6 +103,7 @@ var Routes = routes.NamedRoutes{
 	"AppUpdate":       patch("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Update)),
 	"AppUpload":       post("/namespaces/:namespace/applications/:app/store", errorHandler(application.Controller{}.Upload)), // See upload.go
 	"AppValidateCV":   get("/namespaces/:namespace/applications/:app/validate-cv", errorHandler(application.Controller{}.ValidateChartValues)),
+	"AppWebhook":      get("/namespaces/:namespace/applications/:app/webhook", errorHandler(application.Controller{}.Webhook)), // See webhook.go
 
 	"AppMatch":  get("/namespaces/:namespace/appsmatches/:pattern", errorHandler(application.Controller{}.Match)),
 	"AppMatch0": get("/namespaces/:namespace/appsmatches", errorHandler(application.Controller{}.Match)),
@@ -109,6 +119,11 @@ var Routes = routes.NamedRoutes{
 	"EnvShow":  get("/namespaces/:namespace/applications/:app/environment/:env", errorHandler(env.Controller{}.Show)),
 	"EnvUnset": delete("/namespaces/:namespace/applications/:app/environment/:env", errorHandler(env.Controller{}.Unset)),
 
+	// Build-only environment, seen by staging, not by the workload
+	"BuildEnvList":  get("/namespaces/:namespace/applications/:app/buildenvironment", errorHandler(env.Controller{}.BuildIndex)),
+	"BuildEnvSet":   post("/namespaces/:namespace/applications/:app/buildenvironment", errorHandler(env.Controller{}.BuildSet)),
+	"BuildEnvUnset": delete("/namespaces/:namespace/applications/:app/buildenvironment/:env", errorHandler(env.Controller{}.BuildUnset)),
+
 	// Bind and unbind configurations to/from applications, by means of configurationbindings in applications
 	"ConfigurationBindingCreate": post("/namespaces/:namespace/applications/:app/configurationbindings",
 		errorHandler(configurationbinding.Controller{}.Create)),
@@ -121,6 +136,18 @@ var Routes = routes.NamedRoutes{
 	"NamespaceDelete": delete("/namespaces/:namespace", errorHandler(namespace.Controller{}.Delete)),
 	"NamespaceShow":   get("/namespaces/:namespace", errorHandler(namespace.Controller{}.Show)),
 
+	// Quota of namespaces, managed by admins, see AdminRoutes
+	"NamespaceQuotaUpdate": put("/namespaces/:namespace/quota", errorHandler(namespace.Controller{}.QuotaUpdate)),
+
+	// Members of namespaces, managed by their owners
+	"NamespaceMemberAdd":    post("/namespaces/:namespace/members", errorHandler(namespace.Controller{}.MemberAdd)),
+	"NamespaceMemberRemove": delete("/namespaces/:namespace/members/:username", errorHandler(namespace.Controller{}.MemberRemove)),
+
+	// Pull credentials for container registries, per namespace
+	"RegistryLogins": get("/namespaces/:namespace/registries", errorHandler(namespace.Controller{}.RegistryIndex)),
+	"RegistryLogin":  post("/namespaces/:namespace/registries", errorHandler(namespace.Controller{}.RegistryLogin)),
+	"RegistryLogout": delete("/namespaces/:namespace/registries/:registry", errorHandler(namespace.Controller{}.RegistryLogout)),
+
 	// Note, the second registration catches calls with an empty pattern!
 	"NamespacesMatch":  get("/namespacematches/:pattern", errorHandler(namespace.Controller{}.Match)),
 	"NamespacesMatch0": get("/namespacematches", errorHandler(namespace.Controller{}.Match)),
@@ -156,7 +183,22 @@ var Routes = routes.NamedRoutes{
 	"ServiceList":        get("/namespaces/:namespace/services", errorHandler(service.Controller{}.List)),
 	"ServiceShow":        get("/namespaces/:namespace/services/:service", errorHandler(service.Controller{}.Show)),
 	"ServiceDelete":      delete("/namespaces/:namespace/services/:service", errorHandler(service.Controller{}.Delete)),
+	"ServiceUpdate":      patch("/namespaces/:namespace/services/:service", errorHandler(service.Controller{}.Update)),
 	"ServiceBatchDelete": delete("/namespaces/:namespace/services", errorHandler(service.Controller{}.Delete)),
+	"ServiceReady":       get("/namespaces/:namespace/services/:service/ready", errorHandler(service.Controller{}.Ready)),
+
+	"ServicePortForwardTarget": get("/namespaces/:namespace/services/:service/portforward/target", errorHandler(service.Controller{}.PortForwardTarget)),
+
+	"ServiceRotateCredentials": post("/namespaces/:namespace/services/:service/rotate-credentials", errorHandler(service.Controller{}.RotateCredentials)),
+
+	"ServiceShare":   post("/namespaces/:namespace/services/:service/shares", errorHandler(service.Controller{}.Share)),
+	"ServiceUnshare": delete("/namespaces/:namespace/services/:service/shares/:target", errorHandler(service.Controller{}.Unshare)),
+
+	// Service backups
+	"ServiceBackup":         post("/namespaces/:namespace/services/:service/backups", errorHandler(service.Controller{}.Backup)),
+	"ServiceBackups":        get("/namespaces/:namespace/services/:service/backups", errorHandler(service.Controller{}.Backups)),
+	"ServiceRestore":        post("/namespaces/:namespace/services/:service/backups/:backup/restore", errorHandler(service.Controller{}.Restore)),
+	"ServiceBackupSchedule": put("/namespaces/:namespace/services/:service/backupschedule", errorHandler(service.Controller{}.BackupSchedule)),
 
 	"ServiceMatch":  get("/namespaces/:namespace/servicesmatches/:pattern", errorHandler(service.Controller{}.Match)),
 	"ServiceMatch0": get("/namespaces/:namespace/servicesmatches", errorHandler(service.Controller{}.Match)),
@@ -171,6 +213,14 @@ var Routes = routes.NamedRoutes{
 		"/namespaces/:namespace/services/:service/unbind",
 		errorHandler(service.Controller{}.Unbind)),
 
+	// Maintenance, see AdminRoutes
+	"ImageGC":   post("/admin/gc/images", errorHandler(admin.Controller{}.ImageGC)),
+	"BlobPrune": post("/admin/gc/blobs", errorHandler(admin.Controller{}.BlobPrune)),
+
+	"CatalogServiceAdd":    post("/admin/catalogservices", errorHandler(admin.Controller{}.CatalogServiceAdd)),
+	"CatalogServiceUpdate": put("/admin/catalogservices/:catalogservice", errorHandler(admin.Controller{}.CatalogServiceUpdate)),
+	"CatalogServiceDelete": delete("/admin/catalogservices/:catalogservice", errorHandler(admin.Controller{}.CatalogServiceDelete)),
+
 	// App charts
 	"ChartList":   get("/appcharts", errorHandler(appchart.Controller{}.Index)),
 	"ChartMatch":  get("/appchartsmatch/:pattern", errorHandler(appchart.Controller{}.Match)),
@@ -180,9 +230,20 @@ var Routes = routes.NamedRoutes{
 
 var WsRoutes = routes.NamedRoutes{
 	"AppExec":        get("/namespaces/:namespace/applications/:app/exec", errorHandler(application.Controller{}.Exec)),
+	"AppDebug":       get("/namespaces/:namespace/applications/:app/debug", errorHandler(application.Controller{}.Debug)),
 	"AppPortForward": get("/namespaces/:namespace/applications/:app/portforward", errorHandler(application.Controller{}.PortForward)),
 	"AppLogs":        get("/namespaces/:namespace/applications/:app/logs", application.Controller{}.Logs),
 	"StagingLogs":    get("/namespaces/:namespace/staging/:stage_id/logs", application.Controller{}.Logs),
+
+	"NamespaceEvents": get("/namespaces/:namespace/events", namespace.Controller{}.Events),
+
+	"ServicePortForward": get("/namespaces/:namespace/services/:service/portforward", errorHandler(service.Controller{}.PortForward)),
+}
+
+// HookRoutes are the endpoints called by external services. They are not authenticated.
+// The handlers are responsible for verifying the requests. See webhook.go.
+var HookRoutes = routes.NamedRoutes{
+	"AppGitPush": post("/namespaces/:namespace/applications/:app/git", errorHandler(application.Controller{}.GitPush)),
 }
 
 // Lemon extends the specified router with the methods and urls
@@ -200,3 +261,11 @@ func Spice(router *gin.RouterGroup) {
 		router.Handle(r.Method, r.Path, r.Handler)
 	}
 }
+
+// Zest extends the specified router with the methods and urls
+// handling the webhook endpoints
+func Zest(router *gin.RouterGroup) {
+	for _, r := range HookRoutes {
+		router.Handle(r.Method, r.Path, r.Handler)
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Backup handles the API end point /namespaces/:namespace/services/:service/backups (POST)
+// It backs up the data of the named service into the S3 storage, and returns the backup.
+func (ctr Controller) Backup(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Backup")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apierr := managedCatalogService(ctx, kubeServiceClient, namespace, serviceName)
+	if apierr != nil {
+		return apierr
+	}
+	if catalogService.Backup == nil {
+		return apierror.NewBadRequestError("the catalog service does not support backups").
+			WithDetailsf("catalog service: %s", catalogService.Meta.Name)
+	}
+
+	logger.Info("backing up service", "namespace", namespace, "service", serviceName)
+
+	backup, err := kubeServiceClient.Backup(ctx, namespace, serviceName, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, backup)
+	return nil
+}
+
+// Backups handles the API end point /namespaces/:namespace/services/:service/backups (GET)
+// It returns the backups of the named service, newest first.
+func (ctr Controller) Backups(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Note: No check for the existence of the service. The backups of a deleted service
+	// are kept, and remain accessible.
+
+	backups, err := kubeServiceClient.Backups(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, backups)
+	return nil
+}
+
+// Restore handles the API end point /namespaces/:namespace/services/:service/backups/:backup/restore (POST)
+// It restores the data of the named service from the backup.
+func (ctr Controller) Restore(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Restore")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	backupID := c.Param("backup")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apierr := managedCatalogService(ctx, kubeServiceClient, namespace, serviceName)
+	if apierr != nil {
+		return apierr
+	}
+	if catalogService.Restore == nil {
+		return apierror.NewBadRequestError("the catalog service does not support restoring backups").
+			WithDetailsf("catalog service: %s", catalogService.Meta.Name)
+	}
+
+	backups, err := kubeServiceClient.Backups(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	found := false
+	for _, backup := range backups {
+		if backup.ID == backupID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return apierror.NewNotFoundError("backup", backupID)
+	}
+
+	logger.Info("restoring service", "namespace", namespace, "service", serviceName, "backup", backupID)
+
+	err = kubeServiceClient.Restore(ctx, namespace, serviceName, backupID, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// BackupSchedule handles the API end point /namespaces/:namespace/services/:service/backupschedule (PUT)
+// It sets the schedule of the backups of the named service.
+func (ctr Controller) BackupSchedule(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	var scheduleRequest models.ServiceBackupScheduleRequest
+	err := c.BindJSON(&scheduleRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	var interval time.Duration
+	if scheduleRequest.Interval != "" {
+		interval, err = time.ParseDuration(scheduleRequest.Interval)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+		if interval < 0 {
+			return apierror.NewBadRequestError("the backup interval must not be negative")
+		}
+	}
+	if scheduleRequest.Keep < 0 {
+		return apierror.NewBadRequestError("the number of backups to keep must not be negative")
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apierr := managedCatalogService(ctx, kubeServiceClient, namespace, serviceName)
+	if apierr != nil {
+		return apierr
+	}
+	if interval > 0 && catalogService.Backup == nil {
+		return apierror.NewBadRequestError("the catalog service does not support backups").
+			WithDetailsf("catalog service: %s", catalogService.Meta.Name)
+	}
+
+	err = kubeServiceClient.ScheduleBackups(ctx, namespace, serviceName, interval, scheduleRequest.Keep)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
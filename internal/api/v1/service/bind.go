@@ -75,7 +75,7 @@ func (ctr Controller) Bind(c *gin.Context) apierror.APIErrors {
 	logger.Info("binding service configuration")
 
 	_, errors := configurationbinding.CreateConfigurationBinding(
-		ctx, cluster, namespace, *app, configurationNames,
+		ctx, cluster, namespace, *app, configurationNames, application.BindingOptions{},
 	)
 
 	if errors != nil {
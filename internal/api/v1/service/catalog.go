@@ -1,12 +1,16 @@
 package service
 
 import (
+	"context"
+
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/services"
 	"github.com/gin-gonic/gin"
 
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -28,7 +32,7 @@ func (ctr Controller) Catalog(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	response.OKReturn(c, serviceList)
+	response.OKReturn(c, visibleCatalogServices(ctx, serviceList))
 	return nil
 }
 
@@ -55,6 +59,38 @@ func (ctr Controller) CatalogShow(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	if !catalogServiceVisible(ctx, service) {
+		return apierror.NewNotFoundError("service instance", serviceName)
+	}
+
 	response.OKReturn(c, service)
 	return nil
 }
+
+// visibleCatalogServices returns the catalog services available to the user of the
+// request, see catalogServiceVisible.
+func visibleCatalogServices(ctx context.Context, serviceList []*models.CatalogService) []*models.CatalogService {
+	visible := []*models.CatalogService{}
+	for _, service := range serviceList {
+		if catalogServiceVisible(ctx, service) {
+			visible = append(visible, service)
+		}
+	}
+	return visible
+}
+
+// catalogServiceVisible returns true if the catalog service is available to the user of
+// the request. Admins see all catalog services, users only those available in at least
+// one of their namespaces.
+func catalogServiceVisible(ctx context.Context, service *models.CatalogService) bool {
+	user := requestctx.User(ctx)
+	if user.Role == "admin" || len(service.Namespaces) == 0 {
+		return true
+	}
+	for _, namespace := range user.Namespaces {
+		if service.AvailableIn(namespace) {
+			return true
+		}
+	}
+	return false
+}
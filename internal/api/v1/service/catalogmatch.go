@@ -43,7 +43,7 @@ func (oc Controller) CatalogMatch(c *gin.Context) apierror.APIErrors {
 
 	log.Info("match prefix", "pattern", prefix)
 	matches := []string{}
-	for _, service := range serviceList {
+	for _, service := range visibleCatalogServices(ctx, serviceList) {
 		if strings.HasPrefix(service.Meta.Name, prefix) {
 			matches = append(matches, service.Meta.Name)
 		}
@@ -3,6 +3,8 @@ package service
 import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/services"
 	"github.com/gin-gonic/gin"
 
@@ -40,6 +42,21 @@ func (ctr Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.ServiceAlreadyKnown(createRequest.Name)
 	}
 
+	// Enforce the quota of the namespace, if any.
+	quota, err := namespaces.Quota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if quota != nil && quota.MaxServices > 0 {
+		serviceList, err := kubeServiceClient.ListInNamespace(ctx, namespace)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		if len(serviceList) >= quota.MaxServices {
+			return apierror.NamespaceQuotaExceeded(namespace, "services", quota.MaxServices)
+		}
+	}
+
 	// Ensure that the requested catalog service does exist
 	catalogService, err := kubeServiceClient.GetCatalogService(ctx, createRequest.CatalogService)
 	if err != nil {
@@ -50,8 +67,27 @@ func (ctr Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	// Ensure that the catalog service is available in the namespace
+	if !catalogService.AvailableIn(namespace) {
+		return apierror.NewBadRequestErrorf("catalog service %s is not available in namespace %s",
+			createRequest.CatalogService, namespace)
+	}
+
+	// Ensure that the requested settings are declared by the catalog service, and valid
+	if len(createRequest.Settings) > 0 {
+		issues := application.ValidateCV(createRequest.Settings, catalogService.Settings)
+		if issues != nil {
+			var apiIssues []apierror.APIError
+			for _, err := range issues {
+				apiIssues = append(apiIssues, apierror.NewBadRequestError(err.Error()))
+			}
+
+			return apierror.NewMultiError(apiIssues)
+		}
+	}
+
 	// Now we can (attempt to) create the desired service
-	err = kubeServiceClient.Create(ctx, namespace, createRequest.Name, *catalogService)
+	err = kubeServiceClient.Create(ctx, namespace, createRequest.Name, createRequest.Settings, *catalogService)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
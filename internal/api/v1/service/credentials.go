@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RotateCredentials handles the API end point /namespaces/:namespace/services/:service/rotate-credentials (POST)
+// It sets the credentials of the named service to new values, and restarts the
+// applications bound to the service, to pick up the new values. The restart is rolling,
+// like for `epinio app restart`. The configurations projected by sharing the service are
+// refreshed, and the applications bound to them restarted as well.
+func (ctr Controller) RotateCredentials(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("RotateCredentials")
+	username := requestctx.User(ctx).Username
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apierr := managedCatalogService(ctx, kubeServiceClient, namespace, serviceName)
+	if apierr != nil {
+		return apierr
+	}
+	if catalogService.Credentials == nil {
+		return apierror.NewBadRequestError("the catalog service does not support the rotation of credentials").
+			WithDetailsf("catalog service: %s", catalogService.Meta.Name)
+	}
+
+	logger.Info("rotating credentials", "namespace", namespace, "service", serviceName)
+
+	err = kubeServiceClient.RotateCredentials(ctx, namespace, serviceName, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appNames, err := application.ServicesBoundAppsNamesFor(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	restarted, apiErr := restartApps(ctx, cluster, logger, username, namespace, appNames)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	// The configurations projected into other namespaces follow the service. The apps
+	// bound to them are reported with their namespace.
+	service, apiErr := GetService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	for _, target := range service.Shares {
+		logger.Info("refreshing share", "namespace", namespace, "service", serviceName, "target", target)
+
+		projected, err := configurations.ShareServiceSecrets(ctx, cluster, service, target, username)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		sharedAppNames := []string{}
+		for _, configurationName := range projected {
+			bound, err := application.BoundAppsNamesFor(ctx, cluster, target, configurationName)
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+			sharedAppNames = append(sharedAppNames, bound...)
+		}
+
+		sharedRestarted, apiErr := restartApps(ctx, cluster, logger, username, target,
+			helpers.UniqueStrings(sharedAppNames))
+		if apiErr != nil {
+			return apiErr
+		}
+		for _, appName := range sharedRestarted {
+			restarted = append(restarted, target+"/"+appName)
+		}
+	}
+
+	response.OKReturn(c, models.ServiceRotateCredentialsResponse{
+		RestartedApps: restarted,
+	})
+	return nil
+}
+
+// restartApps restarts the running applications of the namespace, and returns their names.
+func restartApps(ctx context.Context, cluster *kubernetes.Cluster, logger logr.Logger,
+	username, namespace string, appNames []string) ([]string, apierror.APIErrors) {
+
+	restarted := []string{}
+	for _, appName := range appNames {
+		app, err := application.Lookup(ctx, cluster, namespace, appName)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+		if app == nil || app.Workload == nil {
+			// Not running, picks up the new values when deployed
+			continue
+		}
+
+		logger.Info("restarting app", "namespace", namespace, "app", appName)
+
+		nano := time.Now().UnixNano()
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
+		if apierr != nil {
+			return nil, apierr
+		}
+
+		restarted = append(restarted, appName)
+	}
+
+	return restarted, nil
+}
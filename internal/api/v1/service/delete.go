@@ -66,6 +66,27 @@ func (ctr Controller) Delete(c *gin.Context) apierror.APIErrors {
 		theServices = append(theServices, service)
 	}
 
+	// Shared services are only deleted when forced. Then their shares are removed first,
+	// unbinding the projected configurations from the applications of the other namespaces.
+
+	for _, service := range theServices {
+		if len(service.Shares) == 0 {
+			continue
+		}
+		if !deleteRequest.Force {
+			// [SHARED] keep in sync with same marker in the client
+			return apierror.NewBadRequestErrorf("service %s is shared", service.Meta.Name).
+				WithDetailsf("shared with the namespaces %s", strings.Join(service.Shares, ", "))
+		}
+
+		for _, target := range service.Shares {
+			_, apiErr := unshareService(ctx, cluster, logger, username, service, target)
+			if apiErr != nil {
+				return apiErr
+			}
+		}
+	}
+
 	// Collect the configurations per service, and record per bound app the service/config
 	// information
 	//
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// PortForwardTarget handles the API endpoint GET /namespaces/:namespace/services/:service/portforward/target
+// It returns the running pod of the service the ports are forwarded to, and the ports of
+// the service's kubernetes services mapped to the ports of that pod. Kubernetes forwards
+// to pods, not to kube Services, thus clients translate the requested service ports into
+// pod ports before forwarding.
+func (ctr Controller) PortForwardTarget(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	podName, ports, apiErr := portForwardTarget(ctx, cluster, c.Param("namespace"), c.Param("service"), c.Query("instance"))
+	if apiErr != nil {
+		return apiErr
+	}
+
+	response.OKReturn(c, models.ServicePortForwardTargetResponse{
+		Instance: podName,
+		Ports:    ports,
+	})
+	return nil
+}
+
+// PortForward forwards the ports of the request to a running pod behind the kubernetes
+// services of the service instance, the one named by the `instance` query parameter if
+// present. The forwarded ports are the ports of the pod, see PortForwardTarget.
+func (ctr Controller) PortForward(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	podName, _, apiErr := portForwardTarget(ctx, cluster, namespace, c.Param("service"), c.Query("instance"))
+	if apiErr != nil {
+		return apiErr
+	}
+
+	cluster.ProxyPortForward(c.Writer, c.Request, namespace, podName)
+
+	return nil
+}
+
+// portForwardTarget returns the pod of the service to forward to, and the mapping of the
+// service ports to the ports of that pod.
+func portForwardTarget(ctx context.Context, cluster *kubernetes.Cluster, namespace, serviceName, instance string) (string, map[int32]int32, apierror.APIErrors) {
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return "", nil, apierror.InternalError(err)
+	}
+
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return "", nil, apierror.InternalError(err)
+	}
+
+	if service == nil {
+		return "", nil, apierror.ServiceIsNotKnown(serviceName)
+	}
+
+	podName, ports, err := services.GetPortForwardTarget(ctx,
+		cluster.Kubectl.CoreV1().Services(namespace),
+		cluster.Kubectl.CoreV1().Pods(namespace),
+		serviceName, instance)
+	if err != nil {
+		return "", nil, apierror.InternalError(err)
+	}
+	if podName == "" {
+		return "", nil, apierror.NewAPIError("couldn't find any running Pods of the service to connect to", http.StatusBadRequest)
+	}
+
+	return podName, ports, nil
+}
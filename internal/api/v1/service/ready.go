@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Ready handles the API endpoint GET /namespaces/:namespace/services/:service/ready
+//
+// It waits for the provisioning of the specified service to complete, before it returns.
+// It returns with an error if the provisioning failed, or did not complete within
+// `duration.ToDeployment()`.
+func (ctr Controller) Ready(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if service == nil {
+		return apierror.ServiceIsNotKnown(serviceName)
+	}
+
+	err = wait.PollImmediate(time.Second, duration.ToDeployment(), func() (bool, error) {
+		current, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+		if err != nil {
+			return false, err
+		}
+		if current == nil {
+			return false, apierror.ServiceIsNotKnown(serviceName)
+		}
+		service = current
+		return service.Phase == models.ServicePhaseReady || service.Phase == models.ServicePhaseFailed, nil
+	})
+	if apiErr, ok := err.(apierror.APIError); ok {
+		return apiErr
+	}
+	if err != nil && err != wait.ErrWaitTimeout {
+		return apierror.InternalError(err)
+	}
+
+	switch service.Phase {
+	case models.ServicePhaseReady:
+		response.OK(c)
+		return nil
+	case models.ServicePhaseFailed:
+		return apierror.NewBadRequestErrorf("provisioning of service %s failed", serviceName).
+			WithDetails(strings.Join(append([]string{service.Reason}, service.Warnings...), "\n"))
+	}
+
+	return apierror.NewAPIError(fmt.Sprintf("service %s is still %s", serviceName, service.Phase),
+		http.StatusRequestTimeout)
+}
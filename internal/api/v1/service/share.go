@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/configurationbinding"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Share handles the API end point /namespaces/:namespace/services/:service/shares (POST)
+// It projects the configurations of the named service into the requested namespace, as
+// read-only configurations, and records the share. Sharing again refreshes the projected
+// configurations.
+func (ctr Controller) Share(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Share")
+	user := requestctx.User(ctx)
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	var shareRequest models.ServiceShareRequest
+	err := c.BindJSON(&shareRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	target := shareRequest.Namespace
+	if target == "" {
+		return apierror.NewBadRequestError("the namespace to share the service with is missing")
+	}
+	if target == namespace {
+		return apierror.NewBadRequestErrorf("the service %s cannot be shared with its own namespace", serviceName)
+	}
+
+	// The middleware only checked the service's namespace.
+	if user.Role != "admin" && !containsNamespace(user.Namespaces, target) {
+		return apierror.NewAPIError("user unauthorized", http.StatusForbidden).
+			WithDetailsf("namespace %s", target)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, target)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.NamespaceIsNotKnown(target)
+	}
+
+	service, apiErr := GetService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+	if service.ManagedByHelmController {
+		return apierror.NewBadRequestErrorf("the service %s does not support sharing", serviceName).
+			WithDetails("it was created by an older version of Epinio")
+	}
+
+	apiErr = ValidateService(ctx, cluster, logger, service)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	// See Bind for the labeling of the service's secrets as configurations.
+	_, err = configurations.LabelServiceSecrets(ctx, cluster, service)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	logger.Info("sharing service", "namespace", namespace, "service", serviceName, "target", target)
+
+	projected, err := configurations.ShareServiceSecrets(ctx, cluster, service, target, user.Username)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = kubeServiceClient.RecordShare(ctx, namespace, serviceName, target, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.ServiceShareResponse{
+		Configurations: projected,
+	})
+	return nil
+}
+
+// Unshare handles the API end point /namespaces/:namespace/services/:service/shares/:target (DELETE)
+// It removes the configurations projected into the target namespace by sharing the named
+// service, unbinding them from the applications of that namespace first.
+func (ctr Controller) Unshare(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Unshare")
+	username := requestctx.User(ctx).Username
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	target := c.Param("target")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+	if !containsNamespace(service.Shares, target) {
+		return apierror.NewNotFoundError("service share", target).
+			WithDetailsf("the service %s is not shared with the namespace %s", serviceName, target)
+	}
+
+	logger.Info("unsharing service", "namespace", namespace, "service", serviceName, "target", target)
+
+	unboundApps, apiErr := unshareService(ctx, cluster, logger, username, service, target)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	response.OKReturn(c, models.ServiceUnshareResponse{
+		UnboundApps: unboundApps,
+	})
+	return nil
+}
+
+// unshareService unbinds the configurations projected into the target namespace from the
+// applications there, deletes them, and removes the share from the service. It returns
+// the names of the unbound applications.
+func unshareService(ctx context.Context, cluster *kubernetes.Cluster, logger logr.Logger,
+	username string, service *models.Service, target string) ([]string, apierror.APIErrors) {
+
+	projected, err := configurations.ForServiceShare(ctx, cluster, service, target)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	// Collect the projected configurations per bound app, to unbind them in one go.
+	appConfigurations := map[string][]string{}
+	unboundApps := []string{}
+	for _, secret := range projected {
+		bound, err := application.BoundAppsNamesFor(ctx, cluster, target, secret.Name)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+
+		for _, appName := range bound {
+			if _, ok := appConfigurations[appName]; !ok {
+				unboundApps = append(unboundApps, appName)
+			}
+			appConfigurations[appName] = append(appConfigurations[appName], secret.Name)
+		}
+	}
+
+	for _, appName := range unboundApps {
+		logger.Info("unbinding shared configurations", "namespace", target, "app", appName)
+
+		apiErr := configurationbinding.DeleteBinding(ctx, cluster, target, appName, username,
+			appConfigurations[appName])
+		if apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	for _, secret := range projected {
+		err := cluster.DeleteSecret(ctx, target, secret.Name)
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	err = kubeServiceClient.RecordShare(ctx, service.Meta.Namespace, service.Meta.Name, target, false)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	return unboundApps, nil
+}
+
+// UnshareNamespace removes the shares involving the namespace, before its deletion. The
+// services of the namespace are unshared from all their targets, unbinding the projected
+// configurations from the applications there. The shares of services elsewhere with the
+// namespace are dropped, their projected configurations go away with the namespace.
+func UnshareNamespace(ctx context.Context, cluster *kubernetes.Cluster, logger logr.Logger,
+	username, namespace string) apierror.APIErrors {
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	serviceList, err := kubeServiceClient.ListAll(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	for _, service := range serviceList {
+		service := service
+
+		if service.Meta.Namespace == namespace {
+			for _, target := range service.Shares {
+				logger.Info("unsharing service", "namespace", namespace,
+					"service", service.Meta.Name, "target", target)
+
+				_, apiErr := unshareService(ctx, cluster, logger, username, &service, target)
+				if apiErr != nil {
+					return apiErr
+				}
+			}
+			continue
+		}
+
+		if containsNamespace(service.Shares, namespace) {
+			logger.Info("dropping service share", "namespace", service.Meta.Namespace,
+				"service", service.Meta.Name, "target", namespace)
+
+			err = kubeServiceClient.RecordShare(ctx, service.Meta.Namespace, service.Meta.Name, namespace, false)
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// containsNamespace returns true if the namespace is in the list.
+func containsNamespace(list []string, namespace string) bool {
+	for _, ns := range list {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Update handles the API end point /namespaces/:namespace/services/:service (PATCH)
+// It changes the settings of the named service, and/or upgrades it to another chart
+// version, and reports the change. A dry run only reports the change.
+func (ctr Controller) Update(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Update")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	var updateRequest models.ServiceUpdateRequest
+	err := c.BindJSON(&updateRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if updateRequest.ChartVersion != "" && !updateRequest.Upgrade {
+		return apierror.NewBadRequestError("a chart version requires an upgrade")
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apierr := managedCatalogService(ctx, kubeServiceClient, namespace, serviceName)
+	if apierr != nil {
+		return apierr
+	}
+
+	// Ensure that the requested settings are declared by the catalog service, and valid
+	if len(updateRequest.Settings) > 0 {
+		issues := application.ValidateCV(updateRequest.Settings, catalogService.Settings)
+		if issues != nil {
+			var apiIssues []apierror.APIError
+			for _, err := range issues {
+				apiIssues = append(apiIssues, apierror.NewBadRequestError(err.Error()))
+			}
+
+			return apierror.NewMultiError(apiIssues)
+		}
+	}
+
+	// Keep the deployed chart, except when upgrading
+	chartVersion := ""
+	if updateRequest.Upgrade {
+		chartVersion = updateRequest.ChartVersion
+		if chartVersion == "" {
+			chartVersion = catalogService.ChartVersion
+		}
+	}
+
+	logger.Info("updating service", "namespace", namespace, "service", serviceName,
+		"chartVersion", chartVersion, "dryrun", updateRequest.DryRun)
+
+	change, err := kubeServiceClient.Update(ctx, namespace, serviceName,
+		updateRequest.Settings, chartVersion, *catalogService, updateRequest.DryRun)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, change)
+	return nil
+}
+
+// managedCatalogService returns the catalog service of the named service. Services managed
+// by the helm controller are rejected, as Epinio does not manage their helm release.
+func managedCatalogService(ctx context.Context, kubeServiceClient *services.ServiceClient, namespace, serviceName string) (*models.CatalogService, apierror.APIErrors) {
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+	if service == nil {
+		return nil, apierror.ServiceIsNotKnown(serviceName)
+	}
+	if service.ManagedByHelmController {
+		return nil, apierror.NewBadRequestError("unable to change a service managed by the helm controller, recreate it")
+	}
+
+	catalogServiceName := strings.TrimPrefix(service.CatalogService, "[Missing] ")
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, catalogServiceName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, apierror.NewBadRequestError(err.Error()).
+				WithDetailsf("catalog service %s not found", catalogServiceName)
+		}
+		return nil, apierror.InternalError(err)
+	}
+
+	return catalogService, nil
+}
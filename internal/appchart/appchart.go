@@ -109,9 +109,35 @@ func toChart(chart *unstructured.Unstructured) (*models.AppChartFull, error) {
 		return nil, errors.New("spec values should be string")
 	}
 
-	theSettings, _, err := unstructured.NestedMap(chart.UnstructuredContent(), "spec", "settings")
+	settings, err := Settings(chart.UnstructuredContent(), "spec", "settings")
 	if err != nil {
-		return nil, errors.New("spec settings should be string")
+		return nil, err
+	}
+
+	createdAt := chart.GetCreationTimestamp()
+
+	return &models.AppChartFull{
+		AppChart: models.AppChart{
+			Meta: models.MetaLite{
+				Name:      name,
+				CreatedAt: createdAt,
+			},
+			Description:      description,
+			ShortDescription: short,
+			HelmChart:        helmChart,
+			HelmRepo:         helmRepo,
+			Settings:         settings,
+		},
+		Values: theValues,
+	}, nil
+}
+
+// Settings decodes the declarations of the user settings found in the object at the
+// specified path. Catalog services declare their settings in the same way as app charts.
+func Settings(object map[string]interface{}, fields ...string) (map[string]models.AppChartSetting, error) {
+	theSettings, _, err := unstructured.NestedMap(object, fields...)
+	if err != nil {
+		return nil, errors.New("spec settings should be map")
 	}
 
 	settings := make(map[string]models.AppChartSetting)
@@ -141,20 +167,5 @@ func toChart(chart *unstructured.Unstructured) (*models.AppChartFull, error) {
 		}
 	}
 
-	createdAt := chart.GetCreationTimestamp()
-
-	return &models.AppChartFull{
-		AppChart: models.AppChart{
-			Meta: models.MetaLite{
-				Name:      name,
-				CreatedAt: createdAt,
-			},
-			Description:      description,
-			ShortDescription: short,
-			HelmChart:        helmChart,
-			HelmRepo:         helmRepo,
-			Settings:         settings,
-		},
-		Values: theValues,
-	}, nil
+	return settings, nil
 }
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// BuildEnvironment returns the build-only environment variables and their values which are set
+// on the named application by users. These variables are only provided to staging, never to the
+// running workload.
+func BuildEnvironment(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (models.EnvVariableMap, error) {
+	evSecret, err := buildEnvLoad(ctx, cluster, appRef)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.EnvVariableMap{}
+	for name, value := range evSecret.Data {
+		result[name] = string(value)
+	}
+
+	return result, nil
+}
+
+// BuildEnvironmentSet adds or modifies the specified build-only environment variables for the
+// named application. When the function returns the variables will have the specified values.
+// As the variables are used by staging only the workload is not restarted. The next staging
+// will pick the changes up.
+func BuildEnvironmentSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, assignments models.EnvVariableMap, replace bool) error {
+	return buildEnvUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret) {
+		// Replacement is adding to a clear structure
+		if replace {
+			evSecret.Data = make(map[string][]byte)
+		}
+		for name, value := range assignments {
+			evSecret.Data[name] = []byte(value)
+		}
+	})
+}
+
+// BuildEnvironmentUnset removes the specified build-only environment variable from the named
+// application. When the function returns the variable will be gone.
+func BuildEnvironmentUnset(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, varName string) error {
+	return buildEnvUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret) {
+		delete(evSecret.Data, varName)
+	})
+}
+
+// buildEnvUpdate is the helper for the public functions encapsulating the read/modify/write
+// cycle necessary to update the application's kube resource holding the application's
+// build-only environment.
+func buildEnvUpdate(ctx context.Context, cluster *kubernetes.Cluster,
+	appRef models.AppRef, modifyEnvironment func(*v1.Secret)) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		evSecret, err := buildEnvLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if evSecret.Data == nil {
+			evSecret.Data = make(map[string][]byte)
+		}
+
+		modifyEnvironment(evSecret)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, evSecret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// buildEnvLoad locates and returns the kube secret storing the referenced application's
+// build-only environment. If necessary it creates that secret.
+func buildEnvLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*v1.Secret, error) {
+	secretName := appRef.MakeBuildEnvSecretName()
+	return loadOrCreateSecret(ctx, cluster, appRef, secretName, "build-environment")
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -176,7 +177,7 @@ func BoundConfigurationNames(ctx context.Context, cluster *kubernetes.Cluster, a
 
 // BoundConfigurationsSet replaces or adds the specified configuration names to the named application.
 // When the function returns the configuration set will be extended.
-// Adding a known configuration is a no-op.
+// Adding a known configuration is a no-op, keeping the options of the binding.
 func BoundConfigurationsSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, configurationNames []string, replace bool) error {
 	return configUpdate(ctx, cluster, appRef, func(configSecret *v1.Secret) {
 		// Replacement is adding to a clear structure
@@ -184,11 +185,84 @@ func BoundConfigurationsSet(ctx context.Context, cluster *kubernetes.Cluster, ap
 			configSecret.Data = make(map[string][]byte)
 		}
 		for _, configurationName := range configurationNames {
-			configSecret.Data[configurationName] = nil
+			if _, ok := configSecret.Data[configurationName]; !ok {
+				configSecret.Data[configurationName] = nil
+			}
+		}
+	})
+}
+
+// BindingOptions are the options of the binding of a configuration to an application. They
+// are stored as JSON in the value of the binding. The value of a binding without options is
+// empty.
+type BindingOptions struct {
+	Restart models.ConfigurationRestartPolicy `json:"restart,omitempty"` // Overrides the policy of the configuration
+	Env     *models.ConfigurationEnvMapping   `json:"env,omitempty"`     // Keys exposed as environment variables
+}
+
+// decodeBindingOptions returns the options stored in the value of a binding. Undecodable
+// values are treated as no options.
+func decodeBindingOptions(value []byte) BindingOptions {
+	options := BindingOptions{}
+	if len(value) > 0 {
+		_ = json.Unmarshal(value, &options)
+	}
+	return options
+}
+
+// BoundConfigurationsOptionsSet sets the options of the bindings of the specified
+// configurations to the named application. Only the options given are changed, the others
+// are kept. Configurations not bound to the application are ignored.
+func BoundConfigurationsOptionsSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, configurationNames []string, options BindingOptions) error {
+	return configUpdate(ctx, cluster, appRef, func(configSecret *v1.Secret) {
+		for _, configurationName := range configurationNames {
+			value, ok := configSecret.Data[configurationName]
+			if !ok {
+				continue
+			}
+
+			current := decodeBindingOptions(value)
+			if options.Restart != "" {
+				current.Restart = options.Restart
+			}
+			if options.Env != nil {
+				current.Env = options.Env
+			}
+
+			// Marshalling a plain struct of strings cannot fail.
+			encoded, _ := json.Marshal(current)
+			configSecret.Data[configurationName] = encoded
 		}
 	})
 }
 
+// BoundConfigurationsOptions returns the options of the bindings of the configurations to
+// the named application, keyed by configuration name.
+func BoundConfigurationsOptions(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (map[string]BindingOptions, error) {
+	configSecret, err := configLoad(ctx, cluster, appRef)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]BindingOptions{}
+	for name, value := range configSecret.Data {
+		result[name] = decodeBindingOptions(value)
+	}
+
+	return result, nil
+}
+
+// BoundConfigurationRestart returns the restart policy of the binding of the configuration
+// to the named application. It is empty when the binding has no policy of its own.
+func BoundConfigurationRestart(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, configurationName string) (models.ConfigurationRestartPolicy, error) {
+	configSecret, err := configLoad(ctx, cluster, appRef)
+	if err != nil {
+		return "", err
+	}
+
+	return decodeBindingOptions(configSecret.Data[configurationName]).Restart, nil
+}
+
 // BoundConfigurationsUnset removes the specified configuration name from the named application.
 // When the function returns the configuration set will be shrunk.
 // Removing an unknown configuration is a no-op.
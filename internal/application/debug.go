@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/helpers/randstr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// DefaultDebugImage is the image of debug containers when none is specified.
+const DefaultDebugImage = "busybox"
+
+// AddDebugContainer adds an ephemeral container running the image to the pod, and returns
+// its name. The container targets the named container of the pod, i.e. shares its process
+// namespace, and runs the default command of the image with stdin and TTY, to be attached
+// to. Ephemeral containers cannot be removed, they stay in the pod, terminated, after
+// their command exits.
+func AddDebugContainer(ctx context.Context, pods v1.PodInterface, podName, targetContainer, image string) (string, error) {
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "fetching the pod")
+	}
+
+	suffix, err := randstr.Hex16()
+	if err != nil {
+		return "", errors.Wrap(err, "generating the container name")
+	}
+	name := "debugger-" + suffix[:5]
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: targetContainer,
+	})
+
+	_, err = pods.UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "adding the debug container")
+	}
+
+	return name, nil
+}
+
+// WaitForDebugContainer waits until the named ephemeral container of the pod is running.
+// It is an error for the container to terminate before that.
+func WaitForDebugContainer(ctx context.Context, pods v1.PodInterface, podName, name string, timeout time.Duration) error {
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != name {
+				continue
+			}
+			if status.State.Terminated != nil {
+				return false, fmt.Errorf("debug container terminated: %s %s",
+					status.State.Terminated.Reason, status.State.Terminated.Message)
+			}
+			if waiting := status.State.Waiting; waiting != nil {
+				switch waiting.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+					return false, fmt.Errorf("debug container image: %s %s",
+						waiting.Reason, waiting.Message)
+				}
+			}
+			return status.State.Running != nil, nil
+		}
+
+		return false, nil
+	})
+}
@@ -0,0 +1,93 @@
+package application_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/internal/application"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+var _ = Describe("Debug containers", func() {
+	var ctx context.Context
+	var pods v1.PodInterface
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		pods = kubefake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-0", Namespace: "workspace"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "app-image"}},
+			},
+		}).CoreV1().Pods("workspace")
+	})
+
+	setStatus := func(status corev1.ContainerStatus) {
+		pod, err := pods.Get(ctx, "app-0", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{status}
+		_, err = pods.UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("adds an ephemeral container targeting the application container", func() {
+		name, err := application.AddDebugContainer(ctx, pods, "app-0", "app", "busybox")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(HavePrefix("debugger-"))
+
+		pod, err := pods.Get(ctx, "app-0", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.EphemeralContainers).To(HaveLen(1))
+
+		container := pod.Spec.EphemeralContainers[0]
+		Expect(container.Name).To(Equal(name))
+		Expect(container.Image).To(Equal("busybox"))
+		Expect(container.TargetContainerName).To(Equal("app"))
+		Expect(container.Stdin).To(BeTrue())
+		Expect(container.TTY).To(BeTrue())
+	})
+
+	It("fails for an unknown pod", func() {
+		_, err := application.AddDebugContainer(ctx, pods, "missing", "app", "busybox")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("waits for the container to run", func() {
+		setStatus(corev1.ContainerStatus{
+			Name:  "debugger-1",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		})
+
+		err := application.WaitForDebugContainer(ctx, pods, "app-0", "debugger-1", time.Second)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when the image cannot be pulled", func() {
+		setStatus(corev1.ContainerStatus{
+			Name: "debugger-1",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason: "ErrImagePull", Message: "not found",
+			}},
+		})
+
+		err := application.WaitForDebugContainer(ctx, pods, "app-0", "debugger-1", time.Second)
+		Expect(err).To(MatchError(ContainSubstring("ErrImagePull not found")))
+	})
+
+	It("fails when the container terminated", func() {
+		setStatus(corev1.ContainerStatus{
+			Name: "debugger-1",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason: "Completed",
+			}},
+		})
+
+		err := application.WaitForDebugContainer(ctx, pods, "app-0", "debugger-1", time.Second)
+		Expect(err).To(MatchError(ContainSubstring("terminated")))
+	})
+})
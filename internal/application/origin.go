@@ -62,6 +62,12 @@ func Origin(app *unstructured.Unstructured) (models.ApplicationOrigin, error) {
 
 		result.Kind = models.OriginContainer
 		result.Container = container
+
+		// Digest and tracking are kept in annotations, as the CRD does not know
+		// about them.
+		annotations := app.GetAnnotations()
+		result.Digest = annotations[models.EpinioContainerDigestAnnotation]
+		result.Track = annotations[models.EpinioContainerTrackAnnotation] == "true"
 		return result, nil
 	}
 
@@ -88,6 +94,12 @@ func Origin(app *unstructured.Unstructured) (models.ApplicationOrigin, error) {
 
 		result.Kind = models.OriginGit
 		result.Git.URL = repository
+
+		// Credentials and submodule handling are kept in annotations, as the CRD
+		// does not know about them.
+		annotations := app.GetAnnotations()
+		result.Git.Credentials = annotations[models.EpinioGitCredentialsAnnotation]
+		result.Git.Submodules = annotations[models.EpinioGitSubmodulesAnnotation] == "true"
 		return result, nil
 	}
 
@@ -115,11 +127,36 @@ func SetOrigin(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		types.JSONPatchType,
 		patch,
 		metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+
+	patch, err = buildAnnotationPatch(origin)
+	if err != nil {
+		return errors.Wrap(err, "error building annotation patch")
+	}
+
+	_, err = client.Namespace(app.Namespace).Patch(ctx,
+		app.Name,
+		types.MergePatchType,
+		patch,
+		metav1.PatchOptions{})
 
 	return err
 }
 
 func buildBodyPatch(origin models.ApplicationOrigin) ([]byte, error) {
+	// Credentials, submodule handling, digest and tracking are not part of the
+	// CRD. They are stored as annotations instead. See buildAnnotationPatch.
+	if origin.Git != nil {
+		git := *origin.Git
+		git.Credentials = ""
+		git.Submodules = false
+		origin.Git = &git
+	}
+	origin.Digest = ""
+	origin.Track = false
+
 	operations := []PatchOperation{{
 		Op:    "replace",
 		Path:  "/spec/origin",
@@ -129,6 +166,41 @@ func buildBodyPatch(origin models.ApplicationOrigin) ([]byte, error) {
 	return json.Marshal(operations)
 }
 
+// buildAnnotationPatch returns a merge patch setting or removing the annotations holding
+// the git and container options of the origin. A null value removes an annotation.
+func buildAnnotationPatch(origin models.ApplicationOrigin) ([]byte, error) {
+	annotations := map[string]interface{}{
+		models.EpinioGitCredentialsAnnotation:  nil,
+		models.EpinioGitSubmodulesAnnotation:   nil,
+		models.EpinioContainerDigestAnnotation: nil,
+		models.EpinioContainerTrackAnnotation:  nil,
+	}
+
+	if origin.Kind == models.OriginGit && origin.Git != nil {
+		if origin.Git.Credentials != "" {
+			annotations[models.EpinioGitCredentialsAnnotation] = origin.Git.Credentials
+		}
+		if origin.Git.Submodules {
+			annotations[models.EpinioGitSubmodulesAnnotation] = "true"
+		}
+	}
+
+	if origin.Kind == models.OriginContainer {
+		if origin.Digest != "" {
+			annotations[models.EpinioContainerDigestAnnotation] = origin.Digest
+		}
+		if origin.Track {
+			annotations[models.EpinioContainerTrackAnnotation] = "true"
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+}
+
 type PatchOperation struct {
 	Op    string                   `json:"op"`
 	Path  string                   `json:"path"`
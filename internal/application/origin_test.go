@@ -56,6 +56,27 @@ var _ = Describe("Build body for SetOrigin PATCH", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(string(body)).To(MatchJSON(`[{"op":"replace","path":"/spec/origin","value":{"Kind":3,"container":"my-container"}}]`))
 			})
+
+			Context("with digest and tracking", func() {
+				BeforeEach(func() {
+					origin.Digest = "sha256:1234"
+					origin.Track = true
+				})
+
+				It("keeps them out of the spec", func() {
+					body, err := buildBodyPatch(origin)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(MatchJSON(`[{"op":"replace","path":"/spec/origin","value":{"Kind":3,"container":"my-container"}}]`))
+				})
+
+				It("places them into the annotations", func() {
+					body, err := buildAnnotationPatch(origin)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(MatchJSON(`{"metadata":{"annotations":{"epinio.io/git-credentials":null,"epinio.io/git-submodules":null,"epinio.io/container-digest":"sha256:1234","epinio.io/container-track":"true"}}}`))
+				})
+			})
 		})
 
 		When("origin is Git", func() {
@@ -95,6 +116,40 @@ var _ = Describe("Build body for SetOrigin PATCH", func() {
 					Expect(string(body)).To(MatchJSON(`[{"op":"replace","path":"/spec/origin","value":{"Kind":2,"git":{"repository":"git@repo","revision":"revision_1"}}}]`))
 				})
 			})
+
+			Context("with credentials and submodules", func() {
+				BeforeEach(func() {
+					gitOriginRev.Git.Credentials = "my-git-creds"
+					gitOriginRev.Git.Submodules = true
+				})
+
+				It("keeps them out of the spec", func() {
+					body, err := buildBodyPatch(gitOriginRev)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(MatchJSON(`[{"op":"replace","path":"/spec/origin","value":{"Kind":2,"git":{"repository":"git@repo","revision":"revision_1"}}}]`))
+					Expect(gitOriginRev.Git.Credentials).To(Equal("my-git-creds"))
+				})
+
+				It("places them into the annotations", func() {
+					body, err := buildAnnotationPatch(gitOriginRev)
+
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(body)).To(MatchJSON(`{"metadata":{"annotations":{"epinio.io/git-credentials":"my-git-creds","epinio.io/git-submodules":"true","epinio.io/container-digest":null,"epinio.io/container-track":null}}}`))
+				})
+			})
 		})
 	})
 })
+
+var _ = Describe("Build annotation patch for SetOrigin", func() {
+	It("removes the git and container annotations for path origins", func() {
+		body, err := buildAnnotationPatch(models.ApplicationOrigin{
+			Kind: models.OriginPath,
+			Path: "path",
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(MatchJSON(`{"metadata":{"annotations":{"epinio.io/git-credentials":null,"epinio.io/git-submodules":null,"epinio.io/container-digest":null,"epinio.io/container-track":null}}}`))
+	})
+})
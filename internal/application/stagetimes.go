@@ -0,0 +1,74 @@
+package application
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// maxStageTimes is the number of stage times kept in the EpinioStageTimesAnnotation.
+// Older stages are forgotten.
+const maxStageTimes = 100
+
+// StageTimes returns the start times of the recent stages of the application, by stage
+// ID. Stages staged before the times were recorded are not known. The time of a stage
+// orders its image in the registry, where the image creation time is of no help, as
+// buildpacks fix it for reproducible builds.
+func StageTimes(app *unstructured.Unstructured) (map[string]time.Time, error) {
+	times := map[string]time.Time{}
+
+	value := app.GetAnnotations()[models.EpinioStageTimesAnnotation]
+	if value == "" {
+		return times, nil
+	}
+
+	err := json.Unmarshal([]byte(value), &times)
+	if err != nil {
+		return nil, errors.Wrap(err, "stage times should be a map of times")
+	}
+
+	return times, nil
+}
+
+// RecordStageTime adds the start time of the stage to the EpinioStageTimesAnnotation of
+// the application. Only the newest maxStageTimes stages are kept. The caller is
+// responsible for saving the application.
+func RecordStageTime(app *unstructured.Unstructured, stageID string, start time.Time) error {
+	times, err := StageTimes(app)
+	if err != nil {
+		// Do not let a damaged annotation block staging, start over.
+		times = map[string]time.Time{}
+	}
+	times[stageID] = start.UTC()
+
+	if len(times) > maxStageTimes {
+		stageIDs := make([]string, 0, len(times))
+		for id := range times {
+			stageIDs = append(stageIDs, id)
+		}
+		sort.Slice(stageIDs, func(i, j int) bool {
+			return times[stageIDs[i]].After(times[stageIDs[j]])
+		})
+		for _, id := range stageIDs[maxStageTimes:] {
+			delete(times, id)
+		}
+	}
+
+	value, err := json.Marshal(times)
+	if err != nil {
+		return err
+	}
+
+	annotations := app.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[models.EpinioStageTimesAnnotation] = string(value)
+	app.SetAnnotations(annotations)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stage times", func() {
+	var app *unstructured.Unstructured
+
+	BeforeEach(func() {
+		app = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	})
+
+	It("records the start of the stages", func() {
+		start := time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC)
+		Expect(RecordStageTime(app, "stage-1", start)).To(Succeed())
+		Expect(RecordStageTime(app, "stage-2", start.Add(time.Minute))).To(Succeed())
+
+		times, err := StageTimes(app)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(times).To(HaveLen(2))
+		Expect(times["stage-1"]).To(BeTemporally("==", start))
+		Expect(times["stage-2"]).To(BeTemporally("==", start.Add(time.Minute)))
+	})
+
+	It("forgets the oldest stages", func() {
+		start := time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC)
+		for i := 0; i <= maxStageTimes; i++ {
+			id := fmt.Sprintf("stage-%d", i)
+			Expect(RecordStageTime(app, id, start.Add(time.Duration(i)*time.Minute))).To(Succeed())
+		}
+
+		times, err := StageTimes(app)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(times).To(HaveLen(maxStageTimes))
+		Expect(times).ToNot(HaveKey("stage-0"))
+		Expect(times).To(HaveKey(fmt.Sprintf("stage-%d", maxStageTimes)))
+	})
+
+	It("starts over on a damaged annotation", func() {
+		app.SetAnnotations(map[string]string{models.EpinioStageTimesAnnotation: "damaged"})
+
+		_, err := StageTimes(app)
+		Expect(err).To(HaveOccurred())
+
+		Expect(RecordStageTime(app, "stage-1", time.Now())).To(Succeed())
+		times, err := StageTimes(app)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(times).To(HaveKey("stage-1"))
+	})
+})
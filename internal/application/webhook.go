@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/randstr"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const webhookSecretKey = "secret"
+
+// WebhookSecret returns the key used to verify the signatures of the git webhook
+// notifications for the referenced application. The key is generated on first use.
+func WebhookSecret(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (string, error) {
+	var key string
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := loadOrCreateSecret(ctx, cluster, appRef,
+			appRef.MakeWebhookSecretName(), "webhook")
+		if err != nil {
+			return err
+		}
+
+		if value, ok := secret.Data[webhookSecretKey]; ok && len(value) > 0 {
+			key = string(value)
+			return nil
+		}
+
+		key, err = randstr.Hex16()
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[webhookSecretKey] = []byte(key)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+
+	return key, err
+}
+
+// WebhookSecretLookup returns the key used to verify the signatures of the git webhook
+// notifications for the referenced application. In contrast to WebhookSecret the key is
+// not generated if missing. An empty string is returned instead.
+func WebhookSecretLookup(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (string, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeWebhookSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return string(secret.Data[webhookSecretKey]), nil
+}
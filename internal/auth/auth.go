@@ -114,6 +114,21 @@ func (s *AuthService) AddNamespaceToUser(ctx context.Context, username, namespac
 	return errors.Wrap(err, fmt.Sprintf("error updating user secret [%s]", username))
 }
 
+// RemoveNamespaceFromUser will remove the specified namespace from the User.
+// It returns false if the User did not have the namespace
+func (s *AuthService) RemoveNamespaceFromUser(ctx context.Context, username, namespace string) (bool, error) {
+	user, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("error getting user [%s] by username", username))
+	}
+	if !user.RemoveNamespace(namespace) {
+		return false, nil
+	}
+
+	err = s.updateUserSecret(ctx, user)
+	return true, errors.Wrap(err, fmt.Sprintf("error updating user secret [%s]", username))
+}
+
 // RemoveNamespaceFromUsers will remove the specified namespace from all the users
 func (s *AuthService) RemoveNamespaceFromUsers(ctx context.Context, namespace string) error {
 	users, err := s.GetUsers(ctx)
@@ -165,10 +180,9 @@ func (s *AuthService) updateUserSecret(ctx context.Context, user User) error {
 			return errors.Wrap(err, fmt.Sprintf("error getting the user secret [%s]", user.Username))
 		}
 
-		if len(user.Namespaces) > 0 {
-			userSecret.StringData = map[string]string{
-				"namespaces": strings.Join(user.Namespaces, "\n"),
-			}
+		// An empty list is saved as well, to remove the last namespace of the user.
+		userSecret.StringData = map[string]string{
+			"namespaces": strings.Join(user.Namespaces, "\n"),
 		}
 
 		_, err = s.SecretInterface.Update(ctx, userSecret, metav1.UpdateOptions{})
@@ -100,6 +100,45 @@ var _ = Describe("Auth users", func() {
 		})
 	})
 
+	Describe("RemoveNamespaceFromUser", func() {
+
+		When("user has the namespace", func() {
+			It("will be removed, also the last one", func() {
+				userSecrets := []corev1.Secret{
+					newUserSecret("user1", "password", "user", "workspace"),
+				}
+
+				// setup mock
+				fake.ListReturns(&corev1.SecretList{Items: userSecrets}, nil)
+				fake.GetReturns(&userSecrets[0], nil)
+				updatedUserSecret := newUserSecret("user1", "password", "user", "")
+				fake.UpdateReturns(&updatedUserSecret, nil)
+
+				// do test
+				removed, err := authService.RemoveNamespaceFromUser(context.Background(), "user1", "workspace")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(removed).To(BeTrue())
+
+				_, secret, _ := fake.UpdateArgsForCall(0)
+				Expect(secret.StringData).To(HaveKeyWithValue("namespaces", ""))
+			})
+		})
+
+		When("user doesn't have the namespace", func() {
+			It("reports it", func() {
+				userSecrets := []corev1.Secret{
+					newUserSecret("user1", "password", "user", "workspace"),
+				}
+				fake.ListReturns(&corev1.SecretList{Items: userSecrets}, nil)
+
+				removed, err := authService.RemoveNamespaceFromUser(context.Background(), "user1", "other")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(removed).To(BeFalse())
+				Expect(fake.UpdateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("RemoveNamespaceFromUsers", func() {
 
 		When("users have the namespace", func() {
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CmdAdmin implements the command: epinio admin
+var CmdAdmin = &cobra.Command{
+	Use:           "admin",
+	Short:         "Epinio maintenance",
+	Long:          `Maintain the Epinio installation. Requires an admin user`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+func init() {
+	flags := CmdAdminGC.Flags()
+	flags.Bool("dry-run", false, "Only report the images to delete, and the space to reclaim")
+	flags.Int("keep", 0, "Number of newest images to keep per application. Defaults to the server setting")
+
+	CmdAdmin.AddCommand(CmdAdminGC)
+
+	blobFlags := CmdAdminBlobsPrune.Flags()
+	blobFlags.Bool("dry-run", false, "Only report the blobs to delete, and the space to reclaim")
+	blobFlags.String("older-than", "", "Minimum age of the orphaned blobs to delete, like 24h. Defaults to the server setting")
+
+	CmdAdminBlobs.AddCommand(CmdAdminBlobsPrune)
+	CmdAdmin.AddCommand(CmdAdminBlobs)
+}
+
+// CmdAdminGC implements the command: epinio admin gc
+var CmdAdminGC = &cobra.Command{
+	Use:   "gc",
+	Short: "Garbage collect application images",
+	Long:  "Delete the old application images from the Epinio registry. The newest images of each application, and the images of deployed stages are kept.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --dry-run")
+		}
+		keep, err := cmd.Flags().GetInt("keep")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --keep")
+		}
+		if keep < 0 {
+			return errors.New("--keep must not be negative")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ImageGC(keep, dryRun)
+		if err != nil {
+			return errors.Wrap(err, "error collecting images")
+		}
+
+		return nil
+	},
+}
+
+// CmdAdminBlobs implements the command: epinio admin blobs
+var CmdAdminBlobs = &cobra.Command{
+	Use:           "blobs",
+	Short:         "Epinio application source blobs maintenance",
+	Long:          `Maintain the application source blobs in the S3 storage`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+// CmdAdminBlobsPrune implements the command: epinio admin blobs prune
+var CmdAdminBlobsPrune = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune orphaned application source blobs",
+	Long:  "Delete the application source blobs neither referenced by an application nor by a staging job from the S3 storage. These are left behind by failed or abandoned uploads, and by deleted namespaces.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --dry-run")
+		}
+		olderThan, err := cmd.Flags().GetString("older-than")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --older-than")
+		}
+		if olderThan != "" {
+			age, err := time.ParseDuration(olderThan)
+			if err != nil {
+				return errors.Wrap(err, "error parsing option --older-than")
+			}
+			if age < 0 {
+				return errors.New("--older-than must not be negative")
+			}
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.BlobPrune(olderThan, dryRun)
+		if err != nil {
+			return errors.Wrap(err, "error pruning blobs")
+		}
+
+		return nil
+	},
+}
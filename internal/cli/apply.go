@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	CmdApply.Flags().StringP("file", "f", "", "Path to the stack manifest (mandatory)")
+	CmdApply.Flags().Bool("prune", false, "Delete the applications, services, and configurations not declared by the stack")
+	CmdApply.Flags().Bool("dry-run", false, "Only show the plan, do not change anything")
+	checkErr(CmdApply.MarkFlagRequired("file"))
+}
+
+// CmdApply implements the command: epinio apply
+var CmdApply = &cobra.Command{
+	Use:   "apply -f STACK_MANIFEST",
+	Short: "Converge the targeted namespace to a stack manifest",
+	Long: `Converge the targeted namespace to a stack manifest.
+
+The stack manifest is a multi-document yaml file. Each document declares its kind, one of
+app, configuration, and service. App documents are application manifests, as used by push.
+Service documents list the applications bound to them.
+
+The plan of the changes against the current state of the namespace is shown, and then
+applied, creating, updating, and binding as needed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		path, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --file")
+		}
+		prune, err := cmd.Flags().GetBool("prune")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --prune")
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --dry-run")
+		}
+
+		stack, err := manifest.GetStack(path)
+		if err != nil {
+			return errors.Wrap(err, "stack manifest error")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.Apply(cmd.Context(), stack, prune, dryRun)
+		if err != nil {
+			return errors.Wrap(err, "error applying stack")
+		}
+
+		return nil
+	},
+}
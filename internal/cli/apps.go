@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"os"
+	"strings"
+
 	"github.com/epinio/epinio/internal/cli/usercmd"
 	"github.com/epinio/epinio/internal/manifest"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 // CmdApp implements the  command: epinio app
@@ -23,6 +27,9 @@ func init() {
 	CmdAppLogs.Flags().Bool("follow", false, "follow the logs of the application")
 	CmdAppLogs.Flags().Bool("staging", false, "show the staging logs of the application")
 	CmdAppExec.Flags().StringP("instance", "i", "", "The name of the instance to shell to")
+	CmdAppCopy.Flags().StringP("instance", "i", "", "The name of the instance to copy from or to")
+	CmdAppDebug.Flags().StringP("instance", "i", "", "The name of the instance to debug")
+	CmdAppDebug.Flags().String("image", "", "The image of the debug container. Defaults to busybox")
 	CmdAppPortForward.Flags().StringSliceVar(&portForwardAddress, "address", []string{"localhost"}, "Addresses to listen on (comma separated). Only accepts IP addresses or localhost as a value. When localhost is supplied, kubectl will try to bind on both 127.0.0.1 and ::1 and will fail if neither of these addresses are available to bind.")
 	CmdAppPortForward.Flags().StringVarP(&portForwardInstance, "instance", "i", "", "The name of the instance to shell to")
 
@@ -36,6 +43,7 @@ func init() {
 	instancesOption(CmdAppUpdate)
 	chartValueOption(CmdAppCreate)
 	chartValueOption(CmdAppUpdate)
+	templateOption(CmdAppDiff)
 
 	CmdAppCreate.Flags().String("app-chart", "", "App chart to use for deployment")
 	CmdAppUpdate.Flags().String("app-chart", "", "App chart to use for deployment")
@@ -46,8 +54,11 @@ func init() {
 	CmdApp.AddCommand(CmdAppList)
 	CmdApp.AddCommand(CmdAppLogs)
 	CmdApp.AddCommand(CmdAppExec)
+	CmdApp.AddCommand(CmdAppCopy)
+	CmdApp.AddCommand(CmdAppDebug)
 	CmdApp.AddCommand(CmdAppPortForward)
 
+	CmdApp.AddCommand(CmdAppDiff)
 	CmdApp.AddCommand(CmdAppManifest)
 	CmdApp.AddCommand(CmdAppShow)
 	CmdApp.AddCommand(CmdAppExport)
@@ -56,6 +67,7 @@ func init() {
 	CmdApp.AddCommand(CmdAppPush) // See push.go for implementation
 	CmdApp.AddCommand(CmdAppRestart)
 	CmdApp.AddCommand(CmdAppRestage)
+	CmdApp.AddCommand(CmdAppWebhook)
 }
 
 // CmdAppList implements the command: epinio app list
@@ -197,9 +209,13 @@ var CmdAppLogs = &cobra.Command{
 
 // CmdAppExec implements the command: epinio apps exec
 var CmdAppExec = &cobra.Command{
-	Use:   "exec NAME",
-	Short: "creates a shell to the application",
-	Args:  cobra.ExactArgs(1),
+	Use:   "exec NAME [-- COMMAND [ARG...]]",
+	Short: "creates a shell to the application, or runs a command in it",
+	Long: `Creates a shell to the application, or runs a command in it.
+
+A command is run without TTY, with the standard streams of the client, and the exit
+status of the command becomes the exit status of the client.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
@@ -214,12 +230,112 @@ var CmdAppExec = &cobra.Command{
 			return errors.Wrap(err, "could not read instance parameter")
 		}
 
+		if len(args) > 1 {
+			err = client.AppExecCommand(cmd.Context(), args[0], instance, args[1:])
+			var exitErr utilexec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitStatus())
+			}
+			return errors.Wrap(err, "error running command in application")
+		}
+
 		err = client.AppExec(cmd.Context(), args[0], instance)
 		// Note: errors.Wrap (nil, "...") == nil
 		return errors.Wrap(err, "error getting a shell to application")
 	},
 }
 
+// CmdAppDebug implements the command: epinio apps debug
+var CmdAppDebug = &cobra.Command{
+	Use:   "debug NAME",
+	Short: "creates a shell in a debug container of the application",
+	Long: `Creates a shell in a debug container added to an instance of the application.
+
+The debug container is a kubernetes ephemeral container running the image, busybox by
+default. It shares the process namespace of the application, e.g. its processes and their
+files are visible under /proc. Ephemeral containers cannot be removed. The container stays
+in the instance after the shell exits, until the instance is restarted.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingAppsFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		instance, err := cmd.Flags().GetString("instance")
+		if err != nil {
+			cmd.SilenceUsage = false
+			return errors.Wrap(err, "could not read instance parameter")
+		}
+
+		image, err := cmd.Flags().GetString("image")
+		if err != nil {
+			cmd.SilenceUsage = false
+			return errors.Wrap(err, "could not read image parameter")
+		}
+
+		err = client.AppDebug(cmd.Context(), args[0], instance, image)
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error debugging application")
+	},
+}
+
+// CmdAppCopy implements the command: epinio apps cp
+var CmdAppCopy = &cobra.Command{
+	Use:   "cp SOURCE DESTINATION",
+	Short: "Copy files from and to the application",
+	Long: `Copy files from and to the application.
+
+One of source and destination is a path in the application, written as NAME:PATH. An
+existing destination directory receives the copy, otherwise the destination is the path of
+the copy. The application image has to provide tar.`,
+	Example: `  epinio app cp myapp:/workspace/log.txt .
+  epinio app cp ./config myapp:/workspace/config`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		instance, err := cmd.Flags().GetString("instance")
+		if err != nil {
+			cmd.SilenceUsage = false
+			return errors.Wrap(err, "could not read instance parameter")
+		}
+
+		sourceApp, sourcePath := appPath(args[0])
+		destinationApp, destinationPath := appPath(args[1])
+		if (sourceApp == "") == (destinationApp == "") {
+			cmd.SilenceUsage = false
+			return errors.New("exactly one of source and destination has to be a path in the application, NAME:PATH")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		if sourceApp != "" {
+			err = client.AppCopyFrom(sourceApp, instance, sourcePath, destinationPath)
+		} else {
+			err = client.AppCopyTo(destinationApp, instance, sourcePath, destinationPath)
+		}
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error copying files")
+	},
+}
+
+// appPath splits a NAME:PATH argument of `app cp` into application name and path. The
+// name is empty for local paths.
+func appPath(arg string) (string, string) {
+	pieces := strings.SplitN(arg, ":", 2)
+	if len(pieces) != 2 || pieces[0] == "" || strings.ContainsAny(pieces[0], `/\`) {
+		return "", arg
+	}
+	return pieces[0], pieces[1]
+}
+
 var (
 	portForwardAddress  []string
 	portForwardInstance string
@@ -304,6 +420,61 @@ var CmdAppManifest = &cobra.Command{
 	},
 }
 
+// CmdAppDiff implements the command: epinio app diff
+var CmdAppDiff = &cobra.Command{
+	Use:   "diff [MANIFESTPATH]",
+	Short: "Compare an application manifest with the state of the application",
+	Long: `Compare an application manifest with the state of the application of the same name.
+
+The manifest defaults to epinio.yml in the working directory. The exit code is 0 when the
+application matches the manifest, 1 when it drifted, and -1 for errors.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		manifestPath := "epinio.yml"
+		if len(args) == 1 {
+			manifestPath = args[0]
+		}
+
+		if _, err := os.Stat(manifestPath); err != nil {
+			return errors.Wrap(err, "manifest not accessible")
+		}
+
+		options, err := manifest.OptionsFrom(cmd)
+		if err != nil {
+			return err
+		}
+		// The default origin is not declared by the manifest, and not compared.
+		options.NoDefaultOrigin = true
+
+		m, err := manifest.Load(manifestPath, options)
+		if err != nil {
+			return errors.Wrap(err, "manifest error")
+		}
+		if m.Name == "" {
+			return errors.New("Name required, not found in manifest")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		diff, err := client.AppDiff(m)
+		if err != nil {
+			return errors.Wrap(err, "error comparing app manifest")
+		}
+
+		if len(diff) > 0 {
+			// Drift is signaled through the exit code, for use as a CI gate.
+			os.Exit(1)
+		}
+
+		return nil
+	},
+}
+
 // CmdAppRestart implements the command: epinio app restart
 var CmdAppRestart = &cobra.Command{
 	Use:               "restart NAME",
@@ -343,3 +514,24 @@ var CmdAppRestage = &cobra.Command{
 		return errors.Wrap(err, "error restaging app")
 	},
 }
+
+// CmdAppWebhook implements the command: epinio app webhook
+var CmdAppWebhook = &cobra.Command{
+	Use:               "webhook NAME",
+	Short:             "Show the git webhook of the application",
+	Long:              "Show the url and secret to configure at the git hosting service, to redeploy the application on every push",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingAppsFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.AppWebhook(args[0])
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error showing app webhook")
+	},
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +23,89 @@ func init() {
 
 	CmdConfigurationList.Flags().Bool("all", false, "list all configurations")
 
+	CmdConfigurationCreate.Flags().StringSlice("from-file", []string{}, "take the value of a key from a local file, as `KEY=PATH`")
+	CmdConfigurationCreate.Flags().StringSlice("from-env-file", []string{}, "take keys and values from a local file of `KEY=VALUE` lines")
+	CmdConfigurationCreate.Flags().StringSlice("from-external", []string{}, "take the value of a key from an external secret store known to the server, as `KEY=SCHEME:PATH`. The server re-syncs the value periodically")
+
 	changeOptions(CmdConfigurationUpdate)
+
+	restartOption(CmdConfigurationCreate, "restart policy of the configuration, one of always, parallel, rolling, never. Defaults to always")
+	restartOption(CmdConfigurationUpdate, "change the restart policy of the configuration, one of always, parallel, rolling, never")
+	restartOption(CmdConfigurationBind, "restart policy of the binding, overriding the policy of the configuration, one of always, parallel, rolling, never")
+
+	CmdConfigurationBind.Flags().Bool("env", false, "expose all keys of the configuration as environment variables of the application")
+	CmdConfigurationBind.Flags().String("env-prefix", "", "prefix of the names of the environment variables")
+	CmdConfigurationBind.Flags().StringSlice("env-key", []string{}, "expose the selected key as environment variable, as `KEY[=NAME]`")
+}
+
+// restartOption adds the --restart option to the command. The policy determines how the
+// applications bound to the configuration are restarted when it changes.
+func restartOption(cmd *cobra.Command, usage string) {
+	cmd.Flags().String("restart", "", usage)
+	checkErr(cmd.RegisterFlagCompletionFunc("restart",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{
+				models.ConfigurationRestartAlways.String(),
+				models.ConfigurationRestartParallel.String(),
+				models.ConfigurationRestartRolling.String(),
+				models.ConfigurationRestartNever.String(),
+			}, cobra.ShellCompDirectiveNoFileComp
+		}))
+}
+
+// envMapping returns the validated mapping of the configuration keys to environment
+// variables, as per the --env, --env-prefix and --env-key options. It returns nil if none
+// of them is used.
+func envMapping(cmd *cobra.Command) (*models.ConfigurationEnvMapping, error) {
+	all, err := cmd.Flags().GetBool("env")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read option --env")
+	}
+	prefix, err := cmd.Flags().GetString("env-prefix")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read option --env-prefix")
+	}
+	keys, err := cmd.Flags().GetStringSlice("env-key")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read option --env-key")
+	}
+
+	if !all && prefix == "" && len(keys) == 0 {
+		return nil, nil
+	}
+	if all && len(keys) > 0 {
+		return nil, errors.New("the options --env and --env-key exclude each other")
+	}
+
+	mapping := &models.ConfigurationEnvMapping{
+		Prefix: prefix,
+	}
+	for _, key := range keys {
+		if mapping.Keys == nil {
+			mapping.Keys = map[string]string{}
+		}
+		key, name, _ := strings.Cut(key, "=")
+		mapping.Keys[key] = name
+	}
+
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// restartPolicy returns the validated restart policy of the --restart option.
+func restartPolicy(cmd *cobra.Command) (models.ConfigurationRestartPolicy, error) {
+	restart, err := cmd.Flags().GetString("restart")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read option --restart")
+	}
+
+	policy := models.ConfigurationRestartPolicy(restart)
+	if err := policy.Validate(); err != nil {
+		return "", err
+	}
+	return policy, nil
 }
 
 // CmdConfiguration implements the command: epinio configuration
@@ -68,12 +151,12 @@ var CmdConfigurationShow = &cobra.Command{
 
 // CmdConfigurationCreate implements the command: epinio configuration create
 var CmdConfigurationCreate = &cobra.Command{
-	Use:   "create NAME (KEY VALUE)...",
+	Use:   "create NAME [KEY VALUE]...",
 	Short: "Create a configuration",
-	Long:  `Create configuration by name and key/value dictionary.`,
+	Long:  `Create configuration by name and key/value dictionary, and the values of the --from-* options.`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 3 {
-			return errors.New("Not enough arguments, expected name, key, and value")
+		if len(args) < 1 {
+			return errors.New("Not enough arguments, expected name")
 		}
 		if len(args)%2 == 0 {
 			return errors.New("Last Key has no value")
@@ -116,9 +199,14 @@ var CmdConfigurationDelete = &cobra.Command{
 var CmdConfigurationBind = &cobra.Command{
 	Use:   "bind NAME APP",
 	Short: "Bind a configuration to an application",
-	Long:  `Bind configuration by name, to named application.`,
-	Args:  cobra.ExactArgs(2),
-	RunE:  ConfigurationBind,
+	Long: `Bind configuration by name, to named application.
+
+The configuration is mounted as files into the application. With --env or --env-key its
+keys are exposed as environment variables as well. This requires an app chart using the
+'epinio.configenv' values, deploying with other charts is refused. Environment variables
+see changed values only after a restart of the application.`,
+	Args: cobra.ExactArgs(2),
+	RunE: ConfigurationBind,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) > 1 {
 			return nil, cobra.ShellCompDirectiveNoFileComp
@@ -231,7 +319,27 @@ func ConfigurationCreate(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "error initializing cli")
 	}
 
-	err = client.CreateConfiguration(args[0], args[1:])
+	var sources usercmd.ConfigurationSources
+
+	sources.Files, err = cmd.Flags().GetStringSlice("from-file")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --from-file")
+	}
+	sources.EnvFiles, err = cmd.Flags().GetStringSlice("from-env-file")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --from-env-file")
+	}
+	sources.External, err = cmd.Flags().GetStringSlice("from-external")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --from-external")
+	}
+
+	restart, err := restartPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = client.CreateConfiguration(args[0], args[1:], sources, restart)
 	if err != nil {
 		return errors.Wrap(err, "error creating configuration")
 	}
@@ -269,7 +377,12 @@ func ConfigurationUpdate(cmd *cobra.Command, args []string) error {
 		assignments[pieces[0]] = pieces[1]
 	}
 
-	err = client.UpdateConfiguration(args[0], removedKeys, assignments)
+	restart, err := restartPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdateConfiguration(args[0], removedKeys, assignments, restart)
 	if err != nil {
 		return errors.Wrap(err, "error creating configuration")
 	}
@@ -308,7 +421,17 @@ func ConfigurationBind(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "error initializing cli")
 	}
 
-	err = client.BindConfiguration(args[0], args[1])
+	restart, err := restartPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	env, err := envMapping(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = client.BindConfiguration(args[0], args[1], restart, env)
 	if err != nil {
 		return errors.Wrap(err, "error binding configuration")
 	}
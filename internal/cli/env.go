@@ -16,6 +16,10 @@ var CmdAppEnv = &cobra.Command{
 }
 
 func init() {
+	CmdEnvList.Flags().Bool("build-only", false, "list the build-only environment, used by staging only")
+	CmdEnvSet.Flags().Bool("build-only", false, "set into the build-only environment, used by staging only")
+	CmdEnvUnset.Flags().Bool("build-only", false, "remove from the build-only environment, used by staging only")
+
 	CmdAppEnv.AddCommand(CmdEnvList)
 	CmdAppEnv.AddCommand(CmdEnvSet)
 	CmdAppEnv.AddCommand(CmdEnvShow)
@@ -37,7 +41,12 @@ var CmdEnvList = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.EnvList(cmd.Context(), args[0])
+		buildOnly, err := cmd.Flags().GetBool("build-only")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --build-only")
+		}
+
+		err = client.EnvList(cmd.Context(), args[0], buildOnly)
 		if err != nil {
 			return errors.Wrap(err, "error listing app environment")
 		}
@@ -50,7 +59,7 @@ var CmdEnvList = &cobra.Command{
 var CmdEnvSet = &cobra.Command{
 	Use:   "set APPNAME NAME VALUE",
 	Short: "Extend application environment",
-	Long:  "Add or change environment variable of named application",
+	Long:  "Add or change environment variable of named application. The build-only environment is seen by staging only. The regular environment is seen by the running application, and by staging as well, overridden by the build-only environment. Staging with the regular environment is deprecated, set buildpack variables like BP_* as build-only variables",
 	Args:  cobra.ExactArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
@@ -61,7 +70,12 @@ var CmdEnvSet = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.EnvSet(cmd.Context(), args[0], args[1], args[2])
+		buildOnly, err := cmd.Flags().GetBool("build-only")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --build-only")
+		}
+
+		err = client.EnvSet(cmd.Context(), args[0], args[1], args[2], buildOnly)
 		if err != nil {
 			return errors.Wrap(err, "error setting into app environment")
 		}
@@ -148,7 +162,12 @@ var CmdEnvUnset = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.EnvUnset(cmd.Context(), args[0], args[1])
+		buildOnly, err := cmd.Flags().GetBool("build-only")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --build-only")
+		}
+
+		err = client.EnvUnset(cmd.Context(), args[0], args[1], buildOnly)
 		if err != nil {
 			return errors.Wrap(err, "error removing from app environment")
 		}
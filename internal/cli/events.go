@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	CmdEvents.Flags().String("app", "", "Only show the events of the named application")
+}
+
+// CmdEvents implements the command: epinio events
+var CmdEvents = &cobra.Command{
+	Use:   "events [--app NAME]",
+	Short: "Stream the events of the targeted namespace",
+	Long: `Stream the events of the targeted namespace until interrupted.
+
+The events are the creation and deletion of applications, the start and end of their
+staging, readiness changes of their instances and the progress of their deployment, the
+end of the provisioning of services, and changes of configurations. Only events after
+the start of the command are shown.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		appName, err := cmd.Flags().GetString("app")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --app")
+		}
+
+		err = client.Events(appName)
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error streaming events")
+	},
+}
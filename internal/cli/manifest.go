@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/epinio/epinio/internal/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// CmdManifest implements the command: epinio manifest
+var CmdManifest = &cobra.Command{
+	Use:           "manifest",
+	Short:         "Epinio application manifests",
+	Long:          `Work with epinio application manifests`,
+	SilenceErrors: false,
+	Args:          cobra.MinimumNArgs(1),
+}
+
+func init() {
+	templateOption(CmdManifestRender)
+
+	CmdManifest.AddCommand(CmdManifestRender)
+}
+
+// CmdManifestRender implements the command: epinio manifest render
+var CmdManifestRender = &cobra.Command{
+	Use:   "render [MANIFESTPATH]",
+	Short: "Print the manifest as push sees it, after templating",
+	Long: `Print the manifest as push sees it, after templating.
+
+The overlay, if any, is merged over the manifest, and the ((name)) variables are replaced
+by their values. The manifest defaults to epinio.yml in the working directory.`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		manifestPath := "epinio.yml"
+		if len(args) == 1 {
+			manifestPath = args[0]
+		}
+
+		if _, err := os.Stat(manifestPath); err != nil {
+			return errors.Wrap(err, "manifest not accessible")
+		}
+
+		options, err := manifest.OptionsFrom(cmd)
+		if err != nil {
+			return err
+		}
+
+		m, err := manifest.Load(manifestPath, options)
+		if err != nil {
+			return errors.Wrap(err, "manifest error")
+		}
+
+		content, err := yaml.Marshal(m)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), string(content))
+		return nil
+	},
+}
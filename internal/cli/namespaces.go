@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -37,10 +38,37 @@ func init() {
 	flags := CmdNamespaceDelete.Flags()
 	flags.BoolVarP(&force, "force", "f", false, "force namespace deletion")
 
+	for _, cmd := range []*cobra.Command{CmdNamespaceCreate, CmdNamespaceQuota} {
+		quotaFlags := cmd.Flags()
+		quotaFlags.Int("max-apps", 0, "maximum number of applications in the namespace")
+		quotaFlags.Int("max-services", 0, "maximum number of services in the namespace")
+		quotaFlags.String("memory", "", "maximum memory requested by the pods of the namespace, for example 16Gi")
+		quotaFlags.String("cpu", "", "maximum cpu requested by the pods of the namespace, for example 8")
+	}
+
 	CmdNamespace.AddCommand(CmdNamespaceCreate)
 	CmdNamespace.AddCommand(CmdNamespaceList)
 	CmdNamespace.AddCommand(CmdNamespaceDelete)
 	CmdNamespace.AddCommand(CmdNamespaceShow)
+	CmdNamespace.AddCommand(CmdNamespaceQuota)
+
+	CmdNamespaceAddMember.Flags().String("role", models.NamespaceRoleMember, "role of the user, one of member, owner. Owners manage the members")
+	checkErr(CmdNamespaceAddMember.RegisterFlagCompletionFunc("role",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{models.NamespaceRoleMember, models.NamespaceRoleOwner}, cobra.ShellCompDirectiveNoFileComp
+		}))
+
+	CmdNamespace.AddCommand(CmdNamespaceMembers)
+	CmdNamespace.AddCommand(CmdNamespaceAddMember)
+	CmdNamespace.AddCommand(CmdNamespaceRemoveMember)
+
+	CmdNamespaceExport.Flags().String("passphrase-file", "", "encrypt the configuration values and service settings with the passphrase read from the file")
+	CmdNamespaceImport.Flags().String("passphrase-file", "", "decrypt the configuration values and service settings with the passphrase read from the file")
+	CmdNamespaceImport.Flags().String("as", "", "name of the imported namespace, instead of the exported name")
+	CmdNamespaceImport.Flags().Bool("skip-quota", false, "do not set the exported quota on the imported namespace")
+
+	CmdNamespace.AddCommand(CmdNamespaceExport)
+	CmdNamespace.AddCommand(CmdNamespaceImport)
 }
 
 // CmdNamespaces implements the command: epinio namespace list
@@ -68,6 +96,7 @@ var CmdNamespaceList = &cobra.Command{
 var CmdNamespaceCreate = &cobra.Command{
 	Use:   "create NAME",
 	Short: "Creates an epinio-controlled namespace",
+	Long:  "Creates an epinio-controlled namespace. Only admins can limit it by a quota, see also `epinio namespace quota`.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
@@ -77,7 +106,12 @@ var CmdNamespaceCreate = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.CreateNamespace(args[0])
+		quota, err := namespaceQuota(cmd)
+		if err != nil {
+			return err
+		}
+
+		err = client.CreateNamespace(args[0], quota)
 		if err != nil {
 			return errors.Wrap(err, "error creating epinio-controlled namespace")
 		}
@@ -86,6 +120,35 @@ var CmdNamespaceCreate = &cobra.Command{
 	},
 }
 
+// namespaceQuota returns the quota given by the options of the command, or nil if the
+// namespace is not limited.
+func namespaceQuota(cmd *cobra.Command) (*models.NamespaceQuota, error) {
+	quota := models.NamespaceQuota{}
+	var err error
+
+	quota.MaxApps, err = cmd.Flags().GetInt("max-apps")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --max-apps")
+	}
+	quota.MaxServices, err = cmd.Flags().GetInt("max-services")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --max-services")
+	}
+	quota.Memory, err = cmd.Flags().GetString("memory")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --memory")
+	}
+	quota.CPU, err = cmd.Flags().GetString("cpu")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --cpu")
+	}
+
+	if quota == (models.NamespaceQuota{}) {
+		return nil, nil
+	}
+	return &quota, nil
+}
+
 // CmdNamespaceDelete implements the command: epinio namespace delete
 var CmdNamespaceDelete = &cobra.Command{
 	Use:               "delete NAME",
@@ -146,6 +209,204 @@ var CmdNamespaceShow = &cobra.Command{
 	},
 }
 
+// CmdNamespaceQuota implements the command: epinio namespace quota
+var CmdNamespaceQuota = &cobra.Command{
+	Use:               "quota NAME",
+	Short:             "Sets the quota of an epinio-controlled namespace",
+	Long:              "Sets the quota of an epinio-controlled namespace, replacing the current one. The limits which are not given are removed. Only admins manage quotas.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		quota, err := namespaceQuota(cmd)
+		if err != nil {
+			return err
+		}
+		if quota == nil {
+			quota = &models.NamespaceQuota{}
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.UpdateNamespaceQuota(args[0], *quota)
+		if err != nil {
+			return errors.Wrap(err, "error updating namespace quota")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceMembers implements the command: epinio namespace members
+var CmdNamespaceMembers = &cobra.Command{
+	Use:               "members NAME",
+	Short:             "Lists the users with access to an epinio-controlled namespace",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.NamespaceMembers(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error listing namespace members")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceAddMember implements the command: epinio namespace add-member
+var CmdNamespaceAddMember = &cobra.Command{
+	Use:               "add-member NAME USER",
+	Short:             "Gives a user access to an epinio-controlled namespace",
+	Long:              "Gives a user access to an epinio-controlled namespace. Adding a member again changes its role. Only admins and the owners of the namespace manage its members.",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		role, err := cmd.Flags().GetString("role")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --role")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.AddNamespaceMember(args[0], args[1], role)
+		if err != nil {
+			return errors.Wrap(err, "error adding namespace member")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceRemoveMember implements the command: epinio namespace remove-member
+var CmdNamespaceRemoveMember = &cobra.Command{
+	Use:               "remove-member NAME USER",
+	Short:             "Removes the access of a user to an epinio-controlled namespace",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.RemoveNamespaceMember(args[0], args[1])
+		if err != nil {
+			return errors.Wrap(err, "error removing namespace member")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceExport implements the command: epinio namespace export
+var CmdNamespaceExport = &cobra.Command{
+	Use:               "export NAME DIRECTORY",
+	Short:             "Exports an epinio-controlled namespace into a directory",
+	Long:              "Exports an epinio-controlled namespace into a directory. Writes the manifests of all applications, the configurations, and the services with their catalog references and bindings. With a passphrase the configuration values and service settings are encrypted.",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		passphrase, err := passphrase(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ExportNamespace(args[0], args[1], passphrase)
+		if err != nil {
+			return errors.Wrap(err, "error exporting namespace")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceImport implements the command: epinio namespace import
+var CmdNamespaceImport = &cobra.Command{
+	Use:   "import DIRECTORY",
+	Short: "Imports an epinio-controlled namespace from a directory",
+	Long:  "Imports an epinio-controlled namespace exported into a directory. Creates the namespace, the configurations, the services, and the applications, and binds the services. Only admins can set the exported quota, it is skipped for other users.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		passphrase, err := passphrase(cmd)
+		if err != nil {
+			return err
+		}
+
+		newName, err := cmd.Flags().GetString("as")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --as")
+		}
+
+		skipQuota, err := cmd.Flags().GetBool("skip-quota")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --skip-quota")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ImportNamespace(cmd.Context(), args[0], newName, passphrase, skipQuota)
+		if err != nil {
+			return errors.Wrap(err, "error importing namespace")
+		}
+
+		return nil
+	},
+}
+
+// passphrase is a helper for the namespace export and import commands, returning the
+// passphrase read from the file given by option --passphrase-file, if any
+func passphrase(cmd *cobra.Command) (string, error) {
+	path, err := cmd.Flags().GetString("passphrase-file")
+	if err != nil {
+		return "", errors.Wrap(err, "error reading option --passphrase-file")
+	}
+	if path == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading passphrase")
+	}
+
+	passphrase := strings.TrimSpace(string(content))
+	if passphrase == "" {
+		return "", errors.New("the passphrase file is empty")
+	}
+
+	return passphrase, nil
+}
+
 // askConfirmation is a helper for CmdNamespaceDelete to confirm a deletion request
 func askConfirmation(cmd *cobra.Command) bool {
 	reader := bufio.NewReader(os.Stdin)
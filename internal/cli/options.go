@@ -72,3 +72,11 @@ func envOption(cmd *cobra.Command) {
 func chartValueOption(cmd *cobra.Command) {
 	cmd.Flags().StringSliceP("chart-value", "v", []string{}, "chart customization to be used")
 }
+
+// templateOption initializes the --overlay, --vars-file and --var options for the
+// provided command, controlling the templating of the manifest
+func templateOption(cmd *cobra.Command) {
+	cmd.Flags().String("overlay", "", "name of the overlay merged over the manifest, e.g. prod for epinio.prod.yml")
+	cmd.Flags().StringArray("vars-file", []string{}, "yaml file with the values of the manifest variables")
+	cmd.Flags().StringArray("var", []string{}, "value of a manifest variable, as NAME=VALUE")
+}
@@ -16,7 +16,10 @@ var ()
 func init() {
 	// The following options override manifest data
 	CmdAppPush.Flags().StringP("git", "g", "", "Git repository and revision of sources separated by comma (e.g. GIT_URL,REVISION)")
+	CmdAppPush.Flags().String("git-credentials", "", "Name of the configuration holding the credentials for the git repository. SSH keys require the known_hosts of the git host")
+	CmdAppPush.Flags().Bool("git-submodules", false, "Recursively import the submodules of the git repository")
 	CmdAppPush.Flags().String("container-image-url", "", "Container image url for the app workload image")
+	CmdAppPush.Flags().Bool("container-image-track", false, "Periodically check the tag of the container image, and redeploy when it changes")
 	CmdAppPush.Flags().StringP("name", "n", "", "Application name. (mandatory if no manifest is provided)")
 	CmdAppPush.Flags().StringP("path", "p", "", "Path to application sources.")
 	CmdAppPush.Flags().String("builder-image", "", "Paketo builder image to use for staging")
@@ -27,6 +30,7 @@ func init() {
 	envOption(CmdAppPush)
 	chartValueOption(CmdAppPush)
 	instancesOption(CmdAppPush)
+	templateOption(CmdAppPush)
 }
 
 // CmdAppPush implements the command: epinio app push
@@ -58,7 +62,12 @@ var CmdAppPush = &cobra.Command{
 			manifestPath = filepath.Join(wd, "epinio.yml")
 		}
 
-		m, err := manifest.Get(manifestPath)
+		options, err := manifest.OptionsFrom(cmd)
+		if err != nil {
+			return err
+		}
+
+		m, err := manifest.Load(manifestPath, options)
 		if err != nil {
 			cmd.SilenceUsage = false
 			return errors.Wrap(err, "Manifest error")
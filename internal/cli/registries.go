@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CmdRegistry implements the command: epinio registry
+var CmdRegistry = &cobra.Command{
+	Use:           "registry",
+	Aliases:       []string{"registries"},
+	Short:         "Container registry credentials",
+	Long:          `Manage the pull credentials for container registries of the targeted namespace`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+func init() {
+	flags := CmdRegistryLogin.Flags()
+	flags.StringP("username", "u", "", "User name for the registry")
+	flags.StringP("password", "p", "", "Password for the registry")
+	flags.Bool("password-stdin", false, "Read the password from stdin")
+
+	CmdRegistry.AddCommand(CmdRegistryList)
+	CmdRegistry.AddCommand(CmdRegistryLogin)
+	CmdRegistry.AddCommand(CmdRegistryLogout)
+}
+
+// CmdRegistryList implements the command: epinio registry list
+var CmdRegistryList = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the registries the targeted namespace has credentials for",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.RegistryLogins()
+		if err != nil {
+			return errors.Wrap(err, "error listing registry logins")
+		}
+
+		return nil
+	},
+}
+
+// CmdRegistryLogin implements the command: epinio registry login
+var CmdRegistryLogin = &cobra.Command{
+	Use:   "login URL",
+	Short: "Store pull credentials for a container registry",
+	Long:  "Store pull credentials for a container registry in the targeted namespace. Application workloads of the namespace pull their images with them.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		username, err := cmd.Flags().GetString("username")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --username")
+		}
+		password, err := cmd.Flags().GetString("password")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --password")
+		}
+		passwordStdin, err := cmd.Flags().GetBool("password-stdin")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --password-stdin")
+		}
+
+		if passwordStdin {
+			if password != "" {
+				return errors.New("--password and --password-stdin are mutually exclusive")
+			}
+			input, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return errors.Wrap(err, "error reading password from stdin")
+			}
+			password = strings.TrimRight(string(input), "\r\n")
+		}
+
+		if username == "" || password == "" {
+			return errors.New("username and password are required")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.RegistryLogin(args[0], username, password)
+		if err != nil {
+			return errors.Wrap(err, "error logging into registry")
+		}
+
+		return nil
+	},
+}
+
+// CmdRegistryLogout implements the command: epinio registry logout
+var CmdRegistryLogout = &cobra.Command{
+	Use:   "logout URL",
+	Short: "Remove the pull credentials for a container registry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.RegistryLogout(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error logging out of registry")
+		}
+
+		return nil
+	},
+}
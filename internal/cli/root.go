@@ -96,7 +96,12 @@ func init() {
 	rootCmd.AddCommand(CmdInfo)
 	rootCmd.AddCommand(CmdClientSync)
 	rootCmd.AddCommand(CmdNamespace)
+	rootCmd.AddCommand(CmdRegistry)
+	rootCmd.AddCommand(CmdAdmin)
 	rootCmd.AddCommand(CmdAppPush) // shorthand access to `app push`.
+	rootCmd.AddCommand(CmdApply)
+	rootCmd.AddCommand(CmdManifest)
+	rootCmd.AddCommand(CmdEvents)
 	rootCmd.AddCommand(CmdApp)
 	rootCmd.AddCommand(CmdTarget)
 	rootCmd.AddCommand(CmdConfiguration)
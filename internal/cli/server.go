@@ -14,7 +14,12 @@ import (
 
 	"github.com/epinio/epinio/helpers/termui"
 	"github.com/epinio/epinio/helpers/tracelog"
+	"github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/configuration"
 	"github.com/epinio/epinio/internal/cli/server"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/gc"
+	"github.com/epinio/epinio/internal/services"
 	"github.com/epinio/epinio/internal/version"
 	"github.com/gin-gonic/gin"
 
@@ -79,6 +84,66 @@ func init() {
 	checkErr(err)
 	err = viper.BindEnv("app-image-exporter", "APP_IMAGE_EXPORTER")
 	checkErr(err)
+
+	flags.Duration("container-image-check-interval", 0, "(CONTAINER_IMAGE_CHECK_INTERVAL) Interval for checking the tags of tracked container images for changes. Leave empty to disable the check.")
+	err = viper.BindPFlag("container-image-check-interval", flags.Lookup("container-image-check-interval"))
+	checkErr(err)
+	err = viper.BindEnv("container-image-check-interval", "CONTAINER_IMAGE_CHECK_INTERVAL")
+	checkErr(err)
+
+	flags.Duration("image-gc-interval", 0, "(IMAGE_GC_INTERVAL) Interval for the garbage collection of application images in the Epinio registry. Leave empty to disable the scheduled collection.")
+	err = viper.BindPFlag("image-gc-interval", flags.Lookup("image-gc-interval"))
+	checkErr(err)
+	err = viper.BindEnv("image-gc-interval", "IMAGE_GC_INTERVAL")
+	checkErr(err)
+
+	flags.Int("image-gc-keep", 3, "(IMAGE_GC_KEEP) Number of newest images kept per application by the garbage collection of application images")
+	err = viper.BindPFlag("image-gc-keep", flags.Lookup("image-gc-keep"))
+	checkErr(err)
+	err = viper.BindEnv("image-gc-keep", "IMAGE_GC_KEEP")
+	checkErr(err)
+
+	flags.Duration("blob-prune-interval", 0, "(BLOB_PRUNE_INTERVAL) Interval for pruning the orphaned application source blobs from the S3 storage. Leave empty to disable the scheduled pruning.")
+	err = viper.BindPFlag("blob-prune-interval", flags.Lookup("blob-prune-interval"))
+	checkErr(err)
+	err = viper.BindEnv("blob-prune-interval", "BLOB_PRUNE_INTERVAL")
+	checkErr(err)
+
+	flags.Duration("blob-prune-age", 24*time.Hour, "(BLOB_PRUNE_AGE) Minimum age of the orphaned application source blobs deleted by the pruning")
+	err = viper.BindPFlag("blob-prune-age", flags.Lookup("blob-prune-age"))
+	checkErr(err)
+	err = viper.BindEnv("blob-prune-age", "BLOB_PRUNE_AGE")
+	checkErr(err)
+
+	flags.Duration("service-backup-check-interval", 5*time.Minute, "(SERVICE_BACKUP_CHECK_INTERVAL) Interval for checking the services with scheduled backups for due backups. Zero disables the scheduled backups.")
+	err = viper.BindPFlag("service-backup-check-interval", flags.Lookup("service-backup-check-interval"))
+	checkErr(err)
+	err = viper.BindEnv("service-backup-check-interval", "SERVICE_BACKUP_CHECK_INTERVAL")
+	checkErr(err)
+
+	flags.String("external-secrets-dir", "", "(EXTERNAL_SECRETS_DIR) Directory of the files configurations can reference as `file:PATH`, relative to the sub directory of their namespace. Empty disables file references.")
+	err = viper.BindPFlag("external-secrets-dir", flags.Lookup("external-secrets-dir"))
+	checkErr(err)
+	err = viper.BindEnv("external-secrets-dir", "EXTERNAL_SECRETS_DIR")
+	checkErr(err)
+
+	flags.String("vault-address", "", "(VAULT_ADDR) Address of the HashiCorp Vault configurations can reference as `vault:PATH#FIELD`, with PATH below their namespace, i.e. `MOUNT/NAMESPACE/...` or `MOUNT/data/NAMESPACE/...`. Empty disables vault references.")
+	err = viper.BindPFlag("vault-address", flags.Lookup("vault-address"))
+	checkErr(err)
+	err = viper.BindEnv("vault-address", "VAULT_ADDR")
+	checkErr(err)
+
+	flags.String("vault-token", "", "(VAULT_TOKEN) Token for reading the secrets of the HashiCorp Vault")
+	err = viper.BindPFlag("vault-token", flags.Lookup("vault-token"))
+	checkErr(err)
+	err = viper.BindEnv("vault-token", "VAULT_TOKEN")
+	checkErr(err)
+
+	flags.Duration("configuration-sync-interval", 5*time.Minute, "(CONFIGURATION_SYNC_INTERVAL) Interval for re-syncing the configuration values taken from external secret stores. Zero disables the sync.")
+	err = viper.BindPFlag("configuration-sync-interval", flags.Lookup("configuration-sync-interval"))
+	checkErr(err)
+	err = viper.BindEnv("configuration-sync-interval", "CONFIGURATION_SYNC_INTERVAL")
+	checkErr(err)
 }
 
 // CmdServer implements the command: epinio server
@@ -106,6 +171,34 @@ var CmdServer = &cobra.Command{
 		listeningPort := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
 		ui.Normal().Msg("listening on localhost on port " + listeningPort)
 
+		if interval := viper.GetDuration("container-image-check-interval"); interval > 0 {
+			go application.WatchContainerImages(cmd.Context(), logger.WithName("ContainerImages"), interval)
+		}
+		if interval := viper.GetDuration("image-gc-interval"); interval > 0 {
+			go gc.WatchImages(cmd.Context(), logger.WithName("ImageGC"), interval, viper.GetInt("image-gc-keep"))
+		}
+		if interval := viper.GetDuration("blob-prune-interval"); interval > 0 {
+			go gc.WatchBlobs(cmd.Context(), logger.WithName("BlobPrune"), interval, viper.GetDuration("blob-prune-age"))
+		}
+		go services.ReconcileProvisioning(cmd.Context(), logger.WithName("ServiceReconcile"))
+		if interval := viper.GetDuration("service-backup-check-interval"); interval > 0 {
+			go services.WatchBackups(cmd.Context(), logger.WithName("ServiceBackups"), interval)
+		}
+
+		if dir := viper.GetString("external-secrets-dir"); dir != "" {
+			configurations.RegisterSecretStore("file", configurations.FileStore{Dir: dir})
+		}
+		if address := viper.GetString("vault-address"); address != "" {
+			configurations.RegisterSecretStore("vault", configurations.VaultStore{
+				Address: address,
+				Token:   viper.GetString("vault-token"),
+			})
+		}
+		if interval := viper.GetDuration("configuration-sync-interval"); interval > 0 {
+			go configurations.WatchExternal(cmd.Context(), logger.WithName("ConfigurationSync"), interval,
+				configuration.RestartEnvBoundApps)
+		}
+
 		return startServerGracefully(listener, handler)
 	},
 }
@@ -44,6 +44,7 @@ func NewHandler(logger logr.Logger) (*gin.Engine, error) {
 	// | Path              | Notes      | Logging
 	// | ---               | ---        | ----
 	// | <Root>/...        | API        | Via "<Root>" Group
+	// | <HookRoot>/...    | Webhooks   | Via "<HookRoot>" Group
 	// | /ready            | L/R Probes |
 	// | /namespaces/target/:namespace | ditto      | ditto
 
@@ -120,6 +121,13 @@ func NewHandler(logger logr.Logger) (*gin.Engine, error) {
 		apiv1.Spice(wapiRoutesGroup)
 	}
 
+	// Register webhook routes
+	// No authentication. The handlers verify the signatures of the requests.
+	{
+		hookRoutesGroup := router.Group(apiv1.HookRoot)
+		apiv1.Zest(hookRoutesGroup)
+	}
+
 	// print all registered routes
 	if logger.V(3).Enabled() {
 		for _, h := range router.Routes() {
@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +23,7 @@ var CmdServices = &cobra.Command{
 
 func init() {
 	CmdServiceDelete.Flags().Bool("unbind", false, "Unbind from applications before deleting")
+	CmdServiceDelete.Flags().Bool("force", false, "Remove the shares of shared services before deleting")
 	CmdServices.AddCommand(CmdServiceCatalog)
 	CmdServices.AddCommand(CmdServiceCreate)
 	CmdServices.AddCommand(CmdServiceBind)
@@ -29,6 +33,52 @@ func init() {
 	CmdServices.AddCommand(CmdServiceList)
 
 	CmdServiceList.Flags().Bool("all", false, "list all services")
+
+	CmdServiceCreate.Flags().Bool("wait", false, "wait for the service to be provisioned")
+	CmdServiceCreate.Flags().StringSlice("set", []string{}, "service setting to use, as `name=value`. See the catalog service for the available settings")
+
+	CmdServices.AddCommand(CmdServiceUpdate)
+	CmdServices.AddCommand(CmdServiceUpgrade)
+
+	CmdServiceUpdate.Flags().StringSlice("set", []string{}, "service setting to change, as `name=value`. See the catalog service for the available settings")
+	CmdServiceUpdate.Flags().Bool("dry-run", false, "only show the change of the values")
+	CmdServiceUpgrade.Flags().String("to-version", "", "chart version to upgrade to. Defaults to the chart of the catalog service")
+	CmdServiceUpgrade.Flags().Bool("dry-run", false, "only show the change of the values")
+
+	CmdServices.AddCommand(CmdServiceBackup)
+	CmdServices.AddCommand(CmdServiceBackups)
+	CmdServices.AddCommand(CmdServiceRestore)
+	CmdServices.AddCommand(CmdServiceBackupSchedule)
+
+	CmdServiceBackupSchedule.Flags().String("every", "", "interval of the backups, like 24h. Empty disables the scheduled backups")
+	CmdServiceBackupSchedule.Flags().Int("keep", 0, "number of newest backups to keep after a scheduled backup. Zero keeps all backups")
+
+	CmdServices.AddCommand(CmdServiceRotateCredentials)
+
+	CmdServices.AddCommand(CmdServiceShare)
+	CmdServices.AddCommand(CmdServiceUnshare)
+
+	CmdServices.AddCommand(CmdServicePortForward)
+
+	CmdServicePortForward.Flags().StringSlice("address", []string{"localhost"}, "Addresses to listen on (comma separated). Only accepts IP addresses or localhost as a value. When localhost is supplied, kubectl will try to bind on both 127.0.0.1 and ::1 and will fail if neither of these addresses are available to bind.")
+
+	CmdServiceShare.Flags().String("to-namespace", "", "namespace to share the service with")
+	CmdServiceUnshare.Flags().String("from-namespace", "", "namespace to stop sharing the service with")
+	err := CmdServiceShare.MarkFlagRequired("to-namespace")
+	checkErr(err)
+	err = CmdServiceUnshare.MarkFlagRequired("from-namespace")
+	checkErr(err)
+
+	CmdServiceCatalog.AddCommand(CmdServiceCatalogAdd)
+	CmdServiceCatalog.AddCommand(CmdServiceCatalogUpdate)
+	CmdServiceCatalog.AddCommand(CmdServiceCatalogDelete)
+
+	CmdServiceCatalogAdd.Flags().StringP("file", "f", "", "file holding the Service resource of the catalog service")
+	CmdServiceCatalogUpdate.Flags().StringP("file", "f", "", "file holding the Service resource of the catalog service")
+	err = CmdServiceCatalogAdd.MarkFlagRequired("file")
+	checkErr(err)
+	err = CmdServiceCatalogUpdate.MarkFlagRequired("file")
+	checkErr(err)
 }
 
 var CmdServiceCatalog = &cobra.Command{
@@ -59,6 +109,138 @@ var CmdServiceCatalog = &cobra.Command{
 	},
 }
 
+var CmdServiceRotateCredentials = &cobra.Command{
+	Use:               "rotate-credentials SERVICENAME",
+	Short:             "Rotate the credentials of a service SERVICENAME",
+	Long:              "Set the credentials of a service SERVICENAME to new values, as declared by its catalog service. The applications bound to the service are restarted to pick up the new values.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceRotateCredentials(args[0])
+		return errors.Wrap(err, "error rotating service credentials")
+	},
+}
+
+var CmdServiceShare = &cobra.Command{
+	Use:               "share SERVICENAME --to-namespace NAMESPACE",
+	Short:             "Share a service SERVICENAME with another namespace",
+	Long:              "Project the configurations of a service SERVICENAME into another namespace, as read-only configurations. The applications of that namespace bind them with `epinio configuration bind`. Sharing again refreshes the configurations.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		target, err := cmd.Flags().GetString("to-namespace")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --to-namespace")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceShare(args[0], target)
+		return errors.Wrap(err, "error sharing service")
+	},
+}
+
+var CmdServiceUnshare = &cobra.Command{
+	Use:               "unshare SERVICENAME --from-namespace NAMESPACE",
+	Short:             "Stop sharing a service SERVICENAME with another namespace",
+	Long:              "Remove the configurations of a service SERVICENAME projected into another namespace. They are unbound from the applications of that namespace first.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		target, err := cmd.Flags().GetString("from-namespace")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --from-namespace")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceUnshare(args[0], target)
+		return errors.Wrap(err, "error unsharing service")
+	},
+}
+
+var CmdServiceCatalogAdd = &cobra.Command{
+	Use:   "add -f FILE",
+	Short: "Add a service to the Epinio catalog",
+	Long:  "Add a service to the Epinio catalog. The file holds the Service resource of the catalog service. Restricted to admins.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --file")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceCatalogAdd(file)
+		return errors.Wrap(err, "error adding Epinio catalog service")
+	},
+}
+
+var CmdServiceCatalogUpdate = &cobra.Command{
+	Use:   "update -f FILE",
+	Short: "Update a service of the Epinio catalog",
+	Long:  "Replace a service of the Epinio catalog. The file holds the Service resource of the catalog service. Existing services are not changed. Restricted to admins.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --file")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceCatalogUpdate(file)
+		return errors.Wrap(err, "error updating Epinio catalog service")
+	},
+}
+
+var CmdServiceCatalogDelete = &cobra.Command{
+	Use:               "delete NAME",
+	Short:             "Remove a service from the Epinio catalog",
+	Long:              "Remove a service from the Epinio catalog. Catalog services still used by services are not removed. Restricted to admins.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingCatalogFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceCatalogDelete(args[0])
+		return errors.Wrap(err, "error removing Epinio catalog service")
+	},
+}
+
 var CmdServiceCreate = &cobra.Command{
 	Use:               "create CATALOGSERVICENAME SERVICENAME",
 	Short:             "Create a service SERVICENAME of an Epinio catalog service CATALOGSERVICENAME",
@@ -75,11 +257,193 @@ var CmdServiceCreate = &cobra.Command{
 		catalogServiceName := args[0]
 		serviceName := args[1]
 
-		err = client.ServiceCreate(catalogServiceName, serviceName)
+		settings, err := serviceSettings(cmd)
+		if err != nil {
+			return err
+		}
+
+		wait, err := cmd.Flags().GetBool("wait")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --wait")
+		}
+
+		err = client.ServiceCreate(catalogServiceName, serviceName, settings, wait)
 		return errors.Wrap(err, "error creating service")
 	},
 }
 
+var CmdServiceUpdate = &cobra.Command{
+	Use:               "update SERVICENAME",
+	Short:             "Change the settings of a service SERVICENAME",
+	Long:              "Change the settings of a service SERVICENAME. The change of the helm values is shown before the service is redeployed.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		settings, err := serviceSettings(cmd)
+		if err != nil {
+			return err
+		}
+		if len(settings) == 0 {
+			return errors.New("nothing to change, use --set")
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --dry-run")
+		}
+
+		err = client.ServiceUpdate(args[0], settings, false, "", dryRun)
+		return errors.Wrap(err, "error updating service")
+	},
+}
+
+var CmdServiceUpgrade = &cobra.Command{
+	Use:               "upgrade SERVICENAME",
+	Short:             "Upgrade a service SERVICENAME to a newer chart version",
+	Long:              "Upgrade a service SERVICENAME to the chart of its catalog service, or to the chart version specified with --to-version. The change of the helm values is shown before the service is redeployed.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		version, err := cmd.Flags().GetString("to-version")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --to-version")
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --dry-run")
+		}
+
+		err = client.ServiceUpdate(args[0], models.AppSettings{}, true, version, dryRun)
+		return errors.Wrap(err, "error upgrading service")
+	},
+}
+
+var CmdServiceBackup = &cobra.Command{
+	Use:               "backup SERVICENAME",
+	Short:             "Back up the data of a service SERVICENAME",
+	Long:              "Back up the data of a service SERVICENAME into the S3 storage of Epinio, using the backup command of its catalog service.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceBackup(args[0])
+		return errors.Wrap(err, "error backing up service")
+	},
+}
+
+var CmdServiceBackups = &cobra.Command{
+	Use:               "backups SERVICENAME",
+	Short:             "Lists the backups of a service SERVICENAME",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceBackups(args[0])
+		return errors.Wrap(err, "error listing service backups")
+	},
+}
+
+var CmdServiceRestore = &cobra.Command{
+	Use:               "restore SERVICENAME BACKUPID",
+	Short:             "Restore the data of a service SERVICENAME from the backup BACKUPID",
+	Long:              "Restore the data of a service SERVICENAME from the backup BACKUPID, using the restore command of its catalog service.",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceRestore(args[0], args[1])
+		return errors.Wrap(err, "error restoring service")
+	},
+}
+
+var CmdServiceBackupSchedule = &cobra.Command{
+	Use:               "backup-schedule SERVICENAME",
+	Short:             "Schedule the backups of a service SERVICENAME",
+	Long:              "Schedule the backups of a service SERVICENAME. After each scheduled backup only the newest backups are kept, as per --keep.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		every, err := cmd.Flags().GetString("every")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --every")
+		}
+		if every != "" {
+			if _, err := time.ParseDuration(every); err != nil {
+				return errors.Wrap(err, "error parsing option --every")
+			}
+		}
+		keep, err := cmd.Flags().GetInt("keep")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --keep")
+		}
+		if keep < 0 {
+			return errors.New("--keep must not be negative")
+		}
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceBackupSchedule(args[0], every, keep)
+		return errors.Wrap(err, "error scheduling service backups")
+	},
+}
+
+// serviceSettings returns the service settings specified with the --set option
+func serviceSettings(cmd *cobra.Command) (models.AppSettings, error) {
+	assignments, err := cmd.Flags().GetStringSlice("set")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --set")
+	}
+
+	settings := models.AppSettings{}
+	for _, assignment := range assignments {
+		pieces := strings.SplitN(assignment, "=", 2)
+		if len(pieces) < 2 {
+			return nil, errors.New("Bad --set `" + assignment + "`, expected `name=value` as value")
+		}
+		settings[pieces[0]] = pieces[1]
+	}
+
+	return settings, nil
+}
+
 var CmdServiceShow = &cobra.Command{
 	Use:               "show SERVICENAME",
 	Short:             "Show details of a service SERVICENAME",
@@ -123,12 +487,17 @@ var CmdServiceDelete = &cobra.Command{
 			return errors.Wrap(err, "error reading option --unbind")
 		}
 
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --force")
+		}
+
 		client, err := usercmd.New(cmd.Context())
 		if err != nil {
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.ServiceDelete(args, unbind)
+		err = client.ServiceDelete(args, unbind, force)
 		return errors.Wrap(err, "error deleting service")
 	},
 }
@@ -174,6 +543,37 @@ var CmdServiceUnbind = &cobra.Command{
 	},
 }
 
+// CmdServicePortForward implements the command: epinio service port-forward
+var CmdServicePortForward = &cobra.Command{
+	Use:   "port-forward NAME [LOCAL_PORT:]REMOTE_PORT [...[LOCAL_PORT_N:]REMOTE_PORT_N]",
+	Short: "forward one or more local ports to a service",
+	Long: `Forward one or more local ports to a running pod of the service.
+
+The remote ports are the ports of the service's internal routes. As kubernetes forwards to
+pods, not to kubernetes services, they are mapped to the target ports of the pod. Without a
+local port the remote port is listened on. Remote ports unknown to the service are taken
+as ports of the pod.`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: matchingServiceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New(cmd.Context())
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		address, err := cmd.Flags().GetStringSlice("address")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --address")
+		}
+
+		err = client.ServicePortForward(cmd.Context(), args[0], address, args[1:])
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error port forwarding to service")
+	},
+}
+
 var CmdServiceList = &cobra.Command{
 	Use:   "list",
 	Short: "List all the services in the targeted namespace",
@@ -0,0 +1,112 @@
+package usercmd
+
+import (
+	"strconv"
+
+	"github.com/docker/go-units"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ImageGC garbage collects the application images in the Epinio registry, keeping the
+// newest images of each application. A dry run only reports the images to delete.
+func (c *EpinioClient) ImageGC(keep int, dryRun bool) error {
+	log := c.Log.WithName("ImageGC")
+	log.Info("start")
+	defer log.Info("return")
+
+	title := "Collecting application images"
+	if dryRun {
+		title = "Collecting application images (dry run)"
+	}
+
+	msg := c.ui.Note()
+	if keep > 0 {
+		msg = msg.WithStringValue("Keep", strconv.Itoa(keep))
+	}
+	msg.Msg(title)
+
+	result, err := c.API.ImageGC(models.ImageGCRequest{
+		Keep:   keep,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Deleted) > 0 {
+		table := c.ui.Normal().WithTable("Repository", "Tag", "Digest")
+		for _, image := range result.Deleted {
+			table = table.WithTableRow(image.Repository, image.Tag, image.Digest)
+		}
+		if dryRun {
+			table.Msg("Images to delete")
+		} else {
+			table.Msg("Deleted images")
+		}
+	}
+
+	for _, message := range result.Errors {
+		c.ui.Problem().Msg(message)
+	}
+
+	c.ui.Success().
+		WithStringValue("Deleted", strconv.Itoa(len(result.Deleted))).
+		WithStringValue("Kept", strconv.Itoa(result.Kept)).
+		WithStringValue("Reclaimed", units.HumanSize(float64(result.Reclaimed))).
+		Msg("Image garbage collection done. The registry frees the space when it runs its own garbage collection.")
+
+	return nil
+}
+
+// BlobPrune prunes the orphaned application source blobs from the S3 storage. An empty
+// olderThan uses the server's default age. A dry run only reports the blobs to delete.
+func (c *EpinioClient) BlobPrune(olderThan string, dryRun bool) error {
+	log := c.Log.WithName("BlobPrune")
+	log.Info("start")
+	defer log.Info("return")
+
+	title := "Pruning orphaned application source blobs"
+	if dryRun {
+		title = "Pruning orphaned application source blobs (dry run)"
+	}
+
+	msg := c.ui.Note()
+	if olderThan != "" {
+		msg = msg.WithStringValue("Older Than", olderThan)
+	}
+	msg.Msg(title)
+
+	result, err := c.API.BlobPrune(models.BlobPruneRequest{
+		OlderThan: olderThan,
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Deleted) > 0 {
+		table := c.ui.Normal().WithTable("Blob", "Namespace", "App", "Size", "Last Modified")
+		for _, blob := range result.Deleted {
+			table = table.WithTableRow(blob.BlobUID, blob.Namespace, blob.App,
+				units.HumanSize(float64(blob.Size)), blob.LastModified.String())
+		}
+		if dryRun {
+			table.Msg("Blobs to delete")
+		} else {
+			table.Msg("Deleted blobs")
+		}
+	}
+
+	for _, message := range result.Errors {
+		c.ui.Problem().Msg(message)
+	}
+
+	c.ui.Success().
+		WithStringValue("Deleted", strconv.Itoa(len(result.Deleted))).
+		WithStringValue("Kept", strconv.Itoa(result.Kept)).
+		WithStringValue("Reclaimed", units.HumanSize(float64(result.Reclaimed))).
+		Msg("Blob pruning done.")
+
+	return nil
+}
@@ -266,27 +266,77 @@ func (c *EpinioClient) AppManifest(appName, manifestPath string) error {
 
 	details.Info("show application")
 
-	app, err := c.API.AppShow(c.Settings.Namespace, appName)
+	m, err := c.appManifest(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	yaml, err := yaml.Marshal(m)
 	if err != nil {
 		return err
 	}
 
+	err = os.WriteFile(manifestPath, yaml, 0600)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Saved")
+
+	return nil
+}
+
+// appManifest returns the manifest of the named application in the namespace, i.e. its
+// configuration, origin, and build environment
+func (c *EpinioClient) appManifest(namespace, appName string) (models.ApplicationManifest, error) {
 	m := models.ApplicationManifest{}
+
+	app, err := c.API.AppShow(namespace, appName)
+	if err != nil {
+		return m, err
+	}
+
+	buildEnv, err := c.API.BuildEnvList(namespace, appName)
+	if err != nil {
+		return m, err
+	}
+
 	m.Name = appName
 	m.Configuration = app.Configuration
 	m.Origin = app.Origin
+	if len(buildEnv) > 0 {
+		m.Staging.Environment = buildEnv
+	}
 
-	yaml, err := yaml.Marshal(m)
-	if err != nil {
+	return m, nil
+}
+
+// AppWebhook displays the information needed to configure a git webhook for the named
+// application, in the targeted namespace
+func (c *EpinioClient) AppWebhook(appName string) error {
+	log := c.Log.WithName("AppWebhook").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Show application git webhook")
+
+	if err := c.TargetOk(); err != nil {
 		return err
 	}
 
-	err = os.WriteFile(manifestPath, yaml, 0600)
+	webhook, err := c.API.AppWebhook(c.Settings.Namespace, appName)
 	if err != nil {
 		return err
 	}
 
-	c.ui.Success().Msg("Saved")
+	c.ui.Success().
+		WithStringValue("Payload URL", webhook.URL).
+		WithStringValue("Content Type", "application/json").
+		WithStringValue("Secret", webhook.Secret).
+		Msg("Configure the repository's push webhook with these settings")
 
 	return nil
 }
@@ -434,6 +484,63 @@ func (c *EpinioClient) AppExec(ctx context.Context, appName, instance string) er
 	return c.API.AppExec(c.Settings.Namespace, appName, instance, tty)
 }
 
+// AppDebug opens a shell in a debug container running the image, added to an instance of
+// the application. The container shares the process namespace of the application, for
+// inspection with the tools of the image.
+func (c *EpinioClient) AppDebug(ctx context.Context, appName, instance, image string) error {
+	log := c.Log.WithName("AppDebug").WithValues("Namespace", c.Settings.Namespace,
+		"Application", appName, "Image", image)
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName)
+
+	if instance != "" {
+		msg = msg.WithStringValue("Instance", instance)
+	}
+	if image != "" {
+		msg = msg.WithStringValue("Image", image)
+	}
+
+	msg.Msg("Debugging application")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	tty := kubectlterm.TTY{
+		In:     os.Stdin,
+		Out:    os.Stdout,
+		Raw:    true,
+		TryDev: true,
+	}
+
+	return c.API.AppDebug(c.Settings.Namespace, appName, instance, image, tty)
+}
+
+// AppExecCommand runs the command in an instance of the application, without TTY. The
+// standard streams of the command are those of the client, for use in scripts. Thus
+// nothing else is printed.
+func (c *EpinioClient) AppExecCommand(ctx context.Context, appName, instance string, command []string) error {
+	log := c.Log.WithName("AppExecCommand").WithValues("Namespace", c.Settings.Namespace,
+		"Application", appName, "Instance", instance, "Command", command)
+	log.Info("start")
+	defer log.Info("return")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	return c.API.AppExecCommand(c.Settings.Namespace, appName, instance, client.ExecOpts{
+		Command: command,
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+	})
+}
+
 func (c *EpinioClient) AppPortForward(ctx context.Context, appName, instance string, address, ports []string) error {
 	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
 	log.Info("start")
@@ -0,0 +1,150 @@
+package usercmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/pkg/api/core/v1/client"
+	"github.com/pkg/errors"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// AppCopyFrom copies the file or directory at the path in an instance of the application
+// to the local destination. An existing destination directory receives the copy,
+// otherwise the destination is the path of the copy. The copy is streamed as tar
+// archive over the exec channel, and requires `tar` in the application image.
+func (c *EpinioClient) AppCopyFrom(appName, instance, source, destination string) error {
+	log := c.Log.WithName("AppCopyFrom").WithValues("Namespace", c.Settings.Namespace,
+		"Application", appName, "Source", source, "Destination", destination)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Source", source).
+		WithStringValue("Destination", destination).
+		Msg("Copying from application")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	source = path.Clean(source)
+	name := filepath.Base(destination)
+	dir := filepath.Dir(destination)
+	if info, err := os.Stat(destination); err == nil && info.IsDir() {
+		name = path.Base(source)
+		dir = destination
+	}
+
+	reader, writer := io.Pipe()
+	stderr := &bytes.Buffer{}
+	done := make(chan error, 1)
+
+	go func() {
+		err := c.API.AppExecCommand(c.Settings.Namespace, appName, instance, client.ExecOpts{
+			Command: []string{"tar", "cf", "-", "-C", path.Dir(source), path.Base(source)},
+			Stdout:  writer,
+			Stderr:  stderr,
+		})
+		_ = writer.CloseWithError(err)
+		done <- err
+	}()
+
+	err := helpers.UntarFrom(reader, dir, name)
+	// Drain the remainder, for the command to complete.
+	_, _ = io.Copy(io.Discard, reader)
+	if cerr := <-done; cerr != nil {
+		return copyError(cerr, stderr)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Copied")
+	return nil
+}
+
+// AppCopyTo copies the local file or directory to the path in an instance of the
+// application. An existing destination directory receives the copy, otherwise the
+// destination is the path of the copy. The copy is streamed as tar archive over the exec
+// channel, and requires `tar` in the application image.
+func (c *EpinioClient) AppCopyTo(appName, instance, source, destination string) error {
+	log := c.Log.WithName("AppCopyTo").WithValues("Namespace", c.Settings.Namespace,
+		"Application", appName, "Source", source, "Destination", destination)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Source", source).
+		WithStringValue("Destination", destination).
+		Msg("Copying to application")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(source); err != nil {
+		return errors.Wrap(err, "source not accessible")
+	}
+
+	destination = path.Clean(destination)
+	name := path.Base(destination)
+	dir := path.Dir(destination)
+
+	// Check if the destination is an existing directory.
+	err := c.API.AppExecCommand(c.Settings.Namespace, appName, instance, client.ExecOpts{
+		Command: []string{"test", "-d", destination},
+		Stdout:  io.Discard,
+		Stderr:  io.Discard,
+	})
+	if err == nil {
+		name = filepath.Base(source)
+		dir = destination
+	} else if !isExitError(err) {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(helpers.TarTo(writer, source, name))
+	}()
+
+	stderr := &bytes.Buffer{}
+	err = c.API.AppExecCommand(c.Settings.Namespace, appName, instance, client.ExecOpts{
+		Command: []string{"tar", "xf", "-", "-C", dir},
+		Stdin:   reader,
+		Stdout:  io.Discard,
+		Stderr:  stderr,
+	})
+	_ = reader.Close()
+	if err != nil {
+		return copyError(err, stderr)
+	}
+
+	c.ui.Success().Msg("Copied")
+	return nil
+}
+
+// isExitError returns true if the error is the non-zero exit status of a command run in
+// an application.
+func isExitError(err error) bool {
+	var exitErr utilexec.ExitError
+	return errors.As(err, &exitErr)
+}
+
+// copyError returns the error of the tar command of a copy, with its error output.
+func copyError(err error, stderr *bytes.Buffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return errors.Wrap(err, message)
+	}
+	return err
+}
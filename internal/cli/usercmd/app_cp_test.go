@@ -0,0 +1,78 @@
+package usercmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+var _ = Describe("Client App copy unit tests", func() {
+	var fake *usercmdfakes.FakeAPIClient
+	var epinioClient *usercmd.EpinioClient
+	var dir string
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+		dir = GinkgoT().TempDir()
+
+		var err error
+		epinioClient, err = usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("copies a file from the application", func() {
+		source := filepath.Join(dir, "remote.txt")
+		Expect(os.WriteFile(source, []byte("log"), 0600)).To(Succeed())
+
+		fake.AppExecCommandStub = func(namespace, appName, instance string, opts client.ExecOpts) error {
+			Expect(opts.Command).To(Equal([]string{"tar", "cf", "-", "-C", "/workspace", "log.txt"}))
+			return helpers.TarTo(opts.Stdout, source, "log.txt")
+		}
+
+		Expect(epinioClient.AppCopyFrom("web", "", "/workspace/log.txt", dir)).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(dir, "log.txt"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("log"))
+	})
+
+	It("copies a file to the application", func() {
+		source := filepath.Join(dir, "local.txt")
+		Expect(os.WriteFile(source, []byte("config"), 0600)).To(Succeed())
+		received := filepath.Join(dir, "received")
+
+		fake.AppExecCommandStub = func(namespace, appName, instance string, opts client.ExecOpts) error {
+			if opts.Command[0] == "test" {
+				// Not a directory
+				return utilexec.CodeExitError{Err: os.ErrNotExist, Code: 1}
+			}
+			Expect(opts.Command).To(Equal([]string{"tar", "xf", "-", "-C", "/workspace"}))
+			return helpers.UntarFrom(opts.Stdin, received, "app.conf")
+		}
+
+		Expect(epinioClient.AppCopyTo("web", "", source, "/workspace/app.conf")).To(Succeed())
+		Expect(fake.AppExecCommandCallCount()).To(Equal(2))
+
+		content, err := os.ReadFile(filepath.Join(received, "app.conf"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("config"))
+	})
+
+	It("reports the errors of the copy", func() {
+		fake.AppExecCommandStub = func(namespace, appName, instance string, opts client.ExecOpts) error {
+			_, _ = opts.Stderr.Write([]byte("tar: missing: No such file or directory"))
+			return utilexec.CodeExitError{Err: os.ErrNotExist, Code: 2}
+		}
+
+		err := epinioClient.AppCopyFrom("web", "", "/missing", filepath.Join(dir, "x"))
+		Expect(err).To(MatchError(ContainSubstring("No such file or directory")))
+	})
+})
@@ -0,0 +1,653 @@
+package usercmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+)
+
+// StackChange is a single step of the plan converging the targeted namespace to a stack
+// manifest.
+type StackChange struct {
+	Kind    string // app, configuration, service, binding
+	Name    string
+	Action  string // create, update, deploy, delete, bind, unbind
+	Details string
+
+	apply func() error
+}
+
+// Apply converges the targeted namespace to the stack manifest. It computes the plan
+// against the current state, shows it, and then creates, updates, and binds as needed.
+// With prune the applications, services, and configurations not declared by the stack
+// are deleted. A dry run stops after showing the plan.
+func (c *EpinioClient) Apply(ctx context.Context, stack models.StackManifest, prune, dryRun bool) error {
+	log := c.Log.WithName("Apply").WithValues("Namespace", c.Settings.Namespace, "Manifest", stack.Self)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Manifest", stack.Self).
+		WithBoolValue("Prune", prune).
+		Msg("Applying stack...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	plan, err := c.stackPlan(ctx, stack, prune)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		c.ui.Success().Msg("Nothing to do, the namespace matches the stack.")
+		return nil
+	}
+
+	msg := c.ui.Note().WithTable("Kind", "Name", "Action", "Changes")
+	for _, change := range plan {
+		msg = msg.WithTableRow(change.Kind, change.Name, change.Action, change.Details)
+	}
+	msg.Msg("Plan:")
+
+	if dryRun {
+		c.ui.Exclamation().Msg("Dry run, nothing was changed.")
+		return nil
+	}
+
+	for _, change := range plan {
+		c.ui.Normal().Msgf("%s %s %s ...", change.Action, change.Kind, change.Name)
+
+		if err := change.apply(); err != nil {
+			return errors.Wrapf(err, "%s %s %s", change.Action, change.Kind, change.Name)
+		}
+	}
+
+	c.ui.Success().WithIntValue("Changes", len(plan)).Msg("Stack applied.")
+
+	return nil
+}
+
+// stackPlan returns the changes converging the targeted namespace to the stack, in the
+// order they have to be made. Configurations and services are created before the
+// applications using them, the services are bound after. Deletions come last,
+// applications first.
+func (c *EpinioClient) stackPlan(ctx context.Context, stack models.StackManifest, prune bool) ([]StackChange, error) {
+	namespace := c.Settings.Namespace
+
+	apps, err := c.API.Apps(namespace)
+	if err != nil {
+		return nil, err
+	}
+	configurations, err := c.API.Configurations(namespace)
+	if err != nil {
+		return nil, err
+	}
+	services, err := c.API.ServiceList(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	currentApps := map[string]models.App{}
+	for _, app := range apps {
+		currentApps[app.Meta.Name] = app
+	}
+
+	// Configurations created by services, or shared with the namespace, are not under
+	// the control of the stack.
+	currentConfigurations := map[string]struct{}{}
+	serviceConfigurations := map[string]struct{}{}
+	for _, configuration := range configurations {
+		if configuration.Configuration.Origin != "" || configuration.Configuration.OriginNamespace != "" {
+			serviceConfigurations[configuration.Meta.Name] = struct{}{}
+			continue
+		}
+		currentConfigurations[configuration.Meta.Name] = struct{}{}
+	}
+
+	currentServices := map[string]models.Service{}
+	for _, service := range services {
+		currentServices[service.Meta.Name] = service
+	}
+
+	plan := []StackChange{}
+
+	declaredConfigurations := map[string]struct{}{}
+	for _, configuration := range stack.Configurations {
+		declaredConfigurations[configuration.Name] = struct{}{}
+
+		if _, ok := serviceConfigurations[configuration.Name]; ok {
+			return nil, fmt.Errorf("configuration %s belongs to a service", configuration.Name)
+		}
+
+		if _, ok := currentConfigurations[configuration.Name]; !ok {
+			plan = append(plan, c.configurationCreate(configuration))
+			continue
+		}
+
+		change, err := c.configurationUpdate(configuration)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			plan = append(plan, *change)
+		}
+	}
+
+	declaredServices := map[string]struct{}{}
+	for _, service := range stack.Services {
+		declaredServices[service.Name] = struct{}{}
+
+		current, ok := currentServices[service.Name]
+		if !ok {
+			plan = append(plan, c.serviceCreate(service))
+			continue
+		}
+
+		change, err := c.serviceUpdate(service, current)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			plan = append(plan, *change)
+		}
+	}
+
+	declaredApps := map[string]struct{}{}
+	for _, app := range stack.Apps {
+		declaredApps[app.Name] = struct{}{}
+
+		current, ok := currentApps[app.Name]
+		if !ok {
+			plan = append(plan, c.appCreate(ctx, app))
+			continue
+		}
+
+		change := c.appUpdate(ctx, app, current, serviceConfigurations)
+		if change != nil {
+			plan = append(plan, *change)
+		}
+	}
+
+	for _, service := range stack.Services {
+		for _, appName := range service.BoundApps {
+			_, declared := declaredApps[appName]
+			_, current := currentApps[appName]
+			if !declared && !current {
+				return nil, fmt.Errorf("service %s is bound to the unknown app %s", service.Name, appName)
+			}
+		}
+
+		plan = append(plan, c.serviceBindings(service, currentServices[service.Name].BoundApps)...)
+	}
+
+	if !prune {
+		return plan, nil
+	}
+
+	for _, name := range undeclared(currentApps, declaredApps) {
+		name := name
+		plan = append(plan, StackChange{
+			Kind:   models.StackKindApp,
+			Name:   name,
+			Action: "delete",
+			apply: func() error {
+				_, err := c.API.AppDelete(namespace, []string{name})
+				return err
+			},
+		})
+	}
+
+	for _, name := range undeclared(currentServices, declaredServices) {
+		name := name
+		plan = append(plan, StackChange{
+			Kind:   models.StackKindService,
+			Name:   name,
+			Action: "delete",
+			apply: func() error {
+				_, err := c.API.ServiceDelete(models.ServiceDeleteRequest{Unbind: true},
+					namespace, []string{name}, passError)
+				return err
+			},
+		})
+	}
+
+	for _, name := range undeclared(currentConfigurations, declaredConfigurations) {
+		name := name
+		plan = append(plan, StackChange{
+			Kind:   models.StackKindConfiguration,
+			Name:   name,
+			Action: "delete",
+			apply: func() error {
+				_, err := c.API.ConfigurationDelete(models.ConfigurationDeleteRequest{Unbind: true},
+					namespace, []string{name}, passError)
+				return err
+			},
+		})
+	}
+
+	return plan, nil
+}
+
+// configurationCreate returns the change creating the declared configuration.
+func (c *EpinioClient) configurationCreate(configuration models.ConfigurationManifest) StackChange {
+	return StackChange{
+		Kind:    models.StackKindConfiguration,
+		Name:    configuration.Name,
+		Action:  "create",
+		Details: strings.Join(append(keysOf(configuration.Data), keysOf(configuration.External)...), ", "),
+		apply: func() error {
+			_, err := c.API.ConfigurationCreate(models.ConfigurationCreateRequest{
+				Name:     configuration.Name,
+				Data:     configuration.Data,
+				External: configuration.External,
+				Restart:  configuration.Restart,
+			}, c.Settings.Namespace)
+			return err
+		},
+	}
+}
+
+// configurationUpdate returns the change converging the existing configuration to the
+// declared one, if any. The values are compared, but not shown.
+func (c *EpinioClient) configurationUpdate(configuration models.ConfigurationManifest) (*StackChange, error) {
+	response, err := c.API.ConfigurationShow(c.Settings.Namespace, configuration.Name)
+	if err != nil {
+		return nil, err
+	}
+	current := response.Configuration
+
+	if !sameMap(current.External, configuration.External) {
+		return nil, fmt.Errorf("changing the external references of configuration %s is not supported, delete it first",
+			configuration.Name)
+	}
+
+	request := models.ConfigurationUpdateRequest{Set: map[string]string{}}
+	details := []string{}
+
+	for _, key := range keysOf(configuration.Data) {
+		value, ok := current.Details[key]
+		if ok && value == configuration.Data[key] {
+			continue
+		}
+		request.Set[key] = configuration.Data[key]
+		if ok {
+			details = append(details, "~"+key)
+		} else {
+			details = append(details, "+"+key)
+		}
+	}
+	for _, key := range keysOf(current.Details) {
+		if _, ok := current.External[key]; ok {
+			continue
+		}
+		if _, ok := configuration.Data[key]; !ok {
+			request.Remove = append(request.Remove, key)
+			details = append(details, "-"+key)
+		}
+	}
+
+	if restartOrDefault(configuration.Restart) != restartOrDefault(current.Restart) {
+		request.Restart = restartOrDefault(configuration.Restart)
+		details = append(details, "restart: "+request.Restart.String())
+	}
+
+	if len(details) == 0 {
+		return nil, nil
+	}
+
+	return &StackChange{
+		Kind:    models.StackKindConfiguration,
+		Name:    configuration.Name,
+		Action:  "update",
+		Details: strings.Join(details, ", "),
+		apply: func() error {
+			_, err := c.API.ConfigurationUpdate(request, c.Settings.Namespace, configuration.Name)
+			return err
+		},
+	}, nil
+}
+
+// serviceCreate returns the change creating the declared service, waiting for it to be
+// provisioned, for the applications using it.
+func (c *EpinioClient) serviceCreate(service models.ServiceManifest) StackChange {
+	return StackChange{
+		Kind:    models.StackKindService,
+		Name:    service.Name,
+		Action:  "create",
+		Details: "catalog: " + service.CatalogService,
+		apply: func() error {
+			err := c.API.ServiceCreate(&models.ServiceCreateRequest{
+				CatalogService: service.CatalogService,
+				Name:           service.Name,
+				Settings:       service.Settings,
+			}, c.Settings.Namespace)
+			if err != nil {
+				return err
+			}
+			return c.API.ServiceReady(c.Settings.Namespace, service.Name)
+		},
+	}
+}
+
+// serviceUpdate returns the change converging the settings of the existing service to
+// the declared ones, if any. Settings not declared are left as they are.
+func (c *EpinioClient) serviceUpdate(service models.ServiceManifest, current models.Service) (*StackChange, error) {
+	if service.CatalogService != current.CatalogService {
+		return nil, fmt.Errorf("changing the catalog service of service %s is not supported, delete it first",
+			service.Name)
+	}
+
+	if len(service.Settings) == 0 {
+		return nil, nil
+	}
+
+	// The list does not carry the settings of the services.
+	shown, err := c.API.ServiceShow(&models.ServiceShowRequest{Name: service.Name}, c.Settings.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := models.AppSettings{}
+	details := []string{}
+	for _, key := range keysOf(service.Settings) {
+		if value, ok := shown.Settings[key]; ok && value == service.Settings[key] {
+			continue
+		}
+		settings[key] = service.Settings[key]
+		details = append(details, fmt.Sprintf("%s: %s", key, service.Settings[key]))
+	}
+
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	return &StackChange{
+		Kind:    models.StackKindService,
+		Name:    service.Name,
+		Action:  "update",
+		Details: strings.Join(details, ", "),
+		apply: func() error {
+			_, err := c.API.ServiceUpdate(models.ServiceUpdateRequest{Settings: settings},
+				c.Settings.Namespace, service.Name)
+			return err
+		},
+	}, nil
+}
+
+// serviceBindings returns the changes binding the declared applications to the service,
+// and unbinding the others.
+func (c *EpinioClient) serviceBindings(service models.ServiceManifest, boundApps []string) []StackChange {
+	declared := map[string]struct{}{}
+	for _, appName := range service.BoundApps {
+		declared[appName] = struct{}{}
+	}
+	current := map[string]struct{}{}
+	for _, appName := range boundApps {
+		current[appName] = struct{}{}
+	}
+
+	changes := []StackChange{}
+	for _, appName := range undeclared(declared, current) {
+		appName := appName
+		changes = append(changes, StackChange{
+			Kind:    "binding",
+			Name:    service.Name,
+			Action:  "bind",
+			Details: "app: " + appName,
+			apply: func() error {
+				return c.API.ServiceBind(&models.ServiceBindRequest{AppName: appName},
+					c.Settings.Namespace, service.Name)
+			},
+		})
+	}
+	for _, appName := range undeclared(current, declared) {
+		appName := appName
+		changes = append(changes, StackChange{
+			Kind:    "binding",
+			Name:    service.Name,
+			Action:  "unbind",
+			Details: "app: " + appName,
+			apply: func() error {
+				return c.API.ServiceUnbind(&models.ServiceUnbindRequest{AppName: appName},
+					c.Settings.Namespace, service.Name)
+			},
+		})
+	}
+
+	return changes
+}
+
+// appCreate returns the change creating the declared application. Applications with an
+// origin are pushed, the others are created without workload.
+func (c *EpinioClient) appCreate(ctx context.Context, app models.ApplicationManifest) StackChange {
+	change := StackChange{
+		Kind:   models.StackKindApp,
+		Name:   app.Name,
+		Action: "create",
+	}
+
+	if app.Origin.Kind == models.OriginNone {
+		change.apply = func() error {
+			_, err := c.API.AppCreate(app.ApplicationCreateRequest, c.Settings.Namespace)
+			return err
+		}
+		return change
+	}
+
+	change.Details = "origin: " + app.Origin.String()
+	change.apply = func() error {
+		return c.Push(ctx, PushParams{ApplicationManifest: app})
+	}
+	return change
+}
+
+// appUpdate returns the change converging the existing application to the declared one,
+// if any. A changed origin redeploys the application. The bindings of the configurations
+// created by services are kept, they follow the service bindings. As for appDiff, omitted
+// configurations and environment are not managed by the manifest and left as they are,
+// while declaring them empty removes them.
+func (c *EpinioClient) appUpdate(ctx context.Context, app models.ApplicationManifest, current models.App,
+	serviceConfigurations map[string]struct{}) *StackChange {
+
+	declared := app.Configuration
+	request := models.ApplicationUpdateRequest{}
+	details := []string{}
+	unset := []string{}
+
+	if declared.Instances != nil &&
+		(current.Configuration.Instances == nil || *current.Configuration.Instances != *declared.Instances) {
+		request.Instances = declared.Instances
+		details = append(details, fmt.Sprintf("instances: %d", *declared.Instances))
+	}
+
+	var desired []string
+	if declared.Configurations != nil {
+		desired = append([]string{}, declared.Configurations...)
+		for _, name := range current.Configuration.Configurations {
+			if _, ok := serviceConfigurations[name]; ok && !contains(desired, name) {
+				desired = append(desired, name)
+			}
+		}
+		if !sameSet(desired, current.Configuration.Configurations) {
+			request.Configurations = desired
+			details = append(details, "configurations: "+strings.Join(declared.Configurations, ", "))
+		}
+	}
+
+	if declared.Environment != nil && !sameMap(declared.Environment, current.Configuration.Environment) {
+		if len(declared.Environment) > 0 {
+			request.Environment = declared.Environment
+		} else {
+			unset = keysOf(current.Configuration.Environment)
+		}
+		details = append(details, "environment")
+	}
+
+	if declared.Routes != nil && !sameSet(declared.Routes, current.Configuration.Routes) {
+		request.Routes = declared.Routes
+		details = append(details, "routes: "+strings.Join(declared.Routes, ", "))
+	}
+
+	if declared.AppChart != "" && declared.AppChart != current.Configuration.AppChart {
+		request.AppChart = declared.AppChart
+		details = append(details, "appchart: "+declared.AppChart)
+	}
+
+	for _, key := range keysOf(declared.Settings) {
+		if value, ok := current.Configuration.Settings[key]; ok && value == declared.Settings[key] {
+			continue
+		}
+		request.Settings = declared.Settings
+		details = append(details, "settings")
+		break
+	}
+
+	change := &StackChange{
+		Kind: models.StackKindApp,
+		Name: app.Name,
+	}
+
+	if app.Origin.Kind != models.OriginNone && !sameOrigin(app.Origin, current.Origin) {
+		// Push updates the application before deploying it.
+		if desired != nil {
+			app.Configuration.Configurations = desired
+		}
+
+		change.Action = "deploy"
+		change.Details = strings.Join(append(details, "origin: "+app.Origin.String()), ", ")
+		change.apply = func() error {
+			if err := c.Push(ctx, PushParams{ApplicationManifest: app}); err != nil {
+				return err
+			}
+			return c.envUnset(app.Name, unset)
+		}
+		return change
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	change.Action = "update"
+	change.Details = strings.Join(details, ", ")
+	change.apply = func() error {
+		if _, err := c.API.AppUpdate(request, c.Settings.Namespace, app.Name); err != nil {
+			return err
+		}
+		return c.envUnset(app.Name, unset)
+	}
+	return change
+}
+
+// envUnset removes the named environment variables from the application.
+func (c *EpinioClient) envUnset(appName string, names []string) error {
+	for _, name := range names {
+		if _, err := c.API.EnvUnset(c.Settings.Namespace, appName, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameOrigin returns true if the origins refer to the same sources. The digest the
+// image of a container origin resolved to is ignored.
+func sameOrigin(a, b models.ApplicationOrigin) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+
+	switch a.Kind {
+	case models.OriginPath:
+		return a.Path == b.Path
+	case models.OriginContainer:
+		return a.Container == b.Container
+	case models.OriginGit:
+		return a.Git != nil && b.Git != nil &&
+			a.Git.URL == b.Git.URL && a.Git.Revision == b.Git.Revision
+	}
+	return true
+}
+
+// restartOrDefault returns the restart policy, with the default for an unset policy.
+func restartOrDefault(restart models.ConfigurationRestartPolicy) models.ConfigurationRestartPolicy {
+	if restart == "" {
+		return models.ConfigurationRestartAlways
+	}
+	return restart
+}
+
+// passError is the error function of deletions made by apply, which handles the errors
+// of the server as they are.
+func passError(_ *http.Response, _ []byte, err error) error {
+	return err
+}
+
+// undeclared returns the sorted names of the current map which are not declared.
+func undeclared[T any](current map[string]T, declared map[string]struct{}) []string {
+	names := []string{}
+	for name := range current {
+		if _, ok := declared[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keysOf returns the sorted keys of the map.
+func keysOf[T ~map[string]string](m T) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sameMap returns true if the maps have the same content. Nil and empty maps are the same.
+func sameMap[T ~map[string]string](a, b T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// sameSet returns true if the slices hold the same strings, regardless of order.
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// contains returns true if the slice holds the string.
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package usercmd_test
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client Apply unit tests", func() {
+	var fake *usercmdfakes.FakeAPIClient
+	var epinioClient *usercmd.EpinioClient
+	var stack models.StackManifest
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+
+		var err error
+		epinioClient, err = usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		fake.AppsReturns(models.AppList{
+			{
+				Meta: models.AppRef{Meta: models.Meta{Name: "web"}},
+				Configuration: models.ApplicationUpdateRequest{
+					Configurations: []string{"db", "pg-creds"},
+					Environment:    models.EnvVariableMap{"MODE": "dev"},
+				},
+			},
+			{Meta: models.AppRef{Meta: models.Meta{Name: "old"}}},
+		}, nil)
+		fake.ConfigurationsReturns(models.ConfigurationResponseList{
+			{Meta: models.ConfigurationRef{Meta: models.Meta{Name: "db"}}},
+			{
+				Meta:          models.ConfigurationRef{Meta: models.Meta{Name: "pg-creds"}},
+				Configuration: models.ConfigurationShowResponse{Origin: "pg"},
+			},
+		}, nil)
+		fake.ConfigurationShowReturns(models.ConfigurationResponse{
+			Configuration: models.ConfigurationShowResponse{
+				Details: map[string]string{"user": "admin", "password": "old"},
+			},
+		}, nil)
+		fake.ServiceListReturns(models.ServiceList{
+			{
+				Meta:           models.Meta{Name: "pg"},
+				CatalogService: "postgresql-dev",
+				BoundApps:      []string{"old"},
+			},
+		}, nil)
+		fake.ServiceShowReturns(&models.Service{
+			Meta:           models.Meta{Name: "pg"},
+			CatalogService: "postgresql-dev",
+			Settings:       models.AppSettings{"size": "small", "replicas": "1"},
+			BoundApps:      []string{"old"},
+		}, nil)
+
+		stack = models.StackManifest{
+			Configurations: []models.ConfigurationManifest{
+				{Name: "db", Data: map[string]string{"user": "admin", "password": "new"}},
+				{Name: "cache", Data: map[string]string{"url": "redis://cache"}},
+			},
+			Services: []models.ServiceManifest{
+				{Name: "pg", CatalogService: "postgresql-dev", BoundApps: []string{"web"}},
+			},
+			Apps: []models.ApplicationManifest{{
+				ApplicationCreateRequest: models.ApplicationCreateRequest{
+					Name: "web",
+					Configuration: models.ApplicationUpdateRequest{
+						Configurations: []string{"db", "cache"},
+						Environment:    models.EnvVariableMap{"MODE": "prod"},
+					},
+				},
+			}},
+		}
+	})
+
+	It("changes nothing in a dry run", func() {
+		Expect(epinioClient.Apply(context.Background(), stack, true, true)).To(Succeed())
+
+		Expect(fake.ConfigurationCreateCallCount()).To(Equal(0))
+		Expect(fake.ConfigurationUpdateCallCount()).To(Equal(0))
+		Expect(fake.AppUpdateCallCount()).To(Equal(0))
+		Expect(fake.ServiceBindCallCount()).To(Equal(0))
+		Expect(fake.AppDeleteCallCount()).To(Equal(0))
+	})
+
+	It("converges the namespace to the stack", func() {
+		Expect(epinioClient.Apply(context.Background(), stack, false, false)).To(Succeed())
+
+		Expect(fake.ConfigurationUpdateCallCount()).To(Equal(1))
+		update, namespace, name := fake.ConfigurationUpdateArgsForCall(0)
+		Expect(namespace).To(Equal("workspace"))
+		Expect(name).To(Equal("db"))
+		Expect(update.Set).To(Equal(map[string]string{"password": "new"}))
+		Expect(update.Remove).To(BeEmpty())
+
+		Expect(fake.ConfigurationCreateCallCount()).To(Equal(1))
+		create, _ := fake.ConfigurationCreateArgsForCall(0)
+		Expect(create.Name).To(Equal("cache"))
+
+		// The binding of the service configuration is kept
+		Expect(fake.AppUpdateCallCount()).To(Equal(1))
+		appUpdate, _, appName := fake.AppUpdateArgsForCall(0)
+		Expect(appName).To(Equal("web"))
+		Expect(appUpdate.Configurations).To(Equal([]string{"db", "cache", "pg-creds"}))
+		Expect(appUpdate.Environment).To(Equal(models.EnvVariableMap{"MODE": "prod"}))
+
+		Expect(fake.ServiceBindCallCount()).To(Equal(1))
+		bind, _, serviceName := fake.ServiceBindArgsForCall(0)
+		Expect(serviceName).To(Equal("pg"))
+		Expect(bind.AppName).To(Equal("web"))
+
+		Expect(fake.ServiceUnbindCallCount()).To(Equal(1))
+		unbind, _, _ := fake.ServiceUnbindArgsForCall(0)
+		Expect(unbind.AppName).To(Equal("old"))
+
+		// Without prune nothing is deleted
+		Expect(fake.AppDeleteCallCount()).To(Equal(0))
+	})
+
+	It("leaves omitted configurations and environment alone", func() {
+		stack.Apps[0].Configuration = models.ApplicationUpdateRequest{}
+
+		Expect(epinioClient.Apply(context.Background(), stack, false, false)).To(Succeed())
+
+		Expect(fake.AppUpdateCallCount()).To(Equal(0))
+		Expect(fake.EnvUnsetCallCount()).To(Equal(0))
+	})
+
+	It("clears configurations and environment declared empty", func() {
+		stack.Apps[0].Configuration = models.ApplicationUpdateRequest{
+			Configurations: []string{},
+			Environment:    models.EnvVariableMap{},
+		}
+
+		Expect(epinioClient.Apply(context.Background(), stack, false, false)).To(Succeed())
+
+		// The binding of the service configuration is kept
+		Expect(fake.AppUpdateCallCount()).To(Equal(1))
+		appUpdate, _, _ := fake.AppUpdateArgsForCall(0)
+		Expect(appUpdate.Configurations).To(Equal([]string{"pg-creds"}))
+
+		Expect(fake.EnvUnsetCallCount()).To(Equal(1))
+		_, appName, name := fake.EnvUnsetArgsForCall(0)
+		Expect(appName).To(Equal("web"))
+		Expect(name).To(Equal("MODE"))
+	})
+
+	It("deletes what the stack does not declare when pruning", func() {
+		Expect(epinioClient.Apply(context.Background(), stack, true, false)).To(Succeed())
+
+		Expect(fake.AppDeleteCallCount()).To(Equal(1))
+		_, names := fake.AppDeleteArgsForCall(0)
+		Expect(names).To(Equal([]string{"old"}))
+		Expect(fake.ServiceDeleteCallCount()).To(Equal(0))
+		Expect(fake.ConfigurationDeleteCallCount()).To(Equal(0))
+	})
+
+	It("rejects bindings of unknown apps before changing anything", func() {
+		stack.Services[0].BoundApps = []string{"missing"}
+
+		err := epinioClient.Apply(context.Background(), stack, false, false)
+		Expect(err).To(MatchError("service pg is bound to the unknown app missing"))
+		Expect(fake.ConfigurationCreateCallCount()).To(Equal(0))
+	})
+
+	It("updates only the service settings which differ from the shown ones", func() {
+		stack.Services[0].Settings = models.AppSettings{"size": "small"}
+
+		Expect(epinioClient.Apply(context.Background(), stack, false, false)).To(Succeed())
+		Expect(fake.ServiceUpdateCallCount()).To(Equal(0))
+
+		stack.Services[0].Settings = models.AppSettings{"size": "large", "replicas": "1"}
+
+		Expect(epinioClient.Apply(context.Background(), stack, false, false)).To(Succeed())
+		Expect(fake.ServiceUpdateCallCount()).To(Equal(1))
+		update, namespace, name := fake.ServiceUpdateArgsForCall(0)
+		Expect(namespace).To(Equal("workspace"))
+		Expect(name).To(Equal("pg"))
+		Expect(update.Settings).To(Equal(models.AppSettings{"size": "large"}))
+	})
+})
@@ -46,11 +46,14 @@ type APIClient interface {
 	StagingComplete(namespace string, id string) (models.Response, error)
 	AppRunning(app models.AppRef) (models.Response, error)
 	AppExec(namespace string, appName, instance string, tty kubectlterm.TTY) error
+	AppExecCommand(namespace string, appName, instance string, opts epinioapi.ExecOpts) error
 	AppPortForward(namespace string, appName, instance string, opts *epinioapi.PortForwardOpts) error
+	AppDebug(namespace string, appName, instance, image string, tty kubectlterm.TTY) error
 	AppRestart(namespace string, appName string) error
 	AppGetPart(namespace, appName, part, destinationPath string) error
 	AppMatch(namespace, prefix string) (models.AppMatchResponse, error)
 	AppValidateCV(namespace string, name string) (models.Response, error)
+	AppWebhook(namespace string, name string) (models.AppWebhookResponse, error)
 
 	// env
 	EnvList(namespace string, appName string) (models.EnvVariableMap, error)
@@ -58,6 +61,9 @@ type APIClient interface {
 	EnvShow(namespace string, appName string, envName string) (models.EnvVariable, error)
 	EnvUnset(namespace string, appName string, envName string) (models.Response, error)
 	EnvMatch(namespace string, appName string, prefix string) (models.EnvMatchResponse, error)
+	BuildEnvList(namespace string, appName string) (models.EnvVariableMap, error)
+	BuildEnvSet(req models.EnvVariableMap, namespace string, appName string) (models.Response, error)
+	BuildEnvUnset(namespace string, appName string, envName string) (models.Response, error)
 
 	// info
 	Info() (models.InfoResponse, error)
@@ -66,8 +72,24 @@ type APIClient interface {
 	NamespaceCreate(req models.NamespaceCreateRequest) (models.Response, error)
 	NamespaceDelete(namespace string) (models.Response, error)
 	NamespaceShow(namespace string) (models.Namespace, error)
+	NamespaceQuotaUpdate(quota models.NamespaceQuota, namespace string) (models.Response, error)
+	NamespaceMemberAdd(req models.NamespaceMemberRequest, namespace string) (models.Response, error)
+	NamespaceMemberRemove(namespace, username string) (models.Response, error)
 	NamespacesMatch(prefix string) (models.NamespacesMatchResponse, error)
 	Namespaces() (models.NamespaceList, error)
+	NamespaceEvents(namespace string, callback func(models.Event)) error
+
+	// maintenance
+	ImageGC(req models.ImageGCRequest) (models.ImageGCResponse, error)
+	BlobPrune(req models.BlobPruneRequest) (models.BlobPruneResponse, error)
+	CatalogServiceAdd(req models.CatalogServiceRequest) error
+	CatalogServiceUpdate(req models.CatalogServiceRequest, name string) error
+	CatalogServiceDelete(name string) error
+
+	// registries
+	RegistryLogins(namespace string) (models.RegistryLoginList, error)
+	RegistryLogin(namespace string, req models.RegistryLoginRequest) (models.Response, error)
+	RegistryLogout(namespace, registryURL string) (models.Response, error)
 
 	// configurations
 	Configurations(namespace string) (models.ConfigurationResponseList, error)
@@ -76,7 +98,7 @@ type APIClient interface {
 	ConfigurationBindingDelete(namespace string, appName string, configurationName string) (models.Response, error)
 	ConfigurationDelete(req models.ConfigurationDeleteRequest, namespace string, names []string, f epinioapi.ErrorFunc) (models.ConfigurationDeleteResponse, error)
 	ConfigurationCreate(req models.ConfigurationCreateRequest, namespace string) (models.Response, error)
-	ConfigurationUpdate(req models.ConfigurationUpdateRequest, namespace, name string) (models.Response, error)
+	ConfigurationUpdate(req models.ConfigurationUpdateRequest, namespace, name string) (models.ConfigurationUpdateResponse, error)
 	ConfigurationShow(namespace string, name string) (models.ConfigurationResponse, error)
 	ConfigurationApps(namespace string) (models.ConfigurationAppsResponse, error)
 	ConfigurationMatch(namespace, prefix string) (models.ConfigurationMatchResponse, error)
@@ -89,11 +111,21 @@ type APIClient interface {
 	AllServices() (models.ServiceList, error)
 	ServiceShow(req *models.ServiceShowRequest, namespace string) (*models.Service, error)
 	ServiceCreate(req *models.ServiceCreateRequest, namespace string) error
+	ServiceReady(namespace, name string) error
+	ServiceRotateCredentials(namespace, name string) (models.ServiceRotateCredentialsResponse, error)
+	ServiceShare(req models.ServiceShareRequest, namespace, name string) (models.ServiceShareResponse, error)
+	ServiceUnshare(namespace, name, target string) (models.ServiceUnshareResponse, error)
+	ServiceUpdate(req models.ServiceUpdateRequest, namespace, name string) (models.ServiceUpdateResponse, error)
+	ServiceBackup(namespace, name string) (models.ServiceBackup, error)
+	ServiceBackups(namespace, name string) (models.ServiceBackupList, error)
+	ServiceRestore(namespace, name, backupID string) error
+	ServiceBackupSchedule(req models.ServiceBackupScheduleRequest, namespace, name string) error
 	ServiceBind(req *models.ServiceBindRequest, namespace, name string) error
 	ServiceUnbind(req *models.ServiceUnbindRequest, namespace, name string) error
 	ServiceDelete(req models.ServiceDeleteRequest, namespace string, names []string, f epinioapi.ErrorFunc) (models.ServiceDeleteResponse, error)
 	ServiceList(namespace string) (models.ServiceList, error)
 	ServiceMatch(namespace, prefix string) (models.ServiceMatchResponse, error)
+	ServicePortForward(namespace, name string, opts *epinioapi.PortForwardOpts) error
 
 	// application charts
 	ChartList() ([]models.AppChart, error)
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
 
+	"github.com/pkg/errors"
+	"github.com/subosito/gotenv"
+
 	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
@@ -69,7 +73,7 @@ func (c *EpinioClient) Configurations(all bool) error {
 				configuration.Meta.Name,
 				configuration.Meta.CreatedAt.String(),
 				configuration.Configuration.Type,
-				configuration.Configuration.Origin,
+				configurationOrigin(configuration.Configuration),
 				apps)
 		}
 	} else {
@@ -87,7 +91,7 @@ func (c *EpinioClient) Configurations(all bool) error {
 				configuration.Meta.Name,
 				configuration.Meta.CreatedAt.String(),
 				configuration.Configuration.Type,
-				configuration.Configuration.Origin,
+				configurationOrigin(configuration.Configuration),
 				apps)
 		}
 	}
@@ -120,25 +124,35 @@ func (c *EpinioClient) ConfigurationMatching(ctx context.Context, prefix string)
 }
 
 // BindConfiguration attaches a configuration specified by name to the named application,
-// both in the targeted namespace.
-func (c *EpinioClient) BindConfiguration(configurationName, appName string) error {
+// both in the targeted namespace. A non-empty restart policy overrides the policy of the
+// configuration for the binding. A non-nil mapping exposes the keys of the configuration as
+// environment variables of the application.
+func (c *EpinioClient) BindConfiguration(configurationName, appName string, restart models.ConfigurationRestartPolicy, env *models.ConfigurationEnvMapping) error {
 	log := c.Log.WithName("Bind Configuration To Application").
 		WithValues("Name", configurationName, "Application", appName, "Namespace", c.Settings.Namespace)
 	log.Info("start")
 	defer log.Info("return")
 
-	c.ui.Note().
+	msg := c.ui.Note().
 		WithStringValue("Configuration", configurationName).
 		WithStringValue("Application", appName).
-		WithStringValue("Namespace", c.Settings.Namespace).
-		Msg("Bind Configuration")
+		WithStringValue("Namespace", c.Settings.Namespace)
+	if restart != "" {
+		msg = msg.WithStringValue("Restart", restart.String())
+	}
+	if env != nil {
+		msg = msg.WithStringValue("Environment", envVariables(*env))
+	}
+	msg.Msg("Bind Configuration")
 
 	if err := c.TargetOk(); err != nil {
 		return err
 	}
 
 	request := models.BindRequest{
-		Names: []string{configurationName},
+		Names:   []string{configurationName},
+		Restart: restart,
+		Env:     env,
 	}
 
 	br, err := c.API.ConfigurationBindingCreate(request, c.Settings.Namespace, appName)
@@ -275,7 +289,7 @@ func (c *EpinioClient) DeleteConfiguration(names []string, unbind bool) error {
 
 // UpdateConfiguration updates a configuration specified by name and information about removed keys and changed assignments.
 // TODO: Allow underscores in configuration names (right now they fail because of kubernetes naming rules for secrets)
-func (c *EpinioClient) UpdateConfiguration(name string, removedKeys []string, assignments map[string]string) error {
+func (c *EpinioClient) UpdateConfiguration(name string, removedKeys []string, assignments map[string]string, restart models.ConfigurationRestartPolicy) error {
 	log := c.Log.WithName("Update Configuration").
 		WithValues("Name", name, "Namespace", c.Settings.Namespace)
 	log.Info("start")
@@ -299,6 +313,9 @@ func (c *EpinioClient) UpdateConfiguration(name string, removedKeys []string, as
 	for _, key := range changed {
 		msg = msg.WithTableRow(key, "add/change", assignments[key])
 	}
+	if restart != "" {
+		msg = msg.WithStringValue("Restart", restart.String())
+	}
 	msg.Msg("Update Configuration")
 
 	if err := c.TargetOk(); err != nil {
@@ -306,42 +323,113 @@ func (c *EpinioClient) UpdateConfiguration(name string, removedKeys []string, as
 	}
 
 	request := models.ConfigurationUpdateRequest{
-		Remove: removedKeys,
-		Set:    assignments,
+		Remove:  removedKeys,
+		Set:     assignments,
+		Restart: restart,
 	}
 
-	_, err := c.API.ConfigurationUpdate(request, c.Settings.Namespace, name)
+	resp, err := c.API.ConfigurationUpdate(request, c.Settings.Namespace, name)
 	if err != nil {
 		return err
 	}
 
-	c.ui.Success().
+	success := c.ui.Success().
 		WithStringValue("Name", name).
-		WithStringValue("Namespace", c.Settings.Namespace).
-		Msg("Configuration Changes Saved.")
+		WithStringValue("Namespace", c.Settings.Namespace)
+	if len(resp.RestartedApps) > 0 {
+		success = success.WithStringValue("Restarted Applications", strings.Join(resp.RestartedApps, ", "))
+	}
+	success.Msg("Configuration Changes Saved.")
 
 	return nil
 }
 
-// CreateConfiguration creates a configuration specified by name and key/value dictionary
+// ConfigurationSources are the sources of the values of a configuration, besides the
+// key/value dictionary of the command line. They keep secrets out of the shell history.
+type ConfigurationSources struct {
+	Files    []string // KEY=PATH, the value is the content of the local file
+	EnvFiles []string // Local files of KEY=VALUE lines
+	External []string // KEY=REFERENCE, resolved by the server from an external secret store
+}
+
+// CreateConfiguration creates a configuration specified by name and key/value dictionary,
+// and the additional sources of values. A non-empty restart policy is recorded with the
+// configuration.
 // TODO: Allow underscores in configuration names (right now they fail because of kubernetes naming rules for secrets)
-func (c *EpinioClient) CreateConfiguration(name string, dict []string) error {
+func (c *EpinioClient) CreateConfiguration(name string, dict []string, sources ConfigurationSources, restart models.ConfigurationRestartPolicy) error {
 	log := c.Log.WithName("Create Configuration").
 		WithValues("Name", name, "Namespace", c.Settings.Namespace)
 	log.Info("start")
 	defer log.Info("return")
 
 	data := make(map[string]string)
+	external := make(map[string]string)
 	msg := c.ui.Note().
 		WithStringValue("Name", name).
 		WithStringValue("Namespace", c.Settings.Namespace).
 		WithTable("Parameter", "Value", "Access Path")
-	for i := 0; i < len(dict); i += 2 {
-		key := dict[i]
-		value := dict[i+1]
+
+	// addKey checks that the key is new, and shows it. The values from files are not shown.
+	keys := map[string]struct{}{}
+	addKey := func(key, shown string) error {
+		if _, ok := keys[key]; ok {
+			return fmt.Errorf("the key %s is given more than once", key)
+		}
+		keys[key] = struct{}{}
 		path := fmt.Sprintf("/configurations/%s/%s", name, key)
-		msg = msg.WithTableRow(key, value, path)
-		data[key] = value
+		msg = msg.WithTableRow(key, shown, path)
+		return nil
+	}
+
+	for i := 0; i < len(dict); i += 2 {
+		if err := addKey(dict[i], dict[i+1]); err != nil {
+			return err
+		}
+		data[dict[i]] = dict[i+1]
+	}
+	for _, file := range sources.Files {
+		key, path, found := strings.Cut(file, "=")
+		if !found || key == "" {
+			return fmt.Errorf("bad file %s, expected KEY=PATH", file)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading the value of %s", key)
+		}
+		if err := addKey(key, fmt.Sprintf("(file %s)", path)); err != nil {
+			return err
+		}
+		data[key] = string(content)
+	}
+	for _, envFile := range sources.EnvFiles {
+		env, err := gotenv.Read(envFile)
+		if err != nil {
+			return errors.Wrapf(err, "reading the env file %s", envFile)
+		}
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := addKey(key, fmt.Sprintf("(env file %s)", envFile)); err != nil {
+				return err
+			}
+			data[key] = env[key]
+		}
+	}
+	for _, reference := range sources.External {
+		key, ref, found := strings.Cut(reference, "=")
+		if !found || key == "" {
+			return fmt.Errorf("bad external reference %s, expected KEY=REFERENCE", reference)
+		}
+		if err := addKey(key, fmt.Sprintf("(external %s)", ref)); err != nil {
+			return err
+		}
+		external[key] = ref
+	}
+	if restart != "" {
+		msg = msg.WithStringValue("Restart", restart.String())
 	}
 	msg.Msg("Create Configuration")
 
@@ -350,8 +438,10 @@ func (c *EpinioClient) CreateConfiguration(name string, dict []string) error {
 	}
 
 	request := models.ConfigurationCreateRequest{
-		Name: name,
-		Data: data,
+		Name:     name,
+		Data:     data,
+		External: external,
+		Restart:  restart,
 	}
 
 	_, err := c.API.ConfigurationCreate(request, c.Settings.Namespace)
@@ -400,9 +490,11 @@ func (c *EpinioClient) ConfigurationDetails(name string) error {
 		WithStringValue("Created", resp.Meta.CreatedAt.String()).
 		WithStringValue("User", resp.Configuration.Username).
 		WithStringValue("Type", resp.Configuration.Type).
-		WithStringValue("Origin", resp.Configuration.Origin).
+		WithStringValue("Origin", configurationOrigin(resp.Configuration)).
 		WithStringValue("Used-By", strings.Join(boundApps, ", ")).
 		WithStringValue("Siblings", strings.Join(siblings, ", ")).
+		WithStringValue("External", externalReferences(resp.Configuration.External)).
+		WithStringValue("Restart", restartPolicy(resp.Configuration.Restart)).
 		Msg("")
 
 	if resp.Configuration.Origin != "" && len(boundApps) > 0 {
@@ -456,3 +548,48 @@ func (c *EpinioClient) ConfigurationDetails(name string) error {
 		Msg("Beware, the shown access paths are only available in the application's container")
 	return nil
 }
+
+// envVariables returns the environment variables of the mapping for display.
+func envVariables(env models.ConfigurationEnvMapping) string {
+	if len(env.Keys) == 0 {
+		if env.Prefix == "" {
+			return "all keys"
+		}
+		return fmt.Sprintf("all keys, prefixed with %s", env.Prefix)
+	}
+
+	variables := []string{}
+	for key := range env.Keys {
+		variables = append(variables, fmt.Sprintf("%s=%s", env.EnvName(key), key))
+	}
+	sort.Strings(variables)
+	return strings.Join(variables, ", ")
+}
+
+// restartPolicy returns the restart policy for display, with the default made explicit.
+func restartPolicy(policy models.ConfigurationRestartPolicy) string {
+	if policy == "" {
+		return models.ConfigurationRestartAlways.String()
+	}
+	return policy.String()
+}
+
+// configurationOrigin returns the service the configuration came from, if any. The
+// configurations of a shared service name the namespace of the service as well.
+func configurationOrigin(configuration models.ConfigurationShowResponse) string {
+	if configuration.OriginNamespace != "" {
+		return configuration.OriginNamespace + "/" + configuration.Origin
+	}
+	return configuration.Origin
+}
+
+// externalReferences returns the sorted references of the keys taken from external
+// secret stores, as `KEY=REFERENCE` list.
+func externalReferences(external map[string]string) string {
+	references := make([]string, 0, len(external))
+	for key, reference := range external {
+		references = append(references, key+"="+reference)
+	}
+	sort.Strings(references)
+	return strings.Join(references, ", ")
+}
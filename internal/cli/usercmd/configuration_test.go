@@ -0,0 +1,119 @@
+package usercmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client Configurations unit tests", func() {
+	var fake *usercmdfakes.FakeAPIClient
+	var dir string
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+		dir = GinkgoT().TempDir()
+
+		err := os.WriteFile(filepath.Join(dir, "password"), []byte("s3cret"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		err = os.WriteFile(filepath.Join(dir, ".env"), []byte("# database\nHOST=db\nexport PORT=5432\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("CreateConfiguration", func() {
+		It("takes the values from the command line, files, env files and external references", func() {
+			epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = epinioClient.CreateConfiguration("db", []string{"user", "admin"}, usercmd.ConfigurationSources{
+				Files:    []string{"password=" + filepath.Join(dir, "password")},
+				EnvFiles: []string{filepath.Join(dir, ".env")},
+				External: []string{"token=vault:secret/data/db#token"},
+			}, models.ConfigurationRestartRolling)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fake.ConfigurationCreateCallCount()).To(Equal(1))
+			request, namespace := fake.ConfigurationCreateArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+			Expect(request).To(Equal(models.ConfigurationCreateRequest{
+				Name: "db",
+				Data: map[string]string{
+					"user":     "admin",
+					"password": "s3cret",
+					"HOST":     "db",
+					"PORT":     "5432",
+				},
+				External: map[string]string{
+					"token": "vault:secret/data/db#token",
+				},
+				Restart: models.ConfigurationRestartRolling,
+			}))
+		})
+
+		It("rejects keys given more than once", func() {
+			epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = epinioClient.CreateConfiguration("db", []string{"password", "admin"}, usercmd.ConfigurationSources{
+				Files: []string{"password=" + filepath.Join(dir, "password")},
+			}, "")
+			Expect(err).To(MatchError(ContainSubstring("the key password is given more than once")))
+			Expect(fake.ConfigurationCreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("UpdateConfiguration", func() {
+		It("passes the restart policy along", func() {
+			fake.ConfigurationUpdateReturns(models.ConfigurationUpdateResponse{
+				RestartedApps: []string{"web"},
+			}, nil)
+
+			epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = epinioClient.UpdateConfiguration("db", []string{"user"}, map[string]string{"password": "s3cret"},
+				models.ConfigurationRestartNever)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fake.ConfigurationUpdateCallCount()).To(Equal(1))
+			request, namespace, name := fake.ConfigurationUpdateArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+			Expect(name).To(Equal("db"))
+			Expect(request).To(Equal(models.ConfigurationUpdateRequest{
+				Remove:  []string{"user"},
+				Set:     map[string]string{"password": "s3cret"},
+				Restart: models.ConfigurationRestartNever,
+			}))
+		})
+	})
+
+	Describe("BindConfiguration", func() {
+		It("passes the environment mapping along", func() {
+			fake.ConfigurationBindingCreateReturns(models.BindResponse{}, nil)
+
+			epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+			Expect(err).ToNot(HaveOccurred())
+
+			env := &models.ConfigurationEnvMapping{
+				Keys: map[string]string{"url": "DATABASE_URL"},
+			}
+			err = epinioClient.BindConfiguration("db", "web", "", env)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fake.ConfigurationBindingCreateCallCount()).To(Equal(1))
+			request, namespace, appName := fake.ConfigurationBindingCreateArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+			Expect(appName).To(Equal("web"))
+			Expect(request).To(Equal(models.BindRequest{
+				Names: []string{"db"},
+				Env:   env,
+			}))
+		})
+	})
+})
@@ -0,0 +1,246 @@
+package usercmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/epinio/epinio/internal/routes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// AppDiffEntry is a difference between an application manifest and the state of the
+// application.
+type AppDiffEntry struct {
+	Field    string // instances, environment, configurations, routes, appchart, settings, origin
+	Manifest string
+	Current  string
+}
+
+// deployedValues is the part of the helm values of a deployed application compared
+// against a manifest.
+type deployedValues struct {
+	Epinio struct {
+		Configurations []string             `yaml:"configurations"`
+		Env            []models.EnvVariable `yaml:"env"`
+		ReplicaCount   int32                `yaml:"replicaCount"`
+		Routes         []struct {
+			Domain string `yaml:"domain"`
+			Path   string `yaml:"path"`
+		} `yaml:"routes"`
+	} `yaml:"epinio"`
+	User map[string]interface{} `yaml:"userConfig"`
+}
+
+// AppDiff compares the manifest with the application of the same name in the targeted
+// namespace, and shows the differences. For a deployed application the state is taken
+// from the helm values of its workload, i.e. what actually runs, else from the stored
+// configuration. Fields the manifest leaves unspecified are not compared, including an
+// origin it does not specify.
+func (c *EpinioClient) AppDiff(m models.ApplicationManifest) ([]AppDiffEntry, error) {
+	log := c.Log.WithName("AppDiff").WithValues("Namespace", c.Settings.Namespace, "Application", m.Name)
+	log.Info("start")
+	defer log.Info("return")
+	details := log.V(1) // NOTE: Increment of level, not absolute.
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", m.Name).
+		WithStringValue("Manifest", m.Self).
+		Msg("Compare manifest with application")
+
+	if err := c.TargetOk(); err != nil {
+		return nil, err
+	}
+
+	details.Info("show application")
+
+	app, err := c.API.AppShow(c.Settings.Namespace, m.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	current := app.Configuration
+
+	if app.Workload != nil {
+		details.Info("fetch helm values")
+
+		values, err := c.appValues(m.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		instances := values.Epinio.ReplicaCount
+		current.Instances = &instances
+		current.Configurations = values.Epinio.Configurations
+		current.Environment = models.EnvVariableMap{}
+		for _, ev := range values.Epinio.Env {
+			current.Environment[ev.Name] = ev.Value
+		}
+		current.Routes = []string{}
+		for _, route := range values.Epinio.Routes {
+			current.Routes = append(current.Routes, routes.Route{Domain: route.Domain, Path: route.Path}.String())
+		}
+		current.Settings = models.AppSettings{}
+		for key, value := range values.User {
+			current.Settings[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	diff := appDiff(m, current, app.Origin)
+
+	if len(diff) == 0 {
+		c.ui.Success().Msg("No drift, the application matches the manifest.")
+		return diff, nil
+	}
+
+	msg := c.ui.Note().WithTable("Field", "Manifest", "Current")
+	for _, entry := range diff {
+		msg = msg.WithTableRow(entry.Field, entry.Manifest, entry.Current)
+	}
+	msg.Msg("Drift:")
+
+	return diff, nil
+}
+
+// appValues returns the helm values of the deployed application.
+func (c *EpinioClient) appValues(appName string) (deployedValues, error) {
+	values := deployedValues{}
+
+	dir, err := os.MkdirTemp("", "epinio-diff")
+	if err != nil {
+		return values, errors.Wrap(err, "filesystem error")
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "values.yaml")
+	if err := c.API.AppGetPart(c.Settings.Namespace, appName, "values", path); err != nil {
+		return values, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return values, errors.Wrap(err, "filesystem error")
+	}
+
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return values, errors.Wrap(err, "bad helm values")
+	}
+
+	return values, nil
+}
+
+// appDiff returns the differences between the manifest and the current configuration and
+// origin of the application, in a fixed order of the fields.
+func appDiff(m models.ApplicationManifest, current models.ApplicationUpdateRequest, origin models.ApplicationOrigin) []AppDiffEntry {
+	declared := m.Configuration
+	diff := []AppDiffEntry{}
+
+	if declared.Instances != nil &&
+		(current.Instances == nil || *current.Instances != *declared.Instances) {
+		currentInstances := ""
+		if current.Instances != nil {
+			currentInstances = strconv.Itoa(int(*current.Instances))
+		}
+		diff = append(diff, AppDiffEntry{
+			Field:    "instances",
+			Manifest: strconv.Itoa(int(*declared.Instances)),
+			Current:  currentInstances,
+		})
+	}
+
+	if declared.Environment != nil {
+		for _, name := range keysOf(declared.Environment) {
+			value, ok := current.Environment[name]
+			if ok && value == declared.Environment[name] {
+				continue
+			}
+			diff = append(diff, AppDiffEntry{
+				Field:    "environment " + name,
+				Manifest: declared.Environment[name],
+				Current:  value,
+			})
+		}
+		for _, name := range keysOf(current.Environment) {
+			if _, ok := declared.Environment[name]; !ok {
+				diff = append(diff, AppDiffEntry{
+					Field:   "environment " + name,
+					Current: current.Environment[name],
+				})
+			}
+		}
+	}
+
+	if declared.Configurations != nil && !sameSet(declared.Configurations, current.Configurations) {
+		diff = append(diff, AppDiffEntry{
+			Field:    "configurations",
+			Manifest: sortedList(declared.Configurations),
+			Current:  sortedList(current.Configurations),
+		})
+	}
+
+	if declared.Routes != nil && !sameSet(normalizeRoutes(declared.Routes), normalizeRoutes(current.Routes)) {
+		diff = append(diff, AppDiffEntry{
+			Field:    "routes",
+			Manifest: sortedList(normalizeRoutes(declared.Routes)),
+			Current:  sortedList(normalizeRoutes(current.Routes)),
+		})
+	}
+
+	if declared.AppChart != "" && declared.AppChart != current.AppChart {
+		diff = append(diff, AppDiffEntry{
+			Field:    "appchart",
+			Manifest: declared.AppChart,
+			Current:  current.AppChart,
+		})
+	}
+
+	for _, key := range keysOf(declared.Settings) {
+		value, ok := current.Settings[key]
+		if ok && value == declared.Settings[key] {
+			continue
+		}
+		diff = append(diff, AppDiffEntry{
+			Field:    "setting " + key,
+			Manifest: declared.Settings[key],
+			Current:  value,
+		})
+	}
+
+	if m.Origin.Kind != models.OriginNone && !sameOrigin(m.Origin, origin) {
+		currentOrigin := ""
+		if origin.Kind != models.OriginNone {
+			currentOrigin = origin.String()
+		}
+		diff = append(diff, AppDiffEntry{
+			Field:    "origin",
+			Manifest: m.Origin.String(),
+			Current:  currentOrigin,
+		})
+	}
+
+	return diff
+}
+
+// normalizeRoutes returns the routes in their canonical form, i.e. without trailing `/`.
+func normalizeRoutes(list []string) []string {
+	result := []string{}
+	for _, route := range list {
+		result = append(result, routes.FromString(route).String())
+	}
+	return result
+}
+
+// sortedList returns the strings sorted and comma-separated.
+func sortedList(list []string) string {
+	sorted := append([]string{}, list...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
@@ -0,0 +1,104 @@
+package usercmd_test
+
+import (
+	"os"
+
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client AppDiff unit tests", func() {
+	var fake *usercmdfakes.FakeAPIClient
+	var epinioClient *usercmd.EpinioClient
+	var m models.ApplicationManifest
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+
+		var err error
+		epinioClient, err = usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		instances := int32(2)
+		m = models.ApplicationManifest{
+			ApplicationCreateRequest: models.ApplicationCreateRequest{
+				Name: "web",
+				Configuration: models.ApplicationUpdateRequest{
+					Instances:      &instances,
+					Configurations: []string{"db"},
+					Environment:    models.EnvVariableMap{"MODE": "prod"},
+					Routes:         []string{"web.example.com/"},
+				},
+			},
+			Origin: models.ApplicationOrigin{Kind: models.OriginContainer, Container: "web:1.0"},
+		}
+	})
+
+	It("reports no drift for a matching application", func() {
+		fake.AppShowReturns(models.App{
+			Configuration: m.Configuration,
+			Origin:        models.ApplicationOrigin{Kind: models.OriginContainer, Container: "web:1.0", Digest: "sha256:abc"},
+		}, nil)
+
+		diff, err := epinioClient.AppDiff(m)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+		Expect(fake.AppGetPartCallCount()).To(Equal(0))
+	})
+
+	It("compares against the helm values of a deployed application", func() {
+		fake.AppShowReturns(models.App{
+			Configuration: m.Configuration,
+			Origin:        models.ApplicationOrigin{Kind: models.OriginContainer, Container: "web:0.9"},
+			Workload:      &models.AppDeployment{},
+		}, nil)
+		fake.AppGetPartStub = func(namespace, appName, part, destination string) error {
+			Expect(part).To(Equal("values"))
+			return os.WriteFile(destination, []byte(`epinio:
+  configurations: [db, cache]
+  env:
+  - name: MODE
+    value: dev
+  - name: DEBUG
+    value: "1"
+  replicaCount: 2
+  routes:
+  - domain: web.example.com
+    path: /
+`), 0600)
+		}
+
+		diff, err := epinioClient.AppDiff(m)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff).To(Equal([]usercmd.AppDiffEntry{
+			{Field: "environment MODE", Manifest: "prod", Current: "dev"},
+			{Field: "environment DEBUG", Current: "1"},
+			{Field: "configurations", Manifest: "db", Current: "cache, db"},
+			{Field: "origin", Manifest: "web:1.0", Current: "web:0.9"},
+		}))
+	})
+
+	It("does not compare the fields the manifest leaves unspecified", func() {
+		m.Configuration.Environment = nil
+		m.Configuration.Configurations = nil
+		m.Origin = models.ApplicationOrigin{}
+
+		fake.AppShowReturns(models.App{
+			Configuration: models.ApplicationUpdateRequest{
+				Instances:      m.Configuration.Instances,
+				Configurations: []string{"cache"},
+				Environment:    models.EnvVariableMap{"DEBUG": "1"},
+				Routes:         m.Configuration.Routes,
+			},
+			Origin: models.ApplicationOrigin{Kind: models.OriginPath, Path: "/src/web"},
+		}, nil)
+
+		diff, err := epinioClient.AppDiff(m)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+})
@@ -2,27 +2,40 @@ package usercmd
 
 import (
 	"context"
+	"strings"
 
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
 // EnvList displays a table of all environment variables and their
-// values for the named application.
-func (c *EpinioClient) EnvList(ctx context.Context, appName string) error {
+// values for the named application. With buildOnly set the build-only
+// environment is shown instead.
+func (c *EpinioClient) EnvList(ctx context.Context, appName string, buildOnly bool) error {
 	log := c.Log.WithName("EnvList")
 	log.Info("start")
 	defer log.Info("return")
 
+	title := "Show Application Environment"
+	if buildOnly {
+		title = "Show Application Build Environment"
+	}
+
 	c.ui.Note().
 		WithStringValue("Namespace", c.Settings.Namespace).
 		WithStringValue("Application", appName).
-		Msg("Show Application Environment")
+		Msg(title)
 
 	if err := c.TargetOk(); err != nil {
 		return err
 	}
 
-	eVariables, err := c.API.EnvList(c.Settings.Namespace, appName)
+	var eVariables models.EnvVariableMap
+	var err error
+	if buildOnly {
+		eVariables, err = c.API.BuildEnvList(c.Settings.Namespace, appName)
+	} else {
+		eVariables, err = c.API.EnvList(c.Settings.Namespace, appName)
+	}
 	if err != nil {
 		return err
 	}
@@ -39,17 +52,24 @@ func (c *EpinioClient) EnvList(ctx context.Context, appName string) error {
 
 // EnvSet adds or modifies the specified environment variable in the
 // named application, with the given value. A workload is restarted.
-func (c *EpinioClient) EnvSet(ctx context.Context, appName, envName, envValue string) error {
+// With buildOnly set the variable is placed into the build-only
+// environment instead, and no restart happens.
+func (c *EpinioClient) EnvSet(ctx context.Context, appName, envName, envValue string, buildOnly bool) error {
 	log := c.Log.WithName("Env")
 	log.Info("start")
 	defer log.Info("return")
 
+	title := "Extend or modify application environment"
+	if buildOnly {
+		title = "Extend or modify application build environment"
+	}
+
 	c.ui.Note().
 		WithStringValue("Namespace", c.Settings.Namespace).
 		WithStringValue("Application", appName).
 		WithStringValue("Variable", envName).
 		WithStringValue("Value", envValue).
-		Msg("Extend or modify application environment")
+		Msg(title)
 
 	if err := c.TargetOk(); err != nil {
 		return err
@@ -58,15 +78,30 @@ func (c *EpinioClient) EnvSet(ctx context.Context, appName, envName, envValue st
 	request := models.EnvVariableMap{}
 	request[envName] = envValue
 
-	_, err := c.API.EnvSet(request, c.Settings.Namespace, appName)
+	var err error
+	if buildOnly {
+		_, err = c.API.BuildEnvSet(request, c.Settings.Namespace, appName)
+	} else {
+		_, err = c.API.EnvSet(request, c.Settings.Namespace, appName)
+	}
 	if err != nil {
 		return err
 	}
 
+	if !buildOnly && isBuildpackVariable(envName) {
+		c.ui.Exclamation().Msg("Deprecated: staging sees buildpack variables of the regular environment. Set them with --build-only instead")
+	}
+
 	c.ui.Success().Msg("OK")
 	return nil
 }
 
+// isBuildpackVariable returns true for the variables configuring buildpacks, which belong
+// into the build-only environment.
+func isBuildpackVariable(name string) bool {
+	return strings.HasPrefix(name, "BP_") || strings.HasPrefix(name, "BPL_") || strings.HasPrefix(name, "BPE_")
+}
+
 // EnvShow shows the value of the specified environment variable in
 // the named application.
 func (c *EpinioClient) EnvShow(ctx context.Context, appName, envName string) error {
@@ -97,23 +132,35 @@ func (c *EpinioClient) EnvShow(ctx context.Context, appName, envName string) err
 }
 
 // EnvUnset removes the specified environment variable from the named
-// application. A workload is restarted.
-func (c *EpinioClient) EnvUnset(ctx context.Context, appName, envName string) error {
+// application. A workload is restarted. With buildOnly set the variable
+// is removed from the build-only environment instead, and no restart
+// happens.
+func (c *EpinioClient) EnvUnset(ctx context.Context, appName, envName string, buildOnly bool) error {
 	log := c.Log.WithName("Env")
 	log.Info("start")
 	defer log.Info("return")
 
+	title := "Remove from application environment"
+	if buildOnly {
+		title = "Remove from application build environment"
+	}
+
 	c.ui.Note().
 		WithStringValue("Namespace", c.Settings.Namespace).
 		WithStringValue("Application", appName).
 		WithStringValue("Variable", envName).
-		Msg("Remove from application environment")
+		Msg(title)
 
 	if err := c.TargetOk(); err != nil {
 		return err
 	}
 
-	_, err := c.API.EnvUnset(c.Settings.Namespace, appName, envName)
+	var err error
+	if buildOnly {
+		_, err = c.API.BuildEnvUnset(c.Settings.Namespace, appName, envName)
+	} else {
+		_, err = c.API.EnvUnset(c.Settings.Namespace, appName, envName)
+	}
 	if err != nil {
 		return err
 	}
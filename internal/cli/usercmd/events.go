@@ -0,0 +1,64 @@
+package usercmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Events streams the events of the targeted namespace, optionally only those of the
+// named application, until interrupted.
+func (c *EpinioClient) Events(appName string) error {
+	log := c.Log.WithName("Events").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().WithStringValue("Namespace", c.Settings.Namespace)
+	if appName != "" {
+		msg = msg.WithStringValue("Application", appName)
+	}
+	msg.Msg("Streaming events")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	callback := func(event models.Event) {
+		if appName != "" && !appEvent(event, appName) {
+			return
+		}
+		c.ui.Normal().Compact().Msg(formatEvent(event))
+	}
+
+	return c.API.NamespaceEvents(c.Settings.Namespace, callback)
+}
+
+// appEvent returns true if the event is about the named application.
+func appEvent(event models.Event, appName string) bool {
+	switch event.Type {
+	case models.EventServiceReady, models.EventServiceFailed, models.EventConfigurationChanged:
+		return false
+	}
+	return event.Name == appName
+}
+
+// formatEvent returns the event as single line, its time, type, subject, and details.
+func formatEvent(event models.Event) string {
+	details := []string{}
+	if event.StageID != "" {
+		details = append(details, "stage "+event.StageID)
+	}
+	if event.Instance != "" {
+		details = append(details, "instance "+event.Instance)
+	}
+	if event.Type == models.EventDeploymentProgress {
+		details = append(details, fmt.Sprintf("%d/%d ready", event.Ready, event.Desired))
+	}
+	if event.Message != "" {
+		details = append(details, event.Message)
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s  %-21s %s  %s",
+		event.Time.Format("15:04:05"), event.Type, event.Name, strings.Join(details, ", ")))
+}
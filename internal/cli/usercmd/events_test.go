@@ -0,0 +1,44 @@
+package usercmd_test
+
+import (
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Client Events unit tests", func() {
+	var fake *usercmdfakes.FakeAPIClient
+	var epinioClient *usercmd.EpinioClient
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+
+		var err error
+		epinioClient, err = usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("streams the events of the targeted namespace", func() {
+		fake.NamespaceEventsStub = func(namespace string, callback func(models.Event)) error {
+			callback(models.Event{Type: models.EventAppCreated, Name: "web"})
+			callback(models.Event{Type: models.EventServiceReady, Name: "web"})
+			return nil
+		}
+
+		Expect(epinioClient.Events("web")).To(Succeed())
+		Expect(fake.NamespaceEventsCallCount()).To(Equal(1))
+
+		namespace, _ := fake.NamespaceEventsArgsForCall(0)
+		Expect(namespace).To(Equal("workspace"))
+	})
+
+	It("returns the errors of the stream", func() {
+		fake.NamespaceEventsReturns(errors.New("connection lost"))
+
+		Expect(epinioClient.Events("")).To(MatchError("connection lost"))
+	})
+})
@@ -11,22 +11,28 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-// CreateNamespace creates a namespace
-func (c *EpinioClient) CreateNamespace(namespace string) error {
+// CreateNamespace creates a namespace, limited by the quota, if any
+func (c *EpinioClient) CreateNamespace(namespace string, quota *models.NamespaceQuota) error {
 	log := c.Log.WithName("CreateNamespace").WithValues("Namespace", namespace)
 	log.Info("start")
 	defer log.Info("return")
 
-	c.ui.Note().
-		WithStringValue("Name", namespace).
-		Msg("Creating namespace...")
+	msg := c.ui.Note().
+		WithStringValue("Name", namespace)
+	if quota != nil {
+		msg = msg.WithStringValue("Quota", quotaString(*quota))
+	}
+	msg.Msg("Creating namespace...")
 
 	errorMsgs := validation.IsDNS1123Subdomain(namespace)
 	if len(errorMsgs) > 0 {
 		return fmt.Errorf("%s: %s", "namespace name incorrect", strings.Join(errorMsgs, "\n"))
 	}
 
-	_, err := c.API.NamespaceCreate(models.NamespaceCreateRequest{Name: namespace})
+	_, err := c.API.NamespaceCreate(models.NamespaceCreateRequest{
+		Name:  namespace,
+		Quota: quota,
+	})
 	if err != nil {
 		return err
 	}
@@ -178,7 +184,164 @@ func (c *EpinioClient) ShowNamespace(namespace string) error {
 		WithTableRow("Applications", strings.Join(space.Apps, "\n")).
 		WithTableRow("Configurations", strings.Join(space.Configurations, "\n"))
 
+	msg = msg.
+		WithTableRow("Owners", strings.Join(space.Owners, "\n")).
+		WithTableRow("Members", strings.Join(space.Members, "\n"))
+
+	if space.Quota != nil {
+		usage := models.NamespaceUsage{}
+		if space.Usage != nil {
+			usage = *space.Usage
+		}
+		msg = msg.
+			WithTableRow("Quota", quotaString(*space.Quota)).
+			WithTableRow("Usage", usageString(*space.Quota, usage))
+	}
+
 	msg.Msg("Details:")
 
 	return nil
 }
+
+// NamespaceMembers lists the users with access to the namespace, and their roles
+func (c *EpinioClient) NamespaceMembers(namespace string) error {
+	log := c.Log.WithName("NamespaceMembers").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", namespace).
+		Msg("Listing namespace members...")
+
+	space, err := c.API.NamespaceShow(namespace)
+	if err != nil {
+		return err
+	}
+
+	msg := c.ui.Success().WithTable("User", "Role")
+	for _, owner := range space.Owners {
+		msg = msg.WithTableRow(owner, models.NamespaceRoleOwner)
+	}
+	for _, member := range space.Members {
+		msg = msg.WithTableRow(member, models.NamespaceRoleMember)
+	}
+	msg.Msg("Epinio Namespace Members:")
+
+	return nil
+}
+
+// UpdateNamespaceQuota replaces the quota of the namespace. No quota removes all limits
+func (c *EpinioClient) UpdateNamespaceQuota(namespace string, quota models.NamespaceQuota) error {
+	log := c.Log.WithName("UpdateNamespaceQuota").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	limits := quotaString(quota)
+	if limits == "" {
+		limits = "none"
+	}
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Quota", limits).
+		Msg("Updating namespace quota...")
+
+	_, err := c.API.NamespaceQuotaUpdate(quota, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace quota updated.")
+
+	return nil
+}
+
+// AddNamespaceMember gives the user access to the namespace, in the role
+func (c *EpinioClient) AddNamespaceMember(namespace, username, role string) error {
+	log := c.Log.WithName("AddNamespaceMember").WithValues("Namespace", namespace, "User", username)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("User", username).
+		WithStringValue("Role", role).
+		Msg("Adding namespace member...")
+
+	_, err := c.API.NamespaceMemberAdd(models.NamespaceMemberRequest{
+		Username: username,
+		Role:     role,
+	}, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace member added.")
+
+	return nil
+}
+
+// RemoveNamespaceMember removes the access of the user to the namespace
+func (c *EpinioClient) RemoveNamespaceMember(namespace, username string) error {
+	log := c.Log.WithName("RemoveNamespaceMember").WithValues("Namespace", namespace, "User", username)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("User", username).
+		Msg("Removing namespace member...")
+
+	_, err := c.API.NamespaceMemberRemove(namespace, username)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace member removed.")
+
+	return nil
+}
+
+// quotaString returns the limits of the quota for display.
+func quotaString(quota models.NamespaceQuota) string {
+	limits := []string{}
+	if quota.MaxApps > 0 {
+		limits = append(limits, fmt.Sprintf("%d apps", quota.MaxApps))
+	}
+	if quota.MaxServices > 0 {
+		limits = append(limits, fmt.Sprintf("%d services", quota.MaxServices))
+	}
+	if quota.Memory != "" {
+		limits = append(limits, fmt.Sprintf("%s memory", quota.Memory))
+	}
+	if quota.CPU != "" {
+		limits = append(limits, fmt.Sprintf("%s cpu", quota.CPU))
+	}
+	return strings.Join(limits, "\n")
+}
+
+// usageString returns the usage of the limited resources for display, against their limits.
+func usageString(quota models.NamespaceQuota, usage models.NamespaceUsage) string {
+	used := []string{}
+	if quota.MaxApps > 0 {
+		used = append(used, fmt.Sprintf("%d/%d apps", usage.Apps, quota.MaxApps))
+	}
+	if quota.MaxServices > 0 {
+		used = append(used, fmt.Sprintf("%d/%d services", usage.Services, quota.MaxServices))
+	}
+	if quota.Memory != "" {
+		used = append(used, fmt.Sprintf("%s/%s memory", orZero(usage.Memory), quota.Memory))
+	}
+	if quota.CPU != "" {
+		used = append(used, fmt.Sprintf("%s/%s cpu", orZero(usage.CPU), quota.CPU))
+	}
+	return strings.Join(used, "\n")
+}
+
+// orZero returns the quantity, or zero for none.
+func orZero(quantity string) string {
+	if quantity == "" {
+		return "0"
+	}
+	return quantity
+}
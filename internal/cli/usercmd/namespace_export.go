@@ -0,0 +1,423 @@
+package usercmd
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/internal/manifest"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// NamespaceExportFile is the name of the file holding the namespace, its
+	// configurations and services, in the directory of an export.
+	NamespaceExportFile = "namespace.yml"
+	// NamespaceExportApps is the name of the sub-directory holding the application
+	// manifests, in the directory of an export.
+	NamespaceExportApps = "apps"
+)
+
+// NamespaceExport is the content of the namespace file of an export. The bindings of the
+// configurations are found in the application manifests, the bindings of the services in
+// the services.
+type NamespaceExport struct {
+	Name           string                         `yaml:"name"`
+	Quota          *models.NamespaceQuota         `yaml:"quota,omitempty"`
+	Salt           string                         `yaml:"salt,omitempty"` // Set when the configuration values and service settings are encrypted
+	Configurations []models.ConfigurationManifest `yaml:"configurations,omitempty"`
+	Services       []models.ServiceManifest       `yaml:"services,omitempty"`
+}
+
+// ExportNamespace writes the namespace, its configurations, services and applications
+// into the directory. With a passphrase the values of the configurations and the settings
+// of the services are encrypted.
+func (c *EpinioClient) ExportNamespace(namespace, directory, passphrase string) error {
+	log := c.Log.WithName("ExportNamespace").WithValues("Namespace", namespace, "Directory", directory)
+	log.Info("start")
+	defer log.Info("return")
+	details := log.V(1) // NOTE: Increment of level, not absolute.
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Directory", directory).
+		WithBoolValue("Encrypted", passphrase != "").
+		Msg("Exporting namespace...")
+
+	space, err := c.API.NamespaceShow(namespace)
+	if err != nil {
+		return err
+	}
+
+	export := NamespaceExport{
+		Name:  namespace,
+		Quota: space.Quota,
+	}
+
+	var gcm cipher.AEAD
+	if passphrase != "" {
+		salt := make([]byte, 16)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return errors.Wrap(err, "generating salt")
+		}
+		export.Salt = base64.StdEncoding.EncodeToString(salt)
+
+		gcm, err = exportCipher(passphrase, salt)
+		if err != nil {
+			return err
+		}
+	}
+
+	details.Info("export configurations")
+
+	configurations, err := c.API.Configurations(namespace)
+	if err != nil {
+		return err
+	}
+
+	// Configurations created by services, or shared with the namespace, are not
+	// exported. They are recreated by the services, or not owned by the namespace.
+	skipped := map[string]struct{}{}
+	for _, configuration := range configurations {
+		name := configuration.Meta.Name
+
+		if configuration.Configuration.Origin != "" || configuration.Configuration.OriginNamespace != "" {
+			skipped[name] = struct{}{}
+			continue
+		}
+
+		response, err := c.API.ConfigurationShow(namespace, name)
+		if err != nil {
+			return err
+		}
+
+		data := map[string]string{}
+		for key, value := range response.Configuration.Details {
+			if _, ok := response.Configuration.External[key]; ok {
+				continue
+			}
+			if gcm != nil {
+				value, err = encryptValue(gcm, value)
+				if err != nil {
+					return err
+				}
+			}
+			data[key] = value
+		}
+
+		export.Configurations = append(export.Configurations, models.ConfigurationManifest{
+			Name:     name,
+			Data:     data,
+			External: response.Configuration.External,
+			Restart:  response.Configuration.Restart,
+		})
+	}
+
+	details.Info("export services")
+
+	services, err := c.API.ServiceList(namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		boundApps := append([]string{}, service.BoundApps...)
+		sort.Strings(boundApps)
+
+		// The list does not carry the settings of the services.
+		shown, err := c.API.ServiceShow(&models.ServiceShowRequest{Name: service.Meta.Name}, namespace)
+		if err != nil {
+			return err
+		}
+
+		settings := shown.Settings
+		if gcm != nil && len(settings) > 0 {
+			settings = models.AppSettings{}
+			for key, value := range shown.Settings {
+				settings[key], err = encryptValue(gcm, value)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		export.Services = append(export.Services, models.ServiceManifest{
+			Name:           service.Meta.Name,
+			CatalogService: service.CatalogService,
+			Settings:       settings,
+			BoundApps:      boundApps,
+		})
+	}
+
+	appsDir := filepath.Join(directory, NamespaceExportApps)
+	if err := os.MkdirAll(appsDir, 0700); err != nil {
+		return errors.Wrap(err, "creating export directory")
+	}
+
+	details.Info("export applications")
+
+	for _, appName := range space.Apps {
+		m, err := c.appManifest(namespace, appName)
+		if err != nil {
+			return err
+		}
+
+		kept := []string{}
+		for _, name := range m.Configuration.Configurations {
+			if _, ok := skipped[name]; !ok {
+				kept = append(kept, name)
+			}
+		}
+		m.Configuration.Configurations = kept
+
+		if err := writeYAML(filepath.Join(appsDir, appName+".yml"), m); err != nil {
+			return err
+		}
+	}
+
+	if err := writeYAML(filepath.Join(directory, NamespaceExportFile), export); err != nil {
+		return err
+	}
+
+	c.ui.Success().
+		WithStringValue("Applications", strings.Join(space.Apps, ", ")).
+		WithIntValue("Configurations", len(export.Configurations)).
+		WithIntValue("Services", len(export.Services)).
+		Msg("Namespace exported.")
+
+	return nil
+}
+
+// ImportNamespace recreates the namespace exported into the directory, under the new name,
+// if any. The configurations and services are created before the applications using
+// them. The services are bound last. Applications with sources no longer found at their
+// path are created without a workload, to be pushed later. The quota of the namespace is
+// skipped on request, and when the user is not allowed to set it, i.e. not an admin.
+func (c *EpinioClient) ImportNamespace(ctx context.Context, directory, newName, passphrase string, skipQuota bool) error {
+	log := c.Log.WithName("ImportNamespace").WithValues("Directory", directory, "Name", newName)
+	log.Info("start")
+	defer log.Info("return")
+	details := log.V(1) // NOTE: Increment of level, not absolute.
+
+	content, err := os.ReadFile(filepath.Join(directory, NamespaceExportFile))
+	if err != nil {
+		return errors.Wrap(err, "filesystem error")
+	}
+
+	export := NamespaceExport{}
+	if err := yaml.Unmarshal(content, &export); err != nil {
+		return errors.Wrap(err, "bad yaml")
+	}
+
+	var gcm cipher.AEAD
+	if export.Salt != "" {
+		if passphrase == "" {
+			return errors.New("the configuration values are encrypted, a passphrase is required")
+		}
+
+		salt, err := base64.StdEncoding.DecodeString(export.Salt)
+		if err != nil {
+			return errors.Wrap(err, "bad salt")
+		}
+
+		gcm, err = exportCipher(passphrase, salt)
+		if err != nil {
+			return err
+		}
+	}
+
+	manifests, err := filepath.Glob(filepath.Join(directory, NamespaceExportApps, "*.yml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(manifests)
+
+	namespace := export.Name
+	if newName != "" {
+		namespace = newName
+	}
+
+	c.ui.Note().
+		WithStringValue("Directory", directory).
+		WithStringValue("Namespace", namespace).
+		Msg("Importing namespace...")
+
+	// Decrypt up front, a bad passphrase must not leave a half-imported namespace behind.
+	for _, configuration := range export.Configurations {
+		if gcm == nil {
+			break
+		}
+		for key, value := range configuration.Data {
+			configuration.Data[key], err = decryptValue(gcm, value)
+			if err != nil {
+				return errors.Wrapf(err, "configuration %s, key %s", configuration.Name, key)
+			}
+		}
+	}
+	for _, service := range export.Services {
+		if gcm == nil {
+			break
+		}
+		for key, value := range service.Settings {
+			service.Settings[key], err = decryptValue(gcm, value)
+			if err != nil {
+				return errors.Wrapf(err, "service %s, setting %s", service.Name, key)
+			}
+		}
+	}
+
+	quota := export.Quota
+	if skipQuota && quota != nil {
+		c.ui.Note().Msg("Skipping the quota of the namespace.")
+		quota = nil
+	}
+
+	err = c.CreateNamespace(namespace, quota)
+	if err != nil && quota != nil && isForbidden(err) {
+		c.ui.Exclamation().Msg("Only admins can set the quota of a namespace, importing it without quota.")
+		err = c.CreateNamespace(namespace, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The remaining commands operate on the targeted namespace.
+	previous := c.Settings.Namespace
+	c.Settings.Namespace = namespace
+	defer func() { c.Settings.Namespace = previous }()
+
+	details.Info("import configurations")
+
+	for _, configuration := range export.Configurations {
+		c.ui.Normal().Msgf("Creating configuration %s ...", configuration.Name)
+
+		_, err := c.API.ConfigurationCreate(models.ConfigurationCreateRequest{
+			Name:     configuration.Name,
+			Data:     configuration.Data,
+			External: configuration.External,
+			Restart:  configuration.Restart,
+		}, namespace)
+		if err != nil {
+			return errors.Wrapf(err, "configuration %s", configuration.Name)
+		}
+	}
+
+	details.Info("import services")
+
+	for _, service := range export.Services {
+		if err := c.ServiceCreate(service.CatalogService, service.Name, service.Settings, true); err != nil {
+			return errors.Wrapf(err, "service %s", service.Name)
+		}
+	}
+
+	details.Info("import applications")
+
+	for _, path := range manifests {
+		m, err := manifest.Get(path)
+		if err != nil {
+			return errors.Wrapf(err, "manifest %s", path)
+		}
+
+		if m.Origin.Kind == models.OriginPath {
+			if _, err := os.Stat(m.Origin.Path); err != nil {
+				c.ui.Exclamation().Msgf("The sources of %s are not found at %s, creating it without workload. Push it to deploy it.",
+					m.Name, m.Origin.Path)
+
+				if err := c.AppCreate(m.Name, m.Configuration); err != nil {
+					return errors.Wrapf(err, "application %s", m.Name)
+				}
+				continue
+			}
+		}
+
+		if err := c.Push(ctx, PushParams{ApplicationManifest: m}); err != nil {
+			return errors.Wrapf(err, "application %s", m.Name)
+		}
+	}
+
+	details.Info("import service bindings")
+
+	for _, service := range export.Services {
+		for _, appName := range service.BoundApps {
+			if err := c.ServiceBind(service.Name, appName); err != nil {
+				return errors.Wrapf(err, "service %s, application %s", service.Name, appName)
+			}
+		}
+	}
+
+	c.ui.Success().WithStringValue("Namespace", namespace).Msg("Namespace imported.")
+
+	return nil
+}
+
+// isForbidden returns true if the error is a response of the API server denying the
+// request to the user.
+func isForbidden(err error) bool {
+	var response interface{ StatusCode() int }
+	return errors.As(err, &response) && response.StatusCode() == http.StatusForbidden
+}
+
+// writeYAML writes the value as yaml into the file.
+func writeYAML(path string, value interface{}) error {
+	content, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(os.WriteFile(path, content, 0600), "filesystem error")
+}
+
+// exportCipher returns the AES-GCM cipher keyed by the passphrase and salt.
+func exportCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 32768, 8, 1, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "deriving key")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptValue returns the value encrypted, as base64 of nonce and ciphertext.
+func encryptValue(gcm cipher.AEAD, value string) (string, error) {
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "generating nonce")
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), nil)), nil
+}
+
+// decryptValue reverses encryptValue.
+func decryptValue(gcm cipher.AEAD, value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", errors.Wrap(err, "bad encrypted value")
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("bad encrypted value")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("decryption failed, wrong passphrase")
+	}
+
+	return string(plain), nil
+}
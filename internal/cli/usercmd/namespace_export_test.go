@@ -0,0 +1,189 @@
+package usercmd_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("Client Namespace export unit tests", func() {
+	var fake *usercmdfakes.FakeAPIClient
+	var dir string
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+		dir = GinkgoT().TempDir()
+
+		fake.NamespaceShowReturns(models.Namespace{
+			Apps:  []string{"web"},
+			Quota: &models.NamespaceQuota{MaxApps: 3},
+		}, nil)
+		fake.ConfigurationsReturns(models.ConfigurationResponseList{
+			{Meta: models.ConfigurationRef{Meta: models.Meta{Name: "db"}}},
+			{
+				Meta:          models.ConfigurationRef{Meta: models.Meta{Name: "pg-creds"}},
+				Configuration: models.ConfigurationShowResponse{Origin: "pg"},
+			},
+		}, nil)
+		fake.ConfigurationShowReturns(models.ConfigurationResponse{
+			Configuration: models.ConfigurationShowResponse{
+				Details:  map[string]string{"password": "s3cret", "token": "resolved"},
+				External: map[string]string{"token": "vault:secret/data/db#token"},
+				Restart:  models.ConfigurationRestartRolling,
+			},
+		}, nil)
+		fake.ServiceListReturns(models.ServiceList{
+			{
+				Meta:           models.Meta{Name: "pg"},
+				CatalogService: "postgresql-dev",
+				BoundApps:      []string{"web"},
+			},
+		}, nil)
+		fake.ServiceShowReturns(&models.Service{
+			Meta:           models.Meta{Name: "pg"},
+			CatalogService: "postgresql-dev",
+			Settings:       models.AppSettings{"size": "small"},
+			BoundApps:      []string{"web"},
+		}, nil)
+		fake.AppShowReturns(models.App{
+			Configuration: models.ApplicationUpdateRequest{Configurations: []string{"db", "pg-creds"}},
+			Origin:        models.ApplicationOrigin{Kind: models.OriginPath, Path: filepath.Join(dir, "missing")},
+		}, nil)
+	})
+
+	readExport := func() usercmd.NamespaceExport {
+		content, err := os.ReadFile(filepath.Join(dir, usercmd.NamespaceExportFile))
+		Expect(err).ToNot(HaveOccurred())
+
+		export := usercmd.NamespaceExport{}
+		Expect(yaml.Unmarshal(content, &export)).To(Succeed())
+		return export
+	}
+
+	It("exports the user configurations, the services and the application manifests", func() {
+		epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(epinioClient.ExportNamespace("workspace", dir, "")).To(Succeed())
+
+		export := readExport()
+		Expect(export.Name).To(Equal("workspace"))
+		Expect(export.Salt).To(BeEmpty())
+		Expect(export.Quota).To(Equal(&models.NamespaceQuota{MaxApps: 3}))
+		Expect(export.Configurations).To(Equal([]models.ConfigurationManifest{{
+			Name:     "db",
+			Data:     map[string]string{"password": "s3cret"},
+			External: map[string]string{"token": "vault:secret/data/db#token"},
+			Restart:  models.ConfigurationRestartRolling,
+		}}))
+		Expect(export.Services).To(Equal([]models.ServiceManifest{{
+			Name:           "pg",
+			CatalogService: "postgresql-dev",
+			Settings:       models.AppSettings{"size": "small"},
+			BoundApps:      []string{"web"},
+		}}))
+
+		content, err := os.ReadFile(filepath.Join(dir, usercmd.NamespaceExportApps, "web.yml"))
+		Expect(err).ToNot(HaveOccurred())
+		m := models.ApplicationManifest{}
+		Expect(yaml.Unmarshal(content, &m)).To(Succeed())
+		Expect(m.Name).To(Equal("web"))
+		Expect(m.Configuration.Configurations).To(Equal([]string{"db"}))
+	})
+
+	It("imports an export in dependency order, under a new name", func() {
+		epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "other"}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(epinioClient.ExportNamespace("workspace", dir, "passphrase")).To(Succeed())
+		Expect(readExport().Salt).ToNot(BeEmpty())
+		Expect(readExport().Configurations[0].Data["password"]).ToNot(Equal("s3cret"))
+		Expect(readExport().Services[0].Settings["size"]).ToNot(Equal("small"))
+
+		Expect(epinioClient.ImportNamespace(context.Background(), dir, "copy", "passphrase", false)).To(Succeed())
+
+		Expect(fake.NamespaceCreateCallCount()).To(Equal(1))
+		Expect(fake.NamespaceCreateArgsForCall(0)).To(Equal(models.NamespaceCreateRequest{
+			Name:  "copy",
+			Quota: &models.NamespaceQuota{MaxApps: 3},
+		}))
+
+		Expect(fake.ConfigurationCreateCallCount()).To(Equal(1))
+		request, namespace := fake.ConfigurationCreateArgsForCall(0)
+		Expect(namespace).To(Equal("copy"))
+		Expect(request.Data).To(Equal(map[string]string{"password": "s3cret"}))
+		Expect(request.External).To(Equal(map[string]string{"token": "vault:secret/data/db#token"}))
+
+		Expect(fake.ServiceCreateCallCount()).To(Equal(1))
+		service, namespace := fake.ServiceCreateArgsForCall(0)
+		Expect(namespace).To(Equal("copy"))
+		Expect(service.CatalogService).To(Equal("postgresql-dev"))
+		Expect(service.Settings).To(Equal(models.AppSettings{"size": "small"}))
+
+		// The sources are gone, the application is created without workload
+		Expect(fake.AppCreateCallCount()).To(Equal(1))
+		app, namespace := fake.AppCreateArgsForCall(0)
+		Expect(namespace).To(Equal("copy"))
+		Expect(app.Name).To(Equal("web"))
+		Expect(app.Configuration.Configurations).To(Equal([]string{"db"}))
+
+		Expect(fake.ServiceBindCallCount()).To(Equal(1))
+		bind, namespace, name := fake.ServiceBindArgsForCall(0)
+		Expect(bind.AppName).To(Equal("web"))
+		Expect(namespace).To(Equal("copy"))
+		Expect(name).To(Equal("pg"))
+
+		Expect(epinioClient.Settings.Namespace).To(Equal("other"))
+	})
+
+	It("rejects a wrong passphrase before creating anything", func() {
+		epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(epinioClient.ExportNamespace("workspace", dir, "passphrase")).To(Succeed())
+
+		err = epinioClient.ImportNamespace(context.Background(), dir, "", "wrong", false)
+		Expect(err).To(MatchError(ContainSubstring("wrong passphrase")))
+		Expect(fake.NamespaceCreateCallCount()).To(Equal(0))
+	})
+
+	It("imports without the quota when asked to", func() {
+		epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(epinioClient.ExportNamespace("workspace", dir, "")).To(Succeed())
+		Expect(epinioClient.ImportNamespace(context.Background(), dir, "copy", "", true)).To(Succeed())
+
+		Expect(fake.NamespaceCreateCallCount()).To(Equal(1))
+		Expect(fake.NamespaceCreateArgsForCall(0)).To(Equal(models.NamespaceCreateRequest{Name: "copy"}))
+	})
+
+	It("imports without the quota when the user may not set it", func() {
+		epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{}, fake)
+		Expect(err).ToNot(HaveOccurred())
+
+		fake.NamespaceCreateReturnsOnCall(0, models.Response{}, forbiddenError{})
+
+		Expect(epinioClient.ExportNamespace("workspace", dir, "")).To(Succeed())
+		Expect(epinioClient.ImportNamespace(context.Background(), dir, "copy", "", false)).To(Succeed())
+
+		Expect(fake.NamespaceCreateCallCount()).To(Equal(2))
+		Expect(fake.NamespaceCreateArgsForCall(0).Quota).ToNot(BeNil())
+		Expect(fake.NamespaceCreateArgsForCall(1)).To(Equal(models.NamespaceCreateRequest{Name: "copy"}))
+	})
+})
+
+// forbiddenError is an API response error denying the request.
+type forbiddenError struct{}
+
+func (forbiddenError) Error() string   { return "only admins can set the quota of a namespace" }
+func (forbiddenError) StatusCode() int { return http.StatusForbidden }
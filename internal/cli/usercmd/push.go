@@ -62,6 +62,9 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 	for _, ev := range params.Configuration.Environment.List() {
 		msg = msg.WithStringValue(fmt.Sprintf("Environment '%s'", ev.Name), ev.Value)
 	}
+	for _, ev := range params.Staging.Environment.List() {
+		msg = msg.WithStringValue(fmt.Sprintf("Build Environment '%s'", ev.Name), ev.Value)
+	}
 	// TODO ? Make this a table for nicer alignment
 
 	if err := c.TargetOk(); err != nil {
@@ -125,6 +128,16 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 		}
 	}
 
+	// build-only environment, used by staging
+	if len(params.Staging.Environment) > 0 {
+		details.Info("set build environment")
+
+		_, err = c.API.BuildEnvSet(params.Staging.Environment, appRef.Namespace, appRef.Name)
+		if err != nil {
+			return err
+		}
+	}
+
 	// check customization
 	_, err = c.API.AppValidateCV(appRef.Namespace, appRef.Name)
 	if err != nil {
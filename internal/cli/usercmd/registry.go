@@ -0,0 +1,96 @@
+package usercmd
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RegistryLogins lists the container registries the targeted namespace has pull
+// credentials for
+func (c *EpinioClient) RegistryLogins() error {
+	log := c.Log.WithName("RegistryLogins")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Listing registry logins")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	logins, err := c.API.RegistryLogins(c.Settings.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if len(logins) == 0 {
+		c.ui.Exclamation().Msg("No registry logins found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Registry", "Username")
+	for _, login := range logins {
+		msg = msg.WithTableRow(login.URL, login.Username)
+	}
+	msg.Msg("Registry logins")
+
+	return nil
+}
+
+// RegistryLogin stores the pull credentials for the container registry in the targeted
+// namespace
+func (c *EpinioClient) RegistryLogin(registryURL, username, password string) error {
+	log := c.Log.WithName("RegistryLogin").WithValues("Registry", registryURL)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Registry", registryURL).
+		WithStringValue("Username", username).
+		Msg("Logging into container registry")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.RegistryLogin(c.Settings.Namespace, models.RegistryLoginRequest{
+		URL:      registryURL,
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Credentials stored. Application images from the registry will be pulled with them.")
+
+	return nil
+}
+
+// RegistryLogout removes the pull credentials for the container registry from the
+// targeted namespace
+func (c *EpinioClient) RegistryLogout(registryURL string) error {
+	log := c.Log.WithName("RegistryLogout").WithValues("Registry", registryURL)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Registry", registryURL).
+		Msg("Logging out of container registry")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.RegistryLogout(c.Settings.Namespace, registryURL)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Credentials removed.")
+
+	return nil
+}
@@ -1,16 +1,23 @@
 package usercmd
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/epinio/epinio/helpers/termui"
+	"github.com/epinio/epinio/pkg/api/core/v1/client"
 	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/fatih/color"
 	"github.com/kyokomi/emoji"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
 // ServiceCatalog lists available services
@@ -63,30 +70,265 @@ func (c *EpinioClient) ServiceCatalogShow(serviceName string) error {
 		WithTableRow("Version", catalogService.AppVersion).
 		WithTableRow("Short Description", catalogService.ShortDescription).
 		WithTableRow("Description", catalogService.Description).
+		WithTableRow("Namespaces", catalogNamespaces(catalogService)).
 		Msg("Epinio Service:")
 
+	if len(catalogService.Settings) > 0 {
+		var keys []string
+		for key := range catalogService.Settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		msg := c.ui.Note().WithTable("Key", "Type", "Allowed Values")
+
+		for _, key := range keys {
+			spec := catalogService.Settings[key]
+			msg = msg.WithTableRow(key, spec.Type, details(spec))
+		}
+
+		msg.Msg("Settings")
+	} else {
+		c.ui.Exclamation().Msg("No settings")
+	}
+
 	return nil
 }
 
-// ServiceCreate creates a service
-func (c *EpinioClient) ServiceCreate(catalogServiceName, serviceName string) error {
-	log := c.Log.WithName("ServiceCreate")
+// catalogNamespaces returns a description of the namespaces the catalog service is
+// available in
+func catalogNamespaces(catalogService *models.CatalogService) string {
+	if len(catalogService.Namespaces) == 0 {
+		return "all"
+	}
+	return strings.Join(catalogService.Namespaces, ", ")
+}
+
+// ServiceCatalogAdd adds the catalog service declared in the file to the catalog
+func (c *EpinioClient) ServiceCatalogAdd(path string) error {
+	log := c.Log.WithName("ServiceCatalogAdd")
+	log.Info("start")
+	defer log.Info("return")
+
+	entry, name, err := catalogServiceFile(path)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Note().
+		WithStringValue("Service", name).
+		Msg("Adding catalog service...")
+
+	err = c.API.CatalogServiceAdd(entry)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Catalog service added.")
+
+	return nil
+}
+
+// ServiceCatalogUpdate replaces the catalog service declared in the file
+func (c *EpinioClient) ServiceCatalogUpdate(path string) error {
+	log := c.Log.WithName("ServiceCatalogUpdate")
 	log.Info("start")
 	defer log.Info("return")
 
+	entry, name, err := catalogServiceFile(path)
+	if err != nil {
+		return err
+	}
+
 	c.ui.Note().
+		WithStringValue("Service", name).
+		Msg("Updating catalog service...")
+
+	err = c.API.CatalogServiceUpdate(entry, name)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Catalog service updated.")
+
+	return nil
+}
+
+// ServiceCatalogDelete removes a service from the catalog
+func (c *EpinioClient) ServiceCatalogDelete(name string) error {
+	log := c.Log.WithName("ServiceCatalogDelete")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Service", name).
+		Msg("Removing catalog service...")
+
+	err := c.API.CatalogServiceDelete(name)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Catalog service removed.")
+
+	return nil
+}
+
+// catalogServiceFile reads the catalog service from the YAML file, and returns it with
+// its name. The file holds the `Service` custom resource of the catalog.
+func catalogServiceFile(path string) (models.CatalogServiceRequest, string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "reading the catalog service")
+	}
+
+	var entry models.CatalogServiceRequest
+	err = yaml.Unmarshal(content, &entry)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "decoding the catalog service")
+	}
+
+	object := unstructured.Unstructured{Object: entry}
+	name := object.GetName()
+	if name == "" {
+		name, _, _ = unstructured.NestedString(entry, "spec", "name")
+	}
+	if name == "" {
+		return nil, "", errors.New("the name of the catalog service is missing")
+	}
+
+	return entry, name, nil
+}
+
+// ServiceCreate creates a service, customized by the settings. The service is provisioned
+// in the background, waiting for it is optional.
+func (c *EpinioClient) ServiceCreate(catalogServiceName, serviceName string, settings models.AppSettings, wait bool) error {
+	log := c.Log.WithName("ServiceCreate")
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
 		WithStringValue("Catalog", catalogServiceName).
-		WithStringValue("Service", serviceName).
-		Msg("Creating Service...")
+		WithStringValue("Service", serviceName)
+	for _, setting := range settings.List() {
+		msg = msg.WithStringValue("Setting "+setting.Name, setting.Value)
+	}
+	msg.Msg("Creating Service...")
 
 	request := &models.ServiceCreateRequest{
 		CatalogService: catalogServiceName,
 		Name:           serviceName,
+		Settings:       settings,
 	}
 
 	err := c.API.ServiceCreate(request, c.Settings.Namespace)
-	// Note: errors.Wrap (nil, "...") == nil
-	return errors.Wrap(err, "service create failed")
+	if err != nil {
+		return errors.Wrap(err, "service create failed")
+	}
+
+	if !wait {
+		c.ui.Success().Msg("Service created, provisioning in the background. Use `epinio service show` to follow it.")
+		return nil
+	}
+
+	c.ui.Note().Msg("Waiting for the service to be provisioned...")
+
+	err = c.API.ServiceReady(c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service provisioning failed")
+	}
+
+	c.ui.Success().Msg("Service provisioned.")
+	return nil
+}
+
+// ServiceUpdate changes the settings of a service, and/or upgrades it to another chart
+// version. An empty version upgrades to the chart of the catalog service. The change of
+// the helm values is shown first. A dry run stops after that.
+func (c *EpinioClient) ServiceUpdate(serviceName string, settings models.AppSettings, upgrade bool, chartVersion string, dryRun bool) error {
+	log := c.Log.WithName("ServiceUpdate")
+	log.Info("start")
+	defer log.Info("return")
+
+	title := "Updating Service..."
+	if upgrade {
+		title = "Upgrading Service..."
+	}
+
+	msg := c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName)
+	if chartVersion != "" {
+		msg = msg.WithStringValue("Chart Version", chartVersion)
+	}
+	for _, setting := range settings.List() {
+		msg = msg.WithStringValue("Setting "+setting.Name, setting.Value)
+	}
+	msg.Msg(title)
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	request := models.ServiceUpdateRequest{
+		Settings:     settings,
+		Upgrade:      upgrade,
+		ChartVersion: chartVersion,
+		DryRun:       true,
+	}
+
+	change, err := c.API.ServiceUpdate(request, c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service update failed")
+	}
+
+	c.showServiceChange(change)
+
+	if dryRun {
+		c.ui.Success().Msg("Dry run, service not changed")
+		return nil
+	}
+
+	request.DryRun = false
+	_, err = c.API.ServiceUpdate(request, c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service update failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Service", serviceName).
+		WithStringValue("Chart Version", change.ChartVersion).
+		Msg("Service changed")
+
+	return nil
+}
+
+// showServiceChange shows the chart versions and the values diff of a service change
+func (c *EpinioClient) showServiceChange(change models.ServiceUpdateResponse) {
+	if change.PreviousChartVersion != change.ChartVersion {
+		c.ui.Normal().
+			WithStringValue("From", change.PreviousChartVersion).
+			WithStringValue("To", change.ChartVersion).
+			Msg("Chart version")
+	}
+
+	if change.Diff == "" {
+		c.ui.Normal().Msg("No changes to the values")
+		return
+	}
+
+	lines := strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			lines[i] = color.GreenString(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = color.RedString(line)
+		}
+	}
+
+	c.ui.Normal().Msg("Values diff:")
+	c.ui.Normal().Compact().Msg(strings.Join(lines, "\n"))
 }
 
 // ServiceShow describes a service instance
@@ -126,21 +368,152 @@ func (c *EpinioClient) ServiceShow(serviceName string) error {
 		m = "Details:"
 	}
 
-	msg.WithTable("Key", "Value").
+	msg = msg.WithTable("Key", "Value").
 		WithTableRow("Name", service.Meta.Name).
 		WithTableRow("Created", service.Meta.CreatedAt.String()).
 		WithTableRow("Catalog Service", service.CatalogService).
 		WithTableRow("Version", service.CatalogServiceVersion).
+		WithTableRow("Chart Version", service.ChartVersion).
 		WithTableRow("Status", service.Status.String()).
+		WithTableRow("Phase", service.Phase.String()).
 		WithTableRow("Used-By", strings.Join(boundApps, ", ")).
 		WithTableRow("Internal Routes", strings.Join(internalRoutes, ", ")).
-		Msg(m)
+		WithTableRow("Backup Schedule", backupSchedule(service)).
+		WithTableRow("Shared With", strings.Join(service.Shares, ", ")).
+		WithTableRow("Settings", "")
+
+	for _, setting := range service.Settings.List() {
+		msg = msg.WithTableRow("  - "+setting.Name, setting.Value)
+	}
+
+	msg.Msg(m)
+
+	if service.Reason != "" {
+		c.ui.Problem().Msg("Provisioning failed: " + service.Reason)
+	}
+	for _, warning := range service.Warnings {
+		c.ui.Exclamation().Msg(warning)
+	}
+	if service.Notes != "" {
+		c.ui.Normal().Compact().Msg("Notes:\n" + service.Notes)
+	}
+
+	if service.AvailableChartVersion != "" {
+		c.ui.Exclamation().
+			WithStringValue("Chart Version", service.AvailableChartVersion).
+			Msg("The catalog service offers a newer chart. Use `epinio service upgrade` to upgrade")
+	}
+
+	return nil
+}
+
+// ServiceRotateCredentials rotates the credentials of a service, and reports the restarted
+// applications bound to it
+func (c *EpinioClient) ServiceRotateCredentials(serviceName string) error {
+	log := c.Log.WithName("ServiceRotateCredentials")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName).
+		Msg("Rotating Service Credentials...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	result, err := c.API.ServiceRotateCredentials(c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service credentials rotation failed")
+	}
+
+	msg := c.ui.Success()
+	if len(result.RestartedApps) > 0 {
+		msg = msg.WithStringValue("Restarted Applications", strings.Join(result.RestartedApps, ", "))
+	}
+	msg.Msg("Service credentials rotated.")
 
 	return nil
 }
 
+// ServiceShare shares a service with another namespace, and reports the configurations
+// projected into that namespace
+func (c *EpinioClient) ServiceShare(serviceName, target string) error {
+	log := c.Log.WithName("ServiceShare")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName).
+		WithStringValue("Target Namespace", target).
+		Msg("Sharing Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	request := models.ServiceShareRequest{
+		Namespace: target,
+	}
+
+	result, err := c.API.ServiceShare(request, c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service share failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Configurations", strings.Join(result.Configurations, ", ")).
+		Msg("Service shared. Bind the configurations to the applications of the target namespace.")
+
+	return nil
+}
+
+// ServiceUnshare stops sharing a service with the target namespace, and reports the
+// applications the projected configurations were unbound from
+func (c *EpinioClient) ServiceUnshare(serviceName, target string) error {
+	log := c.Log.WithName("ServiceUnshare")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName).
+		WithStringValue("Target Namespace", target).
+		Msg("Unsharing Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	result, err := c.API.ServiceUnshare(c.Settings.Namespace, serviceName, target)
+	if err != nil {
+		return errors.Wrap(err, "service unshare failed")
+	}
+
+	msg := c.ui.Success()
+	if len(result.UnboundApps) > 0 {
+		msg = msg.WithStringValue("Unbound Applications", strings.Join(result.UnboundApps, ", "))
+	}
+	msg.Msg("Service unshared.")
+
+	return nil
+}
+
+// backupSchedule returns a description of the service's backup schedule
+func backupSchedule(service *models.Service) string {
+	if service.BackupInterval == "" {
+		return "none"
+	}
+	if service.BackupKeep > 0 {
+		return fmt.Sprintf("every %s, keep %d", service.BackupInterval, service.BackupKeep)
+	}
+	return fmt.Sprintf("every %s, keep all", service.BackupInterval)
+}
+
 // ServiceDelete deletes one or more services, specified by name
-func (c *EpinioClient) ServiceDelete(serviceNames []string, unbind bool) error {
+func (c *EpinioClient) ServiceDelete(serviceNames []string, unbind, force bool) error {
 	namesCSV := strings.Join(serviceNames, ", ")
 	log := c.Log.WithName("DeleteService").
 		WithValues("Services", namesCSV, "Namespace", c.Settings.Namespace)
@@ -158,9 +531,11 @@ func (c *EpinioClient) ServiceDelete(serviceNames []string, unbind bool) error {
 
 	request := models.ServiceDeleteRequest{
 		Unbind: unbind,
+		Force:  force,
 	}
 
 	var bound []string
+	var shared string
 
 	_, err := c.API.ServiceDelete(request, c.Settings.Namespace, serviceNames,
 		func(response *http.Response, bodyBytes []byte, err error) error {
@@ -169,14 +544,26 @@ func (c *EpinioClient) ServiceDelete(serviceNames []string, unbind bool) error {
 				return err
 			}
 
-			// A bad request happens when the configuration is still bound to one or
-			// more applications, and the response contains an array of their names.
+			// A bad request happens when
+			//
+			// 1. the configuration is still bound to one or more applications, and the
+			//    response contains an array of their names.
+			//
+			// 2. the service is shared with other namespaces.
 
 			var apiError apierrors.ErrorResponse
 			if err := json.Unmarshal(bodyBytes, &apiError); err != nil {
 				return err
 			}
 
+			// [SHARED] keep in sync with same marker in the server
+			if strings.HasSuffix(apiError.Errors[0].Title, "is shared") {
+				// (2.)
+				shared = apiError.Errors[0].Details
+				return nil
+			}
+
+			// (1.)
 			bound = strings.Split(apiError.Errors[0].Details, ",")
 			return nil
 		})
@@ -200,6 +587,13 @@ func (c *EpinioClient) ServiceDelete(serviceNames []string, unbind bool) error {
 		return nil
 	}
 
+	if shared != "" {
+		c.ui.Exclamation().Msgf("Unable to delete service. It is %s", shared)
+		c.ui.Exclamation().Compact().Msg("Use --force to remove the shares and delete it")
+
+		return nil
+	}
+
 	c.ui.Success().
 		WithStringValue("Names", namesCSV).
 		WithStringValue("Namespace", c.Settings.Namespace).
@@ -240,6 +634,27 @@ func (c *EpinioClient) ServiceUnbind(name, appName string) error {
 	return errors.Wrap(err, "service unbind failed")
 }
 
+// ServicePortForward forwards the local ports to a running pod of the service. The
+// remote ports are the ports of the service's kubernetes services, mapped to the target
+// ports of the pod.
+func (c *EpinioClient) ServicePortForward(ctx context.Context, name string, address, ports []string) error {
+	log := c.Log.WithName("ServicePortForward").WithValues("Namespace", c.Settings.Namespace, "Service", name)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", name).
+		Msg("Executing port forwarding")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	opts := client.NewPortForwardOpts(address, ports)
+	return c.API.ServicePortForward(c.Settings.Namespace, name, opts)
+}
+
 // ServiceList list of the service instances in the targeted namespace
 func (c *EpinioClient) ServiceList() error {
 	log := c.Log.WithName("ServiceList")
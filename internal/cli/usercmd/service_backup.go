@@ -0,0 +1,136 @@
+package usercmd
+
+import (
+	"strconv"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ServiceBackup backs up the data of a service into the S3 storage
+func (c *EpinioClient) ServiceBackup(serviceName string) error {
+	log := c.Log.WithName("ServiceBackup")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName).
+		Msg("Backing up Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	backup, err := c.API.ServiceBackup(c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service backup failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Backup", backup.ID).
+		WithStringValue("Size", units.HumanSize(float64(backup.Size))).
+		Msg("Service backed up")
+
+	return nil
+}
+
+// ServiceBackups lists the backups of a service, newest first
+func (c *EpinioClient) ServiceBackups(serviceName string) error {
+	log := c.Log.WithName("ServiceBackups")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName).
+		Msg("Listing Service Backups...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	backups, err := c.API.ServiceBackups(c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service backups failed")
+	}
+
+	if len(backups) == 0 {
+		c.ui.Normal().Msg("No backups found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("ID", "Created", "Size")
+	for _, backup := range backups {
+		msg = msg.WithTableRow(backup.ID, backup.CreatedAt.String(),
+			units.HumanSize(float64(backup.Size)))
+	}
+	msg.Msg("Details:")
+
+	return nil
+}
+
+// ServiceRestore restores the data of a service from one of its backups
+func (c *EpinioClient) ServiceRestore(serviceName, backupID string) error {
+	log := c.Log.WithName("ServiceRestore")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName).
+		WithStringValue("Backup", backupID).
+		Msg("Restoring Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	err := c.API.ServiceRestore(c.Settings.Namespace, serviceName, backupID)
+	if err != nil {
+		return errors.Wrap(err, "service restore failed")
+	}
+
+	c.ui.Success().Msg("Service restored")
+
+	return nil
+}
+
+// ServiceBackupSchedule sets the schedule of the backups of a service. An empty interval
+// disables the scheduled backups.
+func (c *EpinioClient) ServiceBackupSchedule(serviceName, interval string, keep int) error {
+	log := c.Log.WithName("ServiceBackupSchedule")
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Service", serviceName)
+	if interval != "" {
+		msg = msg.WithStringValue("Every", interval).
+			WithStringValue("Keep", strconv.Itoa(keep))
+	}
+	msg.Msg("Scheduling Service Backups...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	err := c.API.ServiceBackupSchedule(models.ServiceBackupScheduleRequest{
+		Interval: interval,
+		Keep:     keep,
+	}, c.Settings.Namespace, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service backup schedule failed")
+	}
+
+	if interval == "" {
+		c.ui.Success().Msg("Scheduled backups disabled")
+	} else {
+		c.ui.Success().Msg("Backups scheduled")
+	}
+
+	return nil
+}
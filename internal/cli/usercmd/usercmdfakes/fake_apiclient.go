@@ -63,6 +63,21 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	AppDebugStub        func(string, string, string, string, term.TTY) error
+	appDebugMutex       sync.RWMutex
+	appDebugArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 term.TTY
+	}
+	appDebugReturns struct {
+		result1 error
+	}
+	appDebugReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppDeleteStub        func(string, []string) (models.ApplicationDeleteResponse, error)
 	appDeleteMutex       sync.RWMutex
 	appDeleteArgsForCall []struct {
@@ -104,6 +119,20 @@ type FakeAPIClient struct {
 	appExecReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppExecCommandStub        func(string, string, string, client.ExecOpts) error
+	appExecCommandMutex       sync.RWMutex
+	appExecCommandArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 client.ExecOpts
+	}
+	appExecCommandReturns struct {
+		result1 error
+	}
+	appExecCommandReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppGetPartStub        func(string, string, string, string) error
 	appGetPartMutex       sync.RWMutex
 	appGetPartArgsForCall []struct {
@@ -271,6 +300,20 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	AppWebhookStub        func(string, string) (models.AppWebhookResponse, error)
+	appWebhookMutex       sync.RWMutex
+	appWebhookArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appWebhookReturns struct {
+		result1 models.AppWebhookResponse
+		result2 error
+	}
+	appWebhookReturnsOnCall map[int]struct {
+		result1 models.AppWebhookResponse
+		result2 error
+	}
 	AppsStub        func(string) (models.AppList, error)
 	appsMutex       sync.RWMutex
 	appsArgsForCall []struct {
@@ -296,6 +339,97 @@ type FakeAPIClient struct {
 		result1 string
 		result2 error
 	}
+	BlobPruneStub        func(models.BlobPruneRequest) (models.BlobPruneResponse, error)
+	blobPruneMutex       sync.RWMutex
+	blobPruneArgsForCall []struct {
+		arg1 models.BlobPruneRequest
+	}
+	blobPruneReturns struct {
+		result1 models.BlobPruneResponse
+		result2 error
+	}
+	blobPruneReturnsOnCall map[int]struct {
+		result1 models.BlobPruneResponse
+		result2 error
+	}
+	BuildEnvListStub        func(string, string) (models.EnvVariableMap, error)
+	buildEnvListMutex       sync.RWMutex
+	buildEnvListArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	buildEnvListReturns struct {
+		result1 models.EnvVariableMap
+		result2 error
+	}
+	buildEnvListReturnsOnCall map[int]struct {
+		result1 models.EnvVariableMap
+		result2 error
+	}
+	BuildEnvSetStub        func(models.EnvVariableMap, string, string) (models.Response, error)
+	buildEnvSetMutex       sync.RWMutex
+	buildEnvSetArgsForCall []struct {
+		arg1 models.EnvVariableMap
+		arg2 string
+		arg3 string
+	}
+	buildEnvSetReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	buildEnvSetReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	BuildEnvUnsetStub        func(string, string, string) (models.Response, error)
+	buildEnvUnsetMutex       sync.RWMutex
+	buildEnvUnsetArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	buildEnvUnsetReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	buildEnvUnsetReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	CatalogServiceAddStub        func(models.CatalogServiceRequest) error
+	catalogServiceAddMutex       sync.RWMutex
+	catalogServiceAddArgsForCall []struct {
+		arg1 models.CatalogServiceRequest
+	}
+	catalogServiceAddReturns struct {
+		result1 error
+	}
+	catalogServiceAddReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CatalogServiceDeleteStub        func(string) error
+	catalogServiceDeleteMutex       sync.RWMutex
+	catalogServiceDeleteArgsForCall []struct {
+		arg1 string
+	}
+	catalogServiceDeleteReturns struct {
+		result1 error
+	}
+	catalogServiceDeleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CatalogServiceUpdateStub        func(models.CatalogServiceRequest, string) error
+	catalogServiceUpdateMutex       sync.RWMutex
+	catalogServiceUpdateArgsForCall []struct {
+		arg1 models.CatalogServiceRequest
+		arg2 string
+	}
+	catalogServiceUpdateReturns struct {
+		result1 error
+	}
+	catalogServiceUpdateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ChartListStub        func() ([]models.AppChart, error)
 	chartListMutex       sync.RWMutex
 	chartListArgsForCall []struct {
@@ -435,7 +569,7 @@ type FakeAPIClient struct {
 		result1 models.ConfigurationResponse
 		result2 error
 	}
-	ConfigurationUpdateStub        func(models.ConfigurationUpdateRequest, string, string) (models.Response, error)
+	ConfigurationUpdateStub        func(models.ConfigurationUpdateRequest, string, string) (models.ConfigurationUpdateResponse, error)
 	configurationUpdateMutex       sync.RWMutex
 	configurationUpdateArgsForCall []struct {
 		arg1 models.ConfigurationUpdateRequest
@@ -443,11 +577,11 @@ type FakeAPIClient struct {
 		arg3 string
 	}
 	configurationUpdateReturns struct {
-		result1 models.Response
+		result1 models.ConfigurationUpdateResponse
 		result2 error
 	}
 	configurationUpdateReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.ConfigurationUpdateResponse
 		result2 error
 	}
 	ConfigurationsStub        func(string) (models.ConfigurationResponseList, error)
@@ -541,6 +675,19 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	ImageGCStub        func(models.ImageGCRequest) (models.ImageGCResponse, error)
+	imageGCMutex       sync.RWMutex
+	imageGCArgsForCall []struct {
+		arg1 models.ImageGCRequest
+	}
+	imageGCReturns struct {
+		result1 models.ImageGCResponse
+		result2 error
+	}
+	imageGCReturnsOnCall map[int]struct {
+		result1 models.ImageGCResponse
+		result2 error
+	}
 	InfoStub        func() (models.InfoResponse, error)
 	infoMutex       sync.RWMutex
 	infoArgsForCall []struct {
@@ -579,6 +726,60 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	NamespaceEventsStub        func(string, func(models.Event)) error
+	namespaceEventsMutex       sync.RWMutex
+	namespaceEventsArgsForCall []struct {
+		arg1 string
+		arg2 func(models.Event)
+	}
+	namespaceEventsReturns struct {
+		result1 error
+	}
+	namespaceEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NamespaceMemberAddStub        func(models.NamespaceMemberRequest, string) (models.Response, error)
+	namespaceMemberAddMutex       sync.RWMutex
+	namespaceMemberAddArgsForCall []struct {
+		arg1 models.NamespaceMemberRequest
+		arg2 string
+	}
+	namespaceMemberAddReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceMemberAddReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceMemberRemoveStub        func(string, string) (models.Response, error)
+	namespaceMemberRemoveMutex       sync.RWMutex
+	namespaceMemberRemoveArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	namespaceMemberRemoveReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceMemberRemoveReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceQuotaUpdateStub        func(models.NamespaceQuota, string) (models.Response, error)
+	namespaceQuotaUpdateMutex       sync.RWMutex
+	namespaceQuotaUpdateArgsForCall []struct {
+		arg1 models.NamespaceQuota
+		arg2 string
+	}
+	namespaceQuotaUpdateReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceQuotaUpdateReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
 	NamespaceShowStub        func(string) (models.Namespace, error)
 	namespaceShowMutex       sync.RWMutex
 	namespaceShowArgsForCall []struct {
@@ -617,6 +818,88 @@ type FakeAPIClient struct {
 		result1 models.NamespacesMatchResponse
 		result2 error
 	}
+	RegistryLoginStub        func(string, models.RegistryLoginRequest) (models.Response, error)
+	registryLoginMutex       sync.RWMutex
+	registryLoginArgsForCall []struct {
+		arg1 string
+		arg2 models.RegistryLoginRequest
+	}
+	registryLoginReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	registryLoginReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	RegistryLoginsStub        func(string) (models.RegistryLoginList, error)
+	registryLoginsMutex       sync.RWMutex
+	registryLoginsArgsForCall []struct {
+		arg1 string
+	}
+	registryLoginsReturns struct {
+		result1 models.RegistryLoginList
+		result2 error
+	}
+	registryLoginsReturnsOnCall map[int]struct {
+		result1 models.RegistryLoginList
+		result2 error
+	}
+	RegistryLogoutStub        func(string, string) (models.Response, error)
+	registryLogoutMutex       sync.RWMutex
+	registryLogoutArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	registryLogoutReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	registryLogoutReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	ServiceBackupStub        func(string, string) (models.ServiceBackup, error)
+	serviceBackupMutex       sync.RWMutex
+	serviceBackupArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceBackupReturns struct {
+		result1 models.ServiceBackup
+		result2 error
+	}
+	serviceBackupReturnsOnCall map[int]struct {
+		result1 models.ServiceBackup
+		result2 error
+	}
+	ServiceBackupScheduleStub        func(models.ServiceBackupScheduleRequest, string, string) error
+	serviceBackupScheduleMutex       sync.RWMutex
+	serviceBackupScheduleArgsForCall []struct {
+		arg1 models.ServiceBackupScheduleRequest
+		arg2 string
+		arg3 string
+	}
+	serviceBackupScheduleReturns struct {
+		result1 error
+	}
+	serviceBackupScheduleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceBackupsStub        func(string, string) (models.ServiceBackupList, error)
+	serviceBackupsMutex       sync.RWMutex
+	serviceBackupsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceBackupsReturns struct {
+		result1 models.ServiceBackupList
+		result2 error
+	}
+	serviceBackupsReturnsOnCall map[int]struct {
+		result1 models.ServiceBackupList
+		result2 error
+	}
 	ServiceBindStub        func(*models.ServiceBindRequest, string, string) error
 	serviceBindMutex       sync.RWMutex
 	serviceBindArgsForCall []struct {
@@ -723,6 +1006,73 @@ type FakeAPIClient struct {
 		result1 models.ServiceMatchResponse
 		result2 error
 	}
+	ServicePortForwardStub        func(string, string, *client.PortForwardOpts) error
+	servicePortForwardMutex       sync.RWMutex
+	servicePortForwardArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *client.PortForwardOpts
+	}
+	servicePortForwardReturns struct {
+		result1 error
+	}
+	servicePortForwardReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceReadyStub        func(string, string) error
+	serviceReadyMutex       sync.RWMutex
+	serviceReadyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceReadyReturns struct {
+		result1 error
+	}
+	serviceReadyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceRestoreStub        func(string, string, string) error
+	serviceRestoreMutex       sync.RWMutex
+	serviceRestoreArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	serviceRestoreReturns struct {
+		result1 error
+	}
+	serviceRestoreReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceRotateCredentialsStub        func(string, string) (models.ServiceRotateCredentialsResponse, error)
+	serviceRotateCredentialsMutex       sync.RWMutex
+	serviceRotateCredentialsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceRotateCredentialsReturns struct {
+		result1 models.ServiceRotateCredentialsResponse
+		result2 error
+	}
+	serviceRotateCredentialsReturnsOnCall map[int]struct {
+		result1 models.ServiceRotateCredentialsResponse
+		result2 error
+	}
+	ServiceShareStub        func(models.ServiceShareRequest, string, string) (models.ServiceShareResponse, error)
+	serviceShareMutex       sync.RWMutex
+	serviceShareArgsForCall []struct {
+		arg1 models.ServiceShareRequest
+		arg2 string
+		arg3 string
+	}
+	serviceShareReturns struct {
+		result1 models.ServiceShareResponse
+		result2 error
+	}
+	serviceShareReturnsOnCall map[int]struct {
+		result1 models.ServiceShareResponse
+		result2 error
+	}
 	ServiceShowStub        func(*models.ServiceShowRequest, string) (*models.Service, error)
 	serviceShowMutex       sync.RWMutex
 	serviceShowArgsForCall []struct {
@@ -750,6 +1100,36 @@ type FakeAPIClient struct {
 	serviceUnbindReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ServiceUnshareStub        func(string, string, string) (models.ServiceUnshareResponse, error)
+	serviceUnshareMutex       sync.RWMutex
+	serviceUnshareArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	serviceUnshareReturns struct {
+		result1 models.ServiceUnshareResponse
+		result2 error
+	}
+	serviceUnshareReturnsOnCall map[int]struct {
+		result1 models.ServiceUnshareResponse
+		result2 error
+	}
+	ServiceUpdateStub        func(models.ServiceUpdateRequest, string, string) (models.ServiceUpdateResponse, error)
+	serviceUpdateMutex       sync.RWMutex
+	serviceUpdateArgsForCall []struct {
+		arg1 models.ServiceUpdateRequest
+		arg2 string
+		arg3 string
+	}
+	serviceUpdateReturns struct {
+		result1 models.ServiceUpdateResponse
+		result2 error
+	}
+	serviceUpdateReturnsOnCall map[int]struct {
+		result1 models.ServiceUpdateResponse
+		result2 error
+	}
 	StagingCompleteStub        func(string, string) (models.Response, error)
 	stagingCompleteMutex       sync.RWMutex
 	stagingCompleteArgsForCall []struct {
@@ -1011,6 +1391,71 @@ func (fake *FakeAPIClient) AppCreateReturnsOnCall(i int, result1 models.Response
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppDebug(arg1 string, arg2 string, arg3 string, arg4 string, arg5 term.TTY) error {
+	fake.appDebugMutex.Lock()
+	ret, specificReturn := fake.appDebugReturnsOnCall[len(fake.appDebugArgsForCall)]
+	fake.appDebugArgsForCall = append(fake.appDebugArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 term.TTY
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.AppDebugStub
+	fakeReturns := fake.appDebugReturns
+	fake.recordInvocation("AppDebug", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.appDebugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) AppDebugCallCount() int {
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
+	return len(fake.appDebugArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppDebugCalls(stub func(string, string, string, string, term.TTY) error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = stub
+}
+
+func (fake *FakeAPIClient) AppDebugArgsForCall(i int) (string, string, string, string, term.TTY) {
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
+	argsForCall := fake.appDebugArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeAPIClient) AppDebugReturns(result1 error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = nil
+	fake.appDebugReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) AppDebugReturnsOnCall(i int, result1 error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = nil
+	if fake.appDebugReturnsOnCall == nil {
+		fake.appDebugReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appDebugReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAPIClient) AppDelete(arg1 string, arg2 []string) (models.ApplicationDeleteResponse, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1209,19 +1654,19 @@ func (fake *FakeAPIClient) AppExecReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeAPIClient) AppGetPart(arg1 string, arg2 string, arg3 string, arg4 string) error {
-	fake.appGetPartMutex.Lock()
-	ret, specificReturn := fake.appGetPartReturnsOnCall[len(fake.appGetPartArgsForCall)]
-	fake.appGetPartArgsForCall = append(fake.appGetPartArgsForCall, struct {
+func (fake *FakeAPIClient) AppExecCommand(arg1 string, arg2 string, arg3 string, arg4 client.ExecOpts) error {
+	fake.appExecCommandMutex.Lock()
+	ret, specificReturn := fake.appExecCommandReturnsOnCall[len(fake.appExecCommandArgsForCall)]
+	fake.appExecCommandArgsForCall = append(fake.appExecCommandArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-		arg4 string
+		arg4 client.ExecOpts
 	}{arg1, arg2, arg3, arg4})
-	stub := fake.AppGetPartStub
-	fakeReturns := fake.appGetPartReturns
-	fake.recordInvocation("AppGetPart", []interface{}{arg1, arg2, arg3, arg4})
-	fake.appGetPartMutex.Unlock()
+	stub := fake.AppExecCommandStub
+	fakeReturns := fake.appExecCommandReturns
+	fake.recordInvocation("AppExecCommand", []interface{}{arg1, arg2, arg3, arg4})
+	fake.appExecCommandMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3, arg4)
 	}
@@ -1231,15 +1676,79 @@ func (fake *FakeAPIClient) AppGetPart(arg1 string, arg2 string, arg3 string, arg
 	return fakeReturns.result1
 }
 
-func (fake *FakeAPIClient) AppGetPartCallCount() int {
-	fake.appGetPartMutex.RLock()
-	defer fake.appGetPartMutex.RUnlock()
-	return len(fake.appGetPartArgsForCall)
+func (fake *FakeAPIClient) AppExecCommandCallCount() int {
+	fake.appExecCommandMutex.RLock()
+	defer fake.appExecCommandMutex.RUnlock()
+	return len(fake.appExecCommandArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppGetPartCalls(stub func(string, string, string, string) error) {
-	fake.appGetPartMutex.Lock()
-	defer fake.appGetPartMutex.Unlock()
+func (fake *FakeAPIClient) AppExecCommandCalls(stub func(string, string, string, client.ExecOpts) error) {
+	fake.appExecCommandMutex.Lock()
+	defer fake.appExecCommandMutex.Unlock()
+	fake.AppExecCommandStub = stub
+}
+
+func (fake *FakeAPIClient) AppExecCommandArgsForCall(i int) (string, string, string, client.ExecOpts) {
+	fake.appExecCommandMutex.RLock()
+	defer fake.appExecCommandMutex.RUnlock()
+	argsForCall := fake.appExecCommandArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeAPIClient) AppExecCommandReturns(result1 error) {
+	fake.appExecCommandMutex.Lock()
+	defer fake.appExecCommandMutex.Unlock()
+	fake.AppExecCommandStub = nil
+	fake.appExecCommandReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) AppExecCommandReturnsOnCall(i int, result1 error) {
+	fake.appExecCommandMutex.Lock()
+	defer fake.appExecCommandMutex.Unlock()
+	fake.AppExecCommandStub = nil
+	if fake.appExecCommandReturnsOnCall == nil {
+		fake.appExecCommandReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appExecCommandReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) AppGetPart(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.appGetPartMutex.Lock()
+	ret, specificReturn := fake.appGetPartReturnsOnCall[len(fake.appGetPartArgsForCall)]
+	fake.appGetPartArgsForCall = append(fake.appGetPartArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AppGetPartStub
+	fakeReturns := fake.appGetPartReturns
+	fake.recordInvocation("AppGetPart", []interface{}{arg1, arg2, arg3, arg4})
+	fake.appGetPartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) AppGetPartCallCount() int {
+	fake.appGetPartMutex.RLock()
+	defer fake.appGetPartMutex.RUnlock()
+	return len(fake.appGetPartArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppGetPartCalls(stub func(string, string, string, string) error) {
+	fake.appGetPartMutex.Lock()
+	defer fake.appGetPartMutex.Unlock()
 	fake.AppGetPartStub = stub
 }
 
@@ -1984,6 +2493,71 @@ func (fake *FakeAPIClient) AppValidateCVReturnsOnCall(i int, result1 models.Resp
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppWebhook(arg1 string, arg2 string) (models.AppWebhookResponse, error) {
+	fake.appWebhookMutex.Lock()
+	ret, specificReturn := fake.appWebhookReturnsOnCall[len(fake.appWebhookArgsForCall)]
+	fake.appWebhookArgsForCall = append(fake.appWebhookArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppWebhookStub
+	fakeReturns := fake.appWebhookReturns
+	fake.recordInvocation("AppWebhook", []interface{}{arg1, arg2})
+	fake.appWebhookMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppWebhookCallCount() int {
+	fake.appWebhookMutex.RLock()
+	defer fake.appWebhookMutex.RUnlock()
+	return len(fake.appWebhookArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppWebhookCalls(stub func(string, string) (models.AppWebhookResponse, error)) {
+	fake.appWebhookMutex.Lock()
+	defer fake.appWebhookMutex.Unlock()
+	fake.AppWebhookStub = stub
+}
+
+func (fake *FakeAPIClient) AppWebhookArgsForCall(i int) (string, string) {
+	fake.appWebhookMutex.RLock()
+	defer fake.appWebhookMutex.RUnlock()
+	argsForCall := fake.appWebhookArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppWebhookReturns(result1 models.AppWebhookResponse, result2 error) {
+	fake.appWebhookMutex.Lock()
+	defer fake.appWebhookMutex.Unlock()
+	fake.AppWebhookStub = nil
+	fake.appWebhookReturns = struct {
+		result1 models.AppWebhookResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppWebhookReturnsOnCall(i int, result1 models.AppWebhookResponse, result2 error) {
+	fake.appWebhookMutex.Lock()
+	defer fake.appWebhookMutex.Unlock()
+	fake.AppWebhookStub = nil
+	if fake.appWebhookReturnsOnCall == nil {
+		fake.appWebhookReturnsOnCall = make(map[int]struct {
+			result1 models.AppWebhookResponse
+			result2 error
+		})
+	}
+	fake.appWebhookReturnsOnCall[i] = struct {
+		result1 models.AppWebhookResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) Apps(arg1 string) (models.AppList, error) {
 	fake.appsMutex.Lock()
 	ret, specificReturn := fake.appsReturnsOnCall[len(fake.appsArgsForCall)]
@@ -2104,17 +2678,18 @@ func (fake *FakeAPIClient) AuthTokenReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartList() ([]models.AppChart, error) {
-	fake.chartListMutex.Lock()
-	ret, specificReturn := fake.chartListReturnsOnCall[len(fake.chartListArgsForCall)]
-	fake.chartListArgsForCall = append(fake.chartListArgsForCall, struct {
-	}{})
-	stub := fake.ChartListStub
-	fakeReturns := fake.chartListReturns
-	fake.recordInvocation("ChartList", []interface{}{})
-	fake.chartListMutex.Unlock()
+func (fake *FakeAPIClient) BlobPrune(arg1 models.BlobPruneRequest) (models.BlobPruneResponse, error) {
+	fake.blobPruneMutex.Lock()
+	ret, specificReturn := fake.blobPruneReturnsOnCall[len(fake.blobPruneArgsForCall)]
+	fake.blobPruneArgsForCall = append(fake.blobPruneArgsForCall, struct {
+		arg1 models.BlobPruneRequest
+	}{arg1})
+	stub := fake.BlobPruneStub
+	fakeReturns := fake.blobPruneReturns
+	fake.recordInvocation("BlobPrune", []interface{}{arg1})
+	fake.blobPruneMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2122,56 +2697,64 @@ func (fake *FakeAPIClient) ChartList() ([]models.AppChart, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ChartListCallCount() int {
-	fake.chartListMutex.RLock()
-	defer fake.chartListMutex.RUnlock()
-	return len(fake.chartListArgsForCall)
+func (fake *FakeAPIClient) BlobPruneCallCount() int {
+	fake.blobPruneMutex.RLock()
+	defer fake.blobPruneMutex.RUnlock()
+	return len(fake.blobPruneArgsForCall)
 }
 
-func (fake *FakeAPIClient) ChartListCalls(stub func() ([]models.AppChart, error)) {
-	fake.chartListMutex.Lock()
-	defer fake.chartListMutex.Unlock()
-	fake.ChartListStub = stub
+func (fake *FakeAPIClient) BlobPruneCalls(stub func(models.BlobPruneRequest) (models.BlobPruneResponse, error)) {
+	fake.blobPruneMutex.Lock()
+	defer fake.blobPruneMutex.Unlock()
+	fake.BlobPruneStub = stub
 }
 
-func (fake *FakeAPIClient) ChartListReturns(result1 []models.AppChart, result2 error) {
-	fake.chartListMutex.Lock()
-	defer fake.chartListMutex.Unlock()
-	fake.ChartListStub = nil
-	fake.chartListReturns = struct {
-		result1 []models.AppChart
+func (fake *FakeAPIClient) BlobPruneArgsForCall(i int) models.BlobPruneRequest {
+	fake.blobPruneMutex.RLock()
+	defer fake.blobPruneMutex.RUnlock()
+	argsForCall := fake.blobPruneArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) BlobPruneReturns(result1 models.BlobPruneResponse, result2 error) {
+	fake.blobPruneMutex.Lock()
+	defer fake.blobPruneMutex.Unlock()
+	fake.BlobPruneStub = nil
+	fake.blobPruneReturns = struct {
+		result1 models.BlobPruneResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartListReturnsOnCall(i int, result1 []models.AppChart, result2 error) {
-	fake.chartListMutex.Lock()
-	defer fake.chartListMutex.Unlock()
-	fake.ChartListStub = nil
-	if fake.chartListReturnsOnCall == nil {
-		fake.chartListReturnsOnCall = make(map[int]struct {
-			result1 []models.AppChart
+func (fake *FakeAPIClient) BlobPruneReturnsOnCall(i int, result1 models.BlobPruneResponse, result2 error) {
+	fake.blobPruneMutex.Lock()
+	defer fake.blobPruneMutex.Unlock()
+	fake.BlobPruneStub = nil
+	if fake.blobPruneReturnsOnCall == nil {
+		fake.blobPruneReturnsOnCall = make(map[int]struct {
+			result1 models.BlobPruneResponse
 			result2 error
 		})
 	}
-	fake.chartListReturnsOnCall[i] = struct {
-		result1 []models.AppChart
+	fake.blobPruneReturnsOnCall[i] = struct {
+		result1 models.BlobPruneResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartMatch(arg1 string) (models.ChartMatchResponse, error) {
-	fake.chartMatchMutex.Lock()
-	ret, specificReturn := fake.chartMatchReturnsOnCall[len(fake.chartMatchArgsForCall)]
-	fake.chartMatchArgsForCall = append(fake.chartMatchArgsForCall, struct {
+func (fake *FakeAPIClient) BuildEnvList(arg1 string, arg2 string) (models.EnvVariableMap, error) {
+	fake.buildEnvListMutex.Lock()
+	ret, specificReturn := fake.buildEnvListReturnsOnCall[len(fake.buildEnvListArgsForCall)]
+	fake.buildEnvListArgsForCall = append(fake.buildEnvListArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	stub := fake.ChartMatchStub
-	fakeReturns := fake.chartMatchReturns
-	fake.recordInvocation("ChartMatch", []interface{}{arg1})
-	fake.chartMatchMutex.Unlock()
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.BuildEnvListStub
+	fakeReturns := fake.buildEnvListReturns
+	fake.recordInvocation("BuildEnvList", []interface{}{arg1, arg2})
+	fake.buildEnvListMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2179,63 +2762,65 @@ func (fake *FakeAPIClient) ChartMatch(arg1 string) (models.ChartMatchResponse, e
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ChartMatchCallCount() int {
-	fake.chartMatchMutex.RLock()
-	defer fake.chartMatchMutex.RUnlock()
-	return len(fake.chartMatchArgsForCall)
+func (fake *FakeAPIClient) BuildEnvListCallCount() int {
+	fake.buildEnvListMutex.RLock()
+	defer fake.buildEnvListMutex.RUnlock()
+	return len(fake.buildEnvListArgsForCall)
 }
 
-func (fake *FakeAPIClient) ChartMatchCalls(stub func(string) (models.ChartMatchResponse, error)) {
-	fake.chartMatchMutex.Lock()
-	defer fake.chartMatchMutex.Unlock()
-	fake.ChartMatchStub = stub
+func (fake *FakeAPIClient) BuildEnvListCalls(stub func(string, string) (models.EnvVariableMap, error)) {
+	fake.buildEnvListMutex.Lock()
+	defer fake.buildEnvListMutex.Unlock()
+	fake.BuildEnvListStub = stub
 }
 
-func (fake *FakeAPIClient) ChartMatchArgsForCall(i int) string {
-	fake.chartMatchMutex.RLock()
-	defer fake.chartMatchMutex.RUnlock()
-	argsForCall := fake.chartMatchArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) BuildEnvListArgsForCall(i int) (string, string) {
+	fake.buildEnvListMutex.RLock()
+	defer fake.buildEnvListMutex.RUnlock()
+	argsForCall := fake.buildEnvListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) ChartMatchReturns(result1 models.ChartMatchResponse, result2 error) {
-	fake.chartMatchMutex.Lock()
-	defer fake.chartMatchMutex.Unlock()
-	fake.ChartMatchStub = nil
-	fake.chartMatchReturns = struct {
-		result1 models.ChartMatchResponse
+func (fake *FakeAPIClient) BuildEnvListReturns(result1 models.EnvVariableMap, result2 error) {
+	fake.buildEnvListMutex.Lock()
+	defer fake.buildEnvListMutex.Unlock()
+	fake.BuildEnvListStub = nil
+	fake.buildEnvListReturns = struct {
+		result1 models.EnvVariableMap
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartMatchReturnsOnCall(i int, result1 models.ChartMatchResponse, result2 error) {
-	fake.chartMatchMutex.Lock()
-	defer fake.chartMatchMutex.Unlock()
-	fake.ChartMatchStub = nil
-	if fake.chartMatchReturnsOnCall == nil {
-		fake.chartMatchReturnsOnCall = make(map[int]struct {
-			result1 models.ChartMatchResponse
+func (fake *FakeAPIClient) BuildEnvListReturnsOnCall(i int, result1 models.EnvVariableMap, result2 error) {
+	fake.buildEnvListMutex.Lock()
+	defer fake.buildEnvListMutex.Unlock()
+	fake.BuildEnvListStub = nil
+	if fake.buildEnvListReturnsOnCall == nil {
+		fake.buildEnvListReturnsOnCall = make(map[int]struct {
+			result1 models.EnvVariableMap
 			result2 error
 		})
 	}
-	fake.chartMatchReturnsOnCall[i] = struct {
-		result1 models.ChartMatchResponse
+	fake.buildEnvListReturnsOnCall[i] = struct {
+		result1 models.EnvVariableMap
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartShow(arg1 string) (models.AppChart, error) {
-	fake.chartShowMutex.Lock()
-	ret, specificReturn := fake.chartShowReturnsOnCall[len(fake.chartShowArgsForCall)]
-	fake.chartShowArgsForCall = append(fake.chartShowArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	stub := fake.ChartShowStub
-	fakeReturns := fake.chartShowReturns
-	fake.recordInvocation("ChartShow", []interface{}{arg1})
-	fake.chartShowMutex.Unlock()
+func (fake *FakeAPIClient) BuildEnvSet(arg1 models.EnvVariableMap, arg2 string, arg3 string) (models.Response, error) {
+	fake.buildEnvSetMutex.Lock()
+	ret, specificReturn := fake.buildEnvSetReturnsOnCall[len(fake.buildEnvSetArgsForCall)]
+	fake.buildEnvSetArgsForCall = append(fake.buildEnvSetArgsForCall, struct {
+		arg1 models.EnvVariableMap
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.BuildEnvSetStub
+	fakeReturns := fake.buildEnvSetReturns
+	fake.recordInvocation("BuildEnvSet", []interface{}{arg1, arg2, arg3})
+	fake.buildEnvSetMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2243,63 +2828,65 @@ func (fake *FakeAPIClient) ChartShow(arg1 string) (models.AppChart, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ChartShowCallCount() int {
-	fake.chartShowMutex.RLock()
-	defer fake.chartShowMutex.RUnlock()
-	return len(fake.chartShowArgsForCall)
+func (fake *FakeAPIClient) BuildEnvSetCallCount() int {
+	fake.buildEnvSetMutex.RLock()
+	defer fake.buildEnvSetMutex.RUnlock()
+	return len(fake.buildEnvSetArgsForCall)
 }
 
-func (fake *FakeAPIClient) ChartShowCalls(stub func(string) (models.AppChart, error)) {
-	fake.chartShowMutex.Lock()
-	defer fake.chartShowMutex.Unlock()
-	fake.ChartShowStub = stub
+func (fake *FakeAPIClient) BuildEnvSetCalls(stub func(models.EnvVariableMap, string, string) (models.Response, error)) {
+	fake.buildEnvSetMutex.Lock()
+	defer fake.buildEnvSetMutex.Unlock()
+	fake.BuildEnvSetStub = stub
 }
 
-func (fake *FakeAPIClient) ChartShowArgsForCall(i int) string {
-	fake.chartShowMutex.RLock()
-	defer fake.chartShowMutex.RUnlock()
-	argsForCall := fake.chartShowArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) BuildEnvSetArgsForCall(i int) (models.EnvVariableMap, string, string) {
+	fake.buildEnvSetMutex.RLock()
+	defer fake.buildEnvSetMutex.RUnlock()
+	argsForCall := fake.buildEnvSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) ChartShowReturns(result1 models.AppChart, result2 error) {
-	fake.chartShowMutex.Lock()
-	defer fake.chartShowMutex.Unlock()
-	fake.ChartShowStub = nil
-	fake.chartShowReturns = struct {
-		result1 models.AppChart
+func (fake *FakeAPIClient) BuildEnvSetReturns(result1 models.Response, result2 error) {
+	fake.buildEnvSetMutex.Lock()
+	defer fake.buildEnvSetMutex.Unlock()
+	fake.BuildEnvSetStub = nil
+	fake.buildEnvSetReturns = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartShowReturnsOnCall(i int, result1 models.AppChart, result2 error) {
-	fake.chartShowMutex.Lock()
-	defer fake.chartShowMutex.Unlock()
-	fake.ChartShowStub = nil
-	if fake.chartShowReturnsOnCall == nil {
-		fake.chartShowReturnsOnCall = make(map[int]struct {
-			result1 models.AppChart
+func (fake *FakeAPIClient) BuildEnvSetReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.buildEnvSetMutex.Lock()
+	defer fake.buildEnvSetMutex.Unlock()
+	fake.BuildEnvSetStub = nil
+	if fake.buildEnvSetReturnsOnCall == nil {
+		fake.buildEnvSetReturnsOnCall = make(map[int]struct {
+			result1 models.Response
 			result2 error
 		})
 	}
-	fake.chartShowReturnsOnCall[i] = struct {
-		result1 models.AppChart
+	fake.buildEnvSetReturnsOnCall[i] = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationApps(arg1 string) (models.ConfigurationAppsResponse, error) {
-	fake.configurationAppsMutex.Lock()
-	ret, specificReturn := fake.configurationAppsReturnsOnCall[len(fake.configurationAppsArgsForCall)]
-	fake.configurationAppsArgsForCall = append(fake.configurationAppsArgsForCall, struct {
+func (fake *FakeAPIClient) BuildEnvUnset(arg1 string, arg2 string, arg3 string) (models.Response, error) {
+	fake.buildEnvUnsetMutex.Lock()
+	ret, specificReturn := fake.buildEnvUnsetReturnsOnCall[len(fake.buildEnvUnsetArgsForCall)]
+	fake.buildEnvUnsetArgsForCall = append(fake.buildEnvUnsetArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	stub := fake.ConfigurationAppsStub
-	fakeReturns := fake.configurationAppsReturns
-	fake.recordInvocation("ConfigurationApps", []interface{}{arg1})
-	fake.configurationAppsMutex.Unlock()
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.BuildEnvUnsetStub
+	fakeReturns := fake.buildEnvUnsetReturns
+	fake.recordInvocation("BuildEnvUnset", []interface{}{arg1, arg2, arg3})
+	fake.buildEnvUnsetMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2307,53 +2894,485 @@ func (fake *FakeAPIClient) ConfigurationApps(arg1 string) (models.ConfigurationA
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ConfigurationAppsCallCount() int {
-	fake.configurationAppsMutex.RLock()
-	defer fake.configurationAppsMutex.RUnlock()
-	return len(fake.configurationAppsArgsForCall)
+func (fake *FakeAPIClient) BuildEnvUnsetCallCount() int {
+	fake.buildEnvUnsetMutex.RLock()
+	defer fake.buildEnvUnsetMutex.RUnlock()
+	return len(fake.buildEnvUnsetArgsForCall)
 }
 
-func (fake *FakeAPIClient) ConfigurationAppsCalls(stub func(string) (models.ConfigurationAppsResponse, error)) {
-	fake.configurationAppsMutex.Lock()
-	defer fake.configurationAppsMutex.Unlock()
-	fake.ConfigurationAppsStub = stub
+func (fake *FakeAPIClient) BuildEnvUnsetCalls(stub func(string, string, string) (models.Response, error)) {
+	fake.buildEnvUnsetMutex.Lock()
+	defer fake.buildEnvUnsetMutex.Unlock()
+	fake.BuildEnvUnsetStub = stub
 }
 
-func (fake *FakeAPIClient) ConfigurationAppsArgsForCall(i int) string {
-	fake.configurationAppsMutex.RLock()
-	defer fake.configurationAppsMutex.RUnlock()
-	argsForCall := fake.configurationAppsArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) BuildEnvUnsetArgsForCall(i int) (string, string, string) {
+	fake.buildEnvUnsetMutex.RLock()
+	defer fake.buildEnvUnsetMutex.RUnlock()
+	argsForCall := fake.buildEnvUnsetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) ConfigurationAppsReturns(result1 models.ConfigurationAppsResponse, result2 error) {
-	fake.configurationAppsMutex.Lock()
-	defer fake.configurationAppsMutex.Unlock()
-	fake.ConfigurationAppsStub = nil
-	fake.configurationAppsReturns = struct {
-		result1 models.ConfigurationAppsResponse
+func (fake *FakeAPIClient) BuildEnvUnsetReturns(result1 models.Response, result2 error) {
+	fake.buildEnvUnsetMutex.Lock()
+	defer fake.buildEnvUnsetMutex.Unlock()
+	fake.BuildEnvUnsetStub = nil
+	fake.buildEnvUnsetReturns = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationAppsReturnsOnCall(i int, result1 models.ConfigurationAppsResponse, result2 error) {
-	fake.configurationAppsMutex.Lock()
-	defer fake.configurationAppsMutex.Unlock()
-	fake.ConfigurationAppsStub = nil
-	if fake.configurationAppsReturnsOnCall == nil {
-		fake.configurationAppsReturnsOnCall = make(map[int]struct {
-			result1 models.ConfigurationAppsResponse
+func (fake *FakeAPIClient) BuildEnvUnsetReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.buildEnvUnsetMutex.Lock()
+	defer fake.buildEnvUnsetMutex.Unlock()
+	fake.BuildEnvUnsetStub = nil
+	if fake.buildEnvUnsetReturnsOnCall == nil {
+		fake.buildEnvUnsetReturnsOnCall = make(map[int]struct {
+			result1 models.Response
 			result2 error
 		})
 	}
-	fake.configurationAppsReturnsOnCall[i] = struct {
-		result1 models.ConfigurationAppsResponse
+	fake.buildEnvUnsetReturnsOnCall[i] = struct {
+		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationBindingCreate(arg1 models.BindRequest, arg2 string, arg3 string) (models.BindResponse, error) {
-	fake.configurationBindingCreateMutex.Lock()
+func (fake *FakeAPIClient) CatalogServiceAdd(arg1 models.CatalogServiceRequest) error {
+	fake.catalogServiceAddMutex.Lock()
+	ret, specificReturn := fake.catalogServiceAddReturnsOnCall[len(fake.catalogServiceAddArgsForCall)]
+	fake.catalogServiceAddArgsForCall = append(fake.catalogServiceAddArgsForCall, struct {
+		arg1 models.CatalogServiceRequest
+	}{arg1})
+	stub := fake.CatalogServiceAddStub
+	fakeReturns := fake.catalogServiceAddReturns
+	fake.recordInvocation("CatalogServiceAdd", []interface{}{arg1})
+	fake.catalogServiceAddMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) CatalogServiceAddCallCount() int {
+	fake.catalogServiceAddMutex.RLock()
+	defer fake.catalogServiceAddMutex.RUnlock()
+	return len(fake.catalogServiceAddArgsForCall)
+}
+
+func (fake *FakeAPIClient) CatalogServiceAddCalls(stub func(models.CatalogServiceRequest) error) {
+	fake.catalogServiceAddMutex.Lock()
+	defer fake.catalogServiceAddMutex.Unlock()
+	fake.CatalogServiceAddStub = stub
+}
+
+func (fake *FakeAPIClient) CatalogServiceAddArgsForCall(i int) models.CatalogServiceRequest {
+	fake.catalogServiceAddMutex.RLock()
+	defer fake.catalogServiceAddMutex.RUnlock()
+	argsForCall := fake.catalogServiceAddArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) CatalogServiceAddReturns(result1 error) {
+	fake.catalogServiceAddMutex.Lock()
+	defer fake.catalogServiceAddMutex.Unlock()
+	fake.CatalogServiceAddStub = nil
+	fake.catalogServiceAddReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) CatalogServiceAddReturnsOnCall(i int, result1 error) {
+	fake.catalogServiceAddMutex.Lock()
+	defer fake.catalogServiceAddMutex.Unlock()
+	fake.CatalogServiceAddStub = nil
+	if fake.catalogServiceAddReturnsOnCall == nil {
+		fake.catalogServiceAddReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.catalogServiceAddReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) CatalogServiceDelete(arg1 string) error {
+	fake.catalogServiceDeleteMutex.Lock()
+	ret, specificReturn := fake.catalogServiceDeleteReturnsOnCall[len(fake.catalogServiceDeleteArgsForCall)]
+	fake.catalogServiceDeleteArgsForCall = append(fake.catalogServiceDeleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CatalogServiceDeleteStub
+	fakeReturns := fake.catalogServiceDeleteReturns
+	fake.recordInvocation("CatalogServiceDelete", []interface{}{arg1})
+	fake.catalogServiceDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) CatalogServiceDeleteCallCount() int {
+	fake.catalogServiceDeleteMutex.RLock()
+	defer fake.catalogServiceDeleteMutex.RUnlock()
+	return len(fake.catalogServiceDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) CatalogServiceDeleteCalls(stub func(string) error) {
+	fake.catalogServiceDeleteMutex.Lock()
+	defer fake.catalogServiceDeleteMutex.Unlock()
+	fake.CatalogServiceDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) CatalogServiceDeleteArgsForCall(i int) string {
+	fake.catalogServiceDeleteMutex.RLock()
+	defer fake.catalogServiceDeleteMutex.RUnlock()
+	argsForCall := fake.catalogServiceDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) CatalogServiceDeleteReturns(result1 error) {
+	fake.catalogServiceDeleteMutex.Lock()
+	defer fake.catalogServiceDeleteMutex.Unlock()
+	fake.CatalogServiceDeleteStub = nil
+	fake.catalogServiceDeleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) CatalogServiceDeleteReturnsOnCall(i int, result1 error) {
+	fake.catalogServiceDeleteMutex.Lock()
+	defer fake.catalogServiceDeleteMutex.Unlock()
+	fake.CatalogServiceDeleteStub = nil
+	if fake.catalogServiceDeleteReturnsOnCall == nil {
+		fake.catalogServiceDeleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.catalogServiceDeleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) CatalogServiceUpdate(arg1 models.CatalogServiceRequest, arg2 string) error {
+	fake.catalogServiceUpdateMutex.Lock()
+	ret, specificReturn := fake.catalogServiceUpdateReturnsOnCall[len(fake.catalogServiceUpdateArgsForCall)]
+	fake.catalogServiceUpdateArgsForCall = append(fake.catalogServiceUpdateArgsForCall, struct {
+		arg1 models.CatalogServiceRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CatalogServiceUpdateStub
+	fakeReturns := fake.catalogServiceUpdateReturns
+	fake.recordInvocation("CatalogServiceUpdate", []interface{}{arg1, arg2})
+	fake.catalogServiceUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) CatalogServiceUpdateCallCount() int {
+	fake.catalogServiceUpdateMutex.RLock()
+	defer fake.catalogServiceUpdateMutex.RUnlock()
+	return len(fake.catalogServiceUpdateArgsForCall)
+}
+
+func (fake *FakeAPIClient) CatalogServiceUpdateCalls(stub func(models.CatalogServiceRequest, string) error) {
+	fake.catalogServiceUpdateMutex.Lock()
+	defer fake.catalogServiceUpdateMutex.Unlock()
+	fake.CatalogServiceUpdateStub = stub
+}
+
+func (fake *FakeAPIClient) CatalogServiceUpdateArgsForCall(i int) (models.CatalogServiceRequest, string) {
+	fake.catalogServiceUpdateMutex.RLock()
+	defer fake.catalogServiceUpdateMutex.RUnlock()
+	argsForCall := fake.catalogServiceUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) CatalogServiceUpdateReturns(result1 error) {
+	fake.catalogServiceUpdateMutex.Lock()
+	defer fake.catalogServiceUpdateMutex.Unlock()
+	fake.CatalogServiceUpdateStub = nil
+	fake.catalogServiceUpdateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) CatalogServiceUpdateReturnsOnCall(i int, result1 error) {
+	fake.catalogServiceUpdateMutex.Lock()
+	defer fake.catalogServiceUpdateMutex.Unlock()
+	fake.CatalogServiceUpdateStub = nil
+	if fake.catalogServiceUpdateReturnsOnCall == nil {
+		fake.catalogServiceUpdateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.catalogServiceUpdateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ChartList() ([]models.AppChart, error) {
+	fake.chartListMutex.Lock()
+	ret, specificReturn := fake.chartListReturnsOnCall[len(fake.chartListArgsForCall)]
+	fake.chartListArgsForCall = append(fake.chartListArgsForCall, struct {
+	}{})
+	stub := fake.ChartListStub
+	fakeReturns := fake.chartListReturns
+	fake.recordInvocation("ChartList", []interface{}{})
+	fake.chartListMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ChartListCallCount() int {
+	fake.chartListMutex.RLock()
+	defer fake.chartListMutex.RUnlock()
+	return len(fake.chartListArgsForCall)
+}
+
+func (fake *FakeAPIClient) ChartListCalls(stub func() ([]models.AppChart, error)) {
+	fake.chartListMutex.Lock()
+	defer fake.chartListMutex.Unlock()
+	fake.ChartListStub = stub
+}
+
+func (fake *FakeAPIClient) ChartListReturns(result1 []models.AppChart, result2 error) {
+	fake.chartListMutex.Lock()
+	defer fake.chartListMutex.Unlock()
+	fake.ChartListStub = nil
+	fake.chartListReturns = struct {
+		result1 []models.AppChart
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartListReturnsOnCall(i int, result1 []models.AppChart, result2 error) {
+	fake.chartListMutex.Lock()
+	defer fake.chartListMutex.Unlock()
+	fake.ChartListStub = nil
+	if fake.chartListReturnsOnCall == nil {
+		fake.chartListReturnsOnCall = make(map[int]struct {
+			result1 []models.AppChart
+			result2 error
+		})
+	}
+	fake.chartListReturnsOnCall[i] = struct {
+		result1 []models.AppChart
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartMatch(arg1 string) (models.ChartMatchResponse, error) {
+	fake.chartMatchMutex.Lock()
+	ret, specificReturn := fake.chartMatchReturnsOnCall[len(fake.chartMatchArgsForCall)]
+	fake.chartMatchArgsForCall = append(fake.chartMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChartMatchStub
+	fakeReturns := fake.chartMatchReturns
+	fake.recordInvocation("ChartMatch", []interface{}{arg1})
+	fake.chartMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ChartMatchCallCount() int {
+	fake.chartMatchMutex.RLock()
+	defer fake.chartMatchMutex.RUnlock()
+	return len(fake.chartMatchArgsForCall)
+}
+
+func (fake *FakeAPIClient) ChartMatchCalls(stub func(string) (models.ChartMatchResponse, error)) {
+	fake.chartMatchMutex.Lock()
+	defer fake.chartMatchMutex.Unlock()
+	fake.ChartMatchStub = stub
+}
+
+func (fake *FakeAPIClient) ChartMatchArgsForCall(i int) string {
+	fake.chartMatchMutex.RLock()
+	defer fake.chartMatchMutex.RUnlock()
+	argsForCall := fake.chartMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ChartMatchReturns(result1 models.ChartMatchResponse, result2 error) {
+	fake.chartMatchMutex.Lock()
+	defer fake.chartMatchMutex.Unlock()
+	fake.ChartMatchStub = nil
+	fake.chartMatchReturns = struct {
+		result1 models.ChartMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartMatchReturnsOnCall(i int, result1 models.ChartMatchResponse, result2 error) {
+	fake.chartMatchMutex.Lock()
+	defer fake.chartMatchMutex.Unlock()
+	fake.ChartMatchStub = nil
+	if fake.chartMatchReturnsOnCall == nil {
+		fake.chartMatchReturnsOnCall = make(map[int]struct {
+			result1 models.ChartMatchResponse
+			result2 error
+		})
+	}
+	fake.chartMatchReturnsOnCall[i] = struct {
+		result1 models.ChartMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartShow(arg1 string) (models.AppChart, error) {
+	fake.chartShowMutex.Lock()
+	ret, specificReturn := fake.chartShowReturnsOnCall[len(fake.chartShowArgsForCall)]
+	fake.chartShowArgsForCall = append(fake.chartShowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChartShowStub
+	fakeReturns := fake.chartShowReturns
+	fake.recordInvocation("ChartShow", []interface{}{arg1})
+	fake.chartShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ChartShowCallCount() int {
+	fake.chartShowMutex.RLock()
+	defer fake.chartShowMutex.RUnlock()
+	return len(fake.chartShowArgsForCall)
+}
+
+func (fake *FakeAPIClient) ChartShowCalls(stub func(string) (models.AppChart, error)) {
+	fake.chartShowMutex.Lock()
+	defer fake.chartShowMutex.Unlock()
+	fake.ChartShowStub = stub
+}
+
+func (fake *FakeAPIClient) ChartShowArgsForCall(i int) string {
+	fake.chartShowMutex.RLock()
+	defer fake.chartShowMutex.RUnlock()
+	argsForCall := fake.chartShowArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ChartShowReturns(result1 models.AppChart, result2 error) {
+	fake.chartShowMutex.Lock()
+	defer fake.chartShowMutex.Unlock()
+	fake.ChartShowStub = nil
+	fake.chartShowReturns = struct {
+		result1 models.AppChart
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartShowReturnsOnCall(i int, result1 models.AppChart, result2 error) {
+	fake.chartShowMutex.Lock()
+	defer fake.chartShowMutex.Unlock()
+	fake.ChartShowStub = nil
+	if fake.chartShowReturnsOnCall == nil {
+		fake.chartShowReturnsOnCall = make(map[int]struct {
+			result1 models.AppChart
+			result2 error
+		})
+	}
+	fake.chartShowReturnsOnCall[i] = struct {
+		result1 models.AppChart
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationApps(arg1 string) (models.ConfigurationAppsResponse, error) {
+	fake.configurationAppsMutex.Lock()
+	ret, specificReturn := fake.configurationAppsReturnsOnCall[len(fake.configurationAppsArgsForCall)]
+	fake.configurationAppsArgsForCall = append(fake.configurationAppsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ConfigurationAppsStub
+	fakeReturns := fake.configurationAppsReturns
+	fake.recordInvocation("ConfigurationApps", []interface{}{arg1})
+	fake.configurationAppsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ConfigurationAppsCallCount() int {
+	fake.configurationAppsMutex.RLock()
+	defer fake.configurationAppsMutex.RUnlock()
+	return len(fake.configurationAppsArgsForCall)
+}
+
+func (fake *FakeAPIClient) ConfigurationAppsCalls(stub func(string) (models.ConfigurationAppsResponse, error)) {
+	fake.configurationAppsMutex.Lock()
+	defer fake.configurationAppsMutex.Unlock()
+	fake.ConfigurationAppsStub = stub
+}
+
+func (fake *FakeAPIClient) ConfigurationAppsArgsForCall(i int) string {
+	fake.configurationAppsMutex.RLock()
+	defer fake.configurationAppsMutex.RUnlock()
+	argsForCall := fake.configurationAppsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ConfigurationAppsReturns(result1 models.ConfigurationAppsResponse, result2 error) {
+	fake.configurationAppsMutex.Lock()
+	defer fake.configurationAppsMutex.Unlock()
+	fake.ConfigurationAppsStub = nil
+	fake.configurationAppsReturns = struct {
+		result1 models.ConfigurationAppsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationAppsReturnsOnCall(i int, result1 models.ConfigurationAppsResponse, result2 error) {
+	fake.configurationAppsMutex.Lock()
+	defer fake.configurationAppsMutex.Unlock()
+	fake.ConfigurationAppsStub = nil
+	if fake.configurationAppsReturnsOnCall == nil {
+		fake.configurationAppsReturnsOnCall = make(map[int]struct {
+			result1 models.ConfigurationAppsResponse
+			result2 error
+		})
+	}
+	fake.configurationAppsReturnsOnCall[i] = struct {
+		result1 models.ConfigurationAppsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationBindingCreate(arg1 models.BindRequest, arg2 string, arg3 string) (models.BindResponse, error) {
+	fake.configurationBindingCreateMutex.Lock()
 	ret, specificReturn := fake.configurationBindingCreateReturnsOnCall[len(fake.configurationBindingCreateArgsForCall)]
 	fake.configurationBindingCreateArgsForCall = append(fake.configurationBindingCreateArgsForCall, struct {
 		arg1 models.BindRequest
@@ -2751,7 +3770,7 @@ func (fake *FakeAPIClient) ConfigurationShowReturnsOnCall(i int, result1 models.
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationUpdate(arg1 models.ConfigurationUpdateRequest, arg2 string, arg3 string) (models.Response, error) {
+func (fake *FakeAPIClient) ConfigurationUpdate(arg1 models.ConfigurationUpdateRequest, arg2 string, arg3 string) (models.ConfigurationUpdateResponse, error) {
 	fake.configurationUpdateMutex.Lock()
 	ret, specificReturn := fake.configurationUpdateReturnsOnCall[len(fake.configurationUpdateArgsForCall)]
 	fake.configurationUpdateArgsForCall = append(fake.configurationUpdateArgsForCall, struct {
@@ -2778,7 +3797,7 @@ func (fake *FakeAPIClient) ConfigurationUpdateCallCount() int {
 	return len(fake.configurationUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) ConfigurationUpdateCalls(stub func(models.ConfigurationUpdateRequest, string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) ConfigurationUpdateCalls(stub func(models.ConfigurationUpdateRequest, string, string) (models.ConfigurationUpdateResponse, error)) {
 	fake.configurationUpdateMutex.Lock()
 	defer fake.configurationUpdateMutex.Unlock()
 	fake.ConfigurationUpdateStub = stub
@@ -2791,28 +3810,28 @@ func (fake *FakeAPIClient) ConfigurationUpdateArgsForCall(i int) (models.Configu
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) ConfigurationUpdateReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ConfigurationUpdateReturns(result1 models.ConfigurationUpdateResponse, result2 error) {
 	fake.configurationUpdateMutex.Lock()
 	defer fake.configurationUpdateMutex.Unlock()
 	fake.ConfigurationUpdateStub = nil
 	fake.configurationUpdateReturns = struct {
-		result1 models.Response
+		result1 models.ConfigurationUpdateResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationUpdateReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ConfigurationUpdateReturnsOnCall(i int, result1 models.ConfigurationUpdateResponse, result2 error) {
 	fake.configurationUpdateMutex.Lock()
 	defer fake.configurationUpdateMutex.Unlock()
 	fake.ConfigurationUpdateStub = nil
 	if fake.configurationUpdateReturnsOnCall == nil {
 		fake.configurationUpdateReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.ConfigurationUpdateResponse
 			result2 error
 		})
 	}
 	fake.configurationUpdateReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.ConfigurationUpdateResponse
 		result2 error
 	}{result1, result2}
 }
@@ -3234,6 +4253,70 @@ func (fake *FakeAPIClient) EnvUnsetReturnsOnCall(i int, result1 models.Response,
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) ImageGC(arg1 models.ImageGCRequest) (models.ImageGCResponse, error) {
+	fake.imageGCMutex.Lock()
+	ret, specificReturn := fake.imageGCReturnsOnCall[len(fake.imageGCArgsForCall)]
+	fake.imageGCArgsForCall = append(fake.imageGCArgsForCall, struct {
+		arg1 models.ImageGCRequest
+	}{arg1})
+	stub := fake.ImageGCStub
+	fakeReturns := fake.imageGCReturns
+	fake.recordInvocation("ImageGC", []interface{}{arg1})
+	fake.imageGCMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ImageGCCallCount() int {
+	fake.imageGCMutex.RLock()
+	defer fake.imageGCMutex.RUnlock()
+	return len(fake.imageGCArgsForCall)
+}
+
+func (fake *FakeAPIClient) ImageGCCalls(stub func(models.ImageGCRequest) (models.ImageGCResponse, error)) {
+	fake.imageGCMutex.Lock()
+	defer fake.imageGCMutex.Unlock()
+	fake.ImageGCStub = stub
+}
+
+func (fake *FakeAPIClient) ImageGCArgsForCall(i int) models.ImageGCRequest {
+	fake.imageGCMutex.RLock()
+	defer fake.imageGCMutex.RUnlock()
+	argsForCall := fake.imageGCArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ImageGCReturns(result1 models.ImageGCResponse, result2 error) {
+	fake.imageGCMutex.Lock()
+	defer fake.imageGCMutex.Unlock()
+	fake.ImageGCStub = nil
+	fake.imageGCReturns = struct {
+		result1 models.ImageGCResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ImageGCReturnsOnCall(i int, result1 models.ImageGCResponse, result2 error) {
+	fake.imageGCMutex.Lock()
+	defer fake.imageGCMutex.Unlock()
+	fake.ImageGCStub = nil
+	if fake.imageGCReturnsOnCall == nil {
+		fake.imageGCReturnsOnCall = make(map[int]struct {
+			result1 models.ImageGCResponse
+			result2 error
+		})
+	}
+	fake.imageGCReturnsOnCall[i] = struct {
+		result1 models.ImageGCResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) Info() (models.InfoResponse, error) {
 	fake.infoMutex.Lock()
 	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
@@ -3354,18 +4437,275 @@ func (fake *FakeAPIClient) NamespaceCreateReturnsOnCall(i int, result1 models.Re
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceDelete(arg1 string) (models.Response, error) {
-	fake.namespaceDeleteMutex.Lock()
-	ret, specificReturn := fake.namespaceDeleteReturnsOnCall[len(fake.namespaceDeleteArgsForCall)]
-	fake.namespaceDeleteArgsForCall = append(fake.namespaceDeleteArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	stub := fake.NamespaceDeleteStub
-	fakeReturns := fake.namespaceDeleteReturns
-	fake.recordInvocation("NamespaceDelete", []interface{}{arg1})
-	fake.namespaceDeleteMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceDelete(arg1 string) (models.Response, error) {
+	fake.namespaceDeleteMutex.Lock()
+	ret, specificReturn := fake.namespaceDeleteReturnsOnCall[len(fake.namespaceDeleteArgsForCall)]
+	fake.namespaceDeleteArgsForCall = append(fake.namespaceDeleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceDeleteStub
+	fakeReturns := fake.namespaceDeleteReturns
+	fake.recordInvocation("NamespaceDelete", []interface{}{arg1})
+	fake.namespaceDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteCallCount() int {
+	fake.namespaceDeleteMutex.RLock()
+	defer fake.namespaceDeleteMutex.RUnlock()
+	return len(fake.namespaceDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteCalls(stub func(string) (models.Response, error)) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteArgsForCall(i int) string {
+	fake.namespaceDeleteMutex.RLock()
+	defer fake.namespaceDeleteMutex.RUnlock()
+	argsForCall := fake.namespaceDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteReturns(result1 models.Response, result2 error) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = nil
+	fake.namespaceDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = nil
+	if fake.namespaceDeleteReturnsOnCall == nil {
+		fake.namespaceDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEvents(arg1 string, arg2 func(models.Event)) error {
+	fake.namespaceEventsMutex.Lock()
+	ret, specificReturn := fake.namespaceEventsReturnsOnCall[len(fake.namespaceEventsArgsForCall)]
+	fake.namespaceEventsArgsForCall = append(fake.namespaceEventsArgsForCall, struct {
+		arg1 string
+		arg2 func(models.Event)
+	}{arg1, arg2})
+	stub := fake.NamespaceEventsStub
+	fakeReturns := fake.namespaceEventsReturns
+	fake.recordInvocation("NamespaceEvents", []interface{}{arg1, arg2})
+	fake.namespaceEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) NamespaceEventsCallCount() int {
+	fake.namespaceEventsMutex.RLock()
+	defer fake.namespaceEventsMutex.RUnlock()
+	return len(fake.namespaceEventsArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceEventsCalls(stub func(string, func(models.Event)) error) {
+	fake.namespaceEventsMutex.Lock()
+	defer fake.namespaceEventsMutex.Unlock()
+	fake.NamespaceEventsStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceEventsArgsForCall(i int) (string, func(models.Event)) {
+	fake.namespaceEventsMutex.RLock()
+	defer fake.namespaceEventsMutex.RUnlock()
+	argsForCall := fake.namespaceEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceEventsReturns(result1 error) {
+	fake.namespaceEventsMutex.Lock()
+	defer fake.namespaceEventsMutex.Unlock()
+	fake.NamespaceEventsStub = nil
+	fake.namespaceEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) NamespaceEventsReturnsOnCall(i int, result1 error) {
+	fake.namespaceEventsMutex.Lock()
+	defer fake.namespaceEventsMutex.Unlock()
+	fake.NamespaceEventsStub = nil
+	if fake.namespaceEventsReturnsOnCall == nil {
+		fake.namespaceEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.namespaceEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) NamespaceMemberAdd(arg1 models.NamespaceMemberRequest, arg2 string) (models.Response, error) {
+	fake.namespaceMemberAddMutex.Lock()
+	ret, specificReturn := fake.namespaceMemberAddReturnsOnCall[len(fake.namespaceMemberAddArgsForCall)]
+	fake.namespaceMemberAddArgsForCall = append(fake.namespaceMemberAddArgsForCall, struct {
+		arg1 models.NamespaceMemberRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceMemberAddStub
+	fakeReturns := fake.namespaceMemberAddReturns
+	fake.recordInvocation("NamespaceMemberAdd", []interface{}{arg1, arg2})
+	fake.namespaceMemberAddMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceMemberAddCallCount() int {
+	fake.namespaceMemberAddMutex.RLock()
+	defer fake.namespaceMemberAddMutex.RUnlock()
+	return len(fake.namespaceMemberAddArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceMemberAddCalls(stub func(models.NamespaceMemberRequest, string) (models.Response, error)) {
+	fake.namespaceMemberAddMutex.Lock()
+	defer fake.namespaceMemberAddMutex.Unlock()
+	fake.NamespaceMemberAddStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceMemberAddArgsForCall(i int) (models.NamespaceMemberRequest, string) {
+	fake.namespaceMemberAddMutex.RLock()
+	defer fake.namespaceMemberAddMutex.RUnlock()
+	argsForCall := fake.namespaceMemberAddArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceMemberAddReturns(result1 models.Response, result2 error) {
+	fake.namespaceMemberAddMutex.Lock()
+	defer fake.namespaceMemberAddMutex.Unlock()
+	fake.NamespaceMemberAddStub = nil
+	fake.namespaceMemberAddReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceMemberAddReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceMemberAddMutex.Lock()
+	defer fake.namespaceMemberAddMutex.Unlock()
+	fake.NamespaceMemberAddStub = nil
+	if fake.namespaceMemberAddReturnsOnCall == nil {
+		fake.namespaceMemberAddReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceMemberAddReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceMemberRemove(arg1 string, arg2 string) (models.Response, error) {
+	fake.namespaceMemberRemoveMutex.Lock()
+	ret, specificReturn := fake.namespaceMemberRemoveReturnsOnCall[len(fake.namespaceMemberRemoveArgsForCall)]
+	fake.namespaceMemberRemoveArgsForCall = append(fake.namespaceMemberRemoveArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceMemberRemoveStub
+	fakeReturns := fake.namespaceMemberRemoveReturns
+	fake.recordInvocation("NamespaceMemberRemove", []interface{}{arg1, arg2})
+	fake.namespaceMemberRemoveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceMemberRemoveCallCount() int {
+	fake.namespaceMemberRemoveMutex.RLock()
+	defer fake.namespaceMemberRemoveMutex.RUnlock()
+	return len(fake.namespaceMemberRemoveArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceMemberRemoveCalls(stub func(string, string) (models.Response, error)) {
+	fake.namespaceMemberRemoveMutex.Lock()
+	defer fake.namespaceMemberRemoveMutex.Unlock()
+	fake.NamespaceMemberRemoveStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceMemberRemoveArgsForCall(i int) (string, string) {
+	fake.namespaceMemberRemoveMutex.RLock()
+	defer fake.namespaceMemberRemoveMutex.RUnlock()
+	argsForCall := fake.namespaceMemberRemoveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceMemberRemoveReturns(result1 models.Response, result2 error) {
+	fake.namespaceMemberRemoveMutex.Lock()
+	defer fake.namespaceMemberRemoveMutex.Unlock()
+	fake.NamespaceMemberRemoveStub = nil
+	fake.namespaceMemberRemoveReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceMemberRemoveReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceMemberRemoveMutex.Lock()
+	defer fake.namespaceMemberRemoveMutex.Unlock()
+	fake.NamespaceMemberRemoveStub = nil
+	if fake.namespaceMemberRemoveReturnsOnCall == nil {
+		fake.namespaceMemberRemoveReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceMemberRemoveReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdate(arg1 models.NamespaceQuota, arg2 string) (models.Response, error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceQuotaUpdateReturnsOnCall[len(fake.namespaceQuotaUpdateArgsForCall)]
+	fake.namespaceQuotaUpdateArgsForCall = append(fake.namespaceQuotaUpdateArgsForCall, struct {
+		arg1 models.NamespaceQuota
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceQuotaUpdateStub
+	fakeReturns := fake.namespaceQuotaUpdateReturns
+	fake.recordInvocation("NamespaceQuotaUpdate", []interface{}{arg1, arg2})
+	fake.namespaceQuotaUpdateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3373,46 +4713,46 @@ func (fake *FakeAPIClient) NamespaceDelete(arg1 string) (models.Response, error)
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteCallCount() int {
-	fake.namespaceDeleteMutex.RLock()
-	defer fake.namespaceDeleteMutex.RUnlock()
-	return len(fake.namespaceDeleteArgsForCall)
+func (fake *FakeAPIClient) NamespaceQuotaUpdateCallCount() int {
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
+	return len(fake.namespaceQuotaUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteCalls(stub func(string) (models.Response, error)) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = stub
+func (fake *FakeAPIClient) NamespaceQuotaUpdateCalls(stub func(models.NamespaceQuota, string) (models.Response, error)) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = stub
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteArgsForCall(i int) string {
-	fake.namespaceDeleteMutex.RLock()
-	defer fake.namespaceDeleteMutex.RUnlock()
-	argsForCall := fake.namespaceDeleteArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) NamespaceQuotaUpdateArgsForCall(i int) (models.NamespaceQuota, string) {
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceQuotaUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteReturns(result1 models.Response, result2 error) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = nil
-	fake.namespaceDeleteReturns = struct {
+func (fake *FakeAPIClient) NamespaceQuotaUpdateReturns(result1 models.Response, result2 error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = nil
+	fake.namespaceQuotaUpdateReturns = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = nil
-	if fake.namespaceDeleteReturnsOnCall == nil {
-		fake.namespaceDeleteReturnsOnCall = make(map[int]struct {
+func (fake *FakeAPIClient) NamespaceQuotaUpdateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = nil
+	if fake.namespaceQuotaUpdateReturnsOnCall == nil {
+		fake.namespaceQuotaUpdateReturnsOnCall = make(map[int]struct {
 			result1 models.Response
 			result2 error
 		})
 	}
-	fake.namespaceDeleteReturnsOnCall[i] = struct {
+	fake.namespaceQuotaUpdateReturnsOnCall[i] = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
@@ -3538,18 +4878,405 @@ func (fake *FakeAPIClient) NamespacesReturnsOnCall(i int, result1 models.Namespa
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespacesMatch(arg1 string) (models.NamespacesMatchResponse, error) {
-	fake.namespacesMatchMutex.Lock()
-	ret, specificReturn := fake.namespacesMatchReturnsOnCall[len(fake.namespacesMatchArgsForCall)]
-	fake.namespacesMatchArgsForCall = append(fake.namespacesMatchArgsForCall, struct {
+func (fake *FakeAPIClient) NamespacesMatch(arg1 string) (models.NamespacesMatchResponse, error) {
+	fake.namespacesMatchMutex.Lock()
+	ret, specificReturn := fake.namespacesMatchReturnsOnCall[len(fake.namespacesMatchArgsForCall)]
+	fake.namespacesMatchArgsForCall = append(fake.namespacesMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespacesMatchStub
+	fakeReturns := fake.namespacesMatchReturns
+	fake.recordInvocation("NamespacesMatch", []interface{}{arg1})
+	fake.namespacesMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespacesMatchCallCount() int {
+	fake.namespacesMatchMutex.RLock()
+	defer fake.namespacesMatchMutex.RUnlock()
+	return len(fake.namespacesMatchArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespacesMatchCalls(stub func(string) (models.NamespacesMatchResponse, error)) {
+	fake.namespacesMatchMutex.Lock()
+	defer fake.namespacesMatchMutex.Unlock()
+	fake.NamespacesMatchStub = stub
+}
+
+func (fake *FakeAPIClient) NamespacesMatchArgsForCall(i int) string {
+	fake.namespacesMatchMutex.RLock()
+	defer fake.namespacesMatchMutex.RUnlock()
+	argsForCall := fake.namespacesMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespacesMatchReturns(result1 models.NamespacesMatchResponse, result2 error) {
+	fake.namespacesMatchMutex.Lock()
+	defer fake.namespacesMatchMutex.Unlock()
+	fake.NamespacesMatchStub = nil
+	fake.namespacesMatchReturns = struct {
+		result1 models.NamespacesMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespacesMatchReturnsOnCall(i int, result1 models.NamespacesMatchResponse, result2 error) {
+	fake.namespacesMatchMutex.Lock()
+	defer fake.namespacesMatchMutex.Unlock()
+	fake.NamespacesMatchStub = nil
+	if fake.namespacesMatchReturnsOnCall == nil {
+		fake.namespacesMatchReturnsOnCall = make(map[int]struct {
+			result1 models.NamespacesMatchResponse
+			result2 error
+		})
+	}
+	fake.namespacesMatchReturnsOnCall[i] = struct {
+		result1 models.NamespacesMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryLogin(arg1 string, arg2 models.RegistryLoginRequest) (models.Response, error) {
+	fake.registryLoginMutex.Lock()
+	ret, specificReturn := fake.registryLoginReturnsOnCall[len(fake.registryLoginArgsForCall)]
+	fake.registryLoginArgsForCall = append(fake.registryLoginArgsForCall, struct {
+		arg1 string
+		arg2 models.RegistryLoginRequest
+	}{arg1, arg2})
+	stub := fake.RegistryLoginStub
+	fakeReturns := fake.registryLoginReturns
+	fake.recordInvocation("RegistryLogin", []interface{}{arg1, arg2})
+	fake.registryLoginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) RegistryLoginCallCount() int {
+	fake.registryLoginMutex.RLock()
+	defer fake.registryLoginMutex.RUnlock()
+	return len(fake.registryLoginArgsForCall)
+}
+
+func (fake *FakeAPIClient) RegistryLoginCalls(stub func(string, models.RegistryLoginRequest) (models.Response, error)) {
+	fake.registryLoginMutex.Lock()
+	defer fake.registryLoginMutex.Unlock()
+	fake.RegistryLoginStub = stub
+}
+
+func (fake *FakeAPIClient) RegistryLoginArgsForCall(i int) (string, models.RegistryLoginRequest) {
+	fake.registryLoginMutex.RLock()
+	defer fake.registryLoginMutex.RUnlock()
+	argsForCall := fake.registryLoginArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) RegistryLoginReturns(result1 models.Response, result2 error) {
+	fake.registryLoginMutex.Lock()
+	defer fake.registryLoginMutex.Unlock()
+	fake.RegistryLoginStub = nil
+	fake.registryLoginReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryLoginReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.registryLoginMutex.Lock()
+	defer fake.registryLoginMutex.Unlock()
+	fake.RegistryLoginStub = nil
+	if fake.registryLoginReturnsOnCall == nil {
+		fake.registryLoginReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.registryLoginReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryLogins(arg1 string) (models.RegistryLoginList, error) {
+	fake.registryLoginsMutex.Lock()
+	ret, specificReturn := fake.registryLoginsReturnsOnCall[len(fake.registryLoginsArgsForCall)]
+	fake.registryLoginsArgsForCall = append(fake.registryLoginsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RegistryLoginsStub
+	fakeReturns := fake.registryLoginsReturns
+	fake.recordInvocation("RegistryLogins", []interface{}{arg1})
+	fake.registryLoginsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) RegistryLoginsCallCount() int {
+	fake.registryLoginsMutex.RLock()
+	defer fake.registryLoginsMutex.RUnlock()
+	return len(fake.registryLoginsArgsForCall)
+}
+
+func (fake *FakeAPIClient) RegistryLoginsCalls(stub func(string) (models.RegistryLoginList, error)) {
+	fake.registryLoginsMutex.Lock()
+	defer fake.registryLoginsMutex.Unlock()
+	fake.RegistryLoginsStub = stub
+}
+
+func (fake *FakeAPIClient) RegistryLoginsArgsForCall(i int) string {
+	fake.registryLoginsMutex.RLock()
+	defer fake.registryLoginsMutex.RUnlock()
+	argsForCall := fake.registryLoginsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) RegistryLoginsReturns(result1 models.RegistryLoginList, result2 error) {
+	fake.registryLoginsMutex.Lock()
+	defer fake.registryLoginsMutex.Unlock()
+	fake.RegistryLoginsStub = nil
+	fake.registryLoginsReturns = struct {
+		result1 models.RegistryLoginList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryLoginsReturnsOnCall(i int, result1 models.RegistryLoginList, result2 error) {
+	fake.registryLoginsMutex.Lock()
+	defer fake.registryLoginsMutex.Unlock()
+	fake.RegistryLoginsStub = nil
+	if fake.registryLoginsReturnsOnCall == nil {
+		fake.registryLoginsReturnsOnCall = make(map[int]struct {
+			result1 models.RegistryLoginList
+			result2 error
+		})
+	}
+	fake.registryLoginsReturnsOnCall[i] = struct {
+		result1 models.RegistryLoginList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryLogout(arg1 string, arg2 string) (models.Response, error) {
+	fake.registryLogoutMutex.Lock()
+	ret, specificReturn := fake.registryLogoutReturnsOnCall[len(fake.registryLogoutArgsForCall)]
+	fake.registryLogoutArgsForCall = append(fake.registryLogoutArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RegistryLogoutStub
+	fakeReturns := fake.registryLogoutReturns
+	fake.recordInvocation("RegistryLogout", []interface{}{arg1, arg2})
+	fake.registryLogoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) RegistryLogoutCallCount() int {
+	fake.registryLogoutMutex.RLock()
+	defer fake.registryLogoutMutex.RUnlock()
+	return len(fake.registryLogoutArgsForCall)
+}
+
+func (fake *FakeAPIClient) RegistryLogoutCalls(stub func(string, string) (models.Response, error)) {
+	fake.registryLogoutMutex.Lock()
+	defer fake.registryLogoutMutex.Unlock()
+	fake.RegistryLogoutStub = stub
+}
+
+func (fake *FakeAPIClient) RegistryLogoutArgsForCall(i int) (string, string) {
+	fake.registryLogoutMutex.RLock()
+	defer fake.registryLogoutMutex.RUnlock()
+	argsForCall := fake.registryLogoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) RegistryLogoutReturns(result1 models.Response, result2 error) {
+	fake.registryLogoutMutex.Lock()
+	defer fake.registryLogoutMutex.Unlock()
+	fake.RegistryLogoutStub = nil
+	fake.registryLogoutReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) RegistryLogoutReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.registryLogoutMutex.Lock()
+	defer fake.registryLogoutMutex.Unlock()
+	fake.RegistryLogoutStub = nil
+	if fake.registryLogoutReturnsOnCall == nil {
+		fake.registryLogoutReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.registryLogoutReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceBackup(arg1 string, arg2 string) (models.ServiceBackup, error) {
+	fake.serviceBackupMutex.Lock()
+	ret, specificReturn := fake.serviceBackupReturnsOnCall[len(fake.serviceBackupArgsForCall)]
+	fake.serviceBackupArgsForCall = append(fake.serviceBackupArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceBackupStub
+	fakeReturns := fake.serviceBackupReturns
+	fake.recordInvocation("ServiceBackup", []interface{}{arg1, arg2})
+	fake.serviceBackupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceBackupCallCount() int {
+	fake.serviceBackupMutex.RLock()
+	defer fake.serviceBackupMutex.RUnlock()
+	return len(fake.serviceBackupArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceBackupCalls(stub func(string, string) (models.ServiceBackup, error)) {
+	fake.serviceBackupMutex.Lock()
+	defer fake.serviceBackupMutex.Unlock()
+	fake.ServiceBackupStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceBackupArgsForCall(i int) (string, string) {
+	fake.serviceBackupMutex.RLock()
+	defer fake.serviceBackupMutex.RUnlock()
+	argsForCall := fake.serviceBackupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ServiceBackupReturns(result1 models.ServiceBackup, result2 error) {
+	fake.serviceBackupMutex.Lock()
+	defer fake.serviceBackupMutex.Unlock()
+	fake.ServiceBackupStub = nil
+	fake.serviceBackupReturns = struct {
+		result1 models.ServiceBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceBackupReturnsOnCall(i int, result1 models.ServiceBackup, result2 error) {
+	fake.serviceBackupMutex.Lock()
+	defer fake.serviceBackupMutex.Unlock()
+	fake.ServiceBackupStub = nil
+	if fake.serviceBackupReturnsOnCall == nil {
+		fake.serviceBackupReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceBackup
+			result2 error
+		})
+	}
+	fake.serviceBackupReturnsOnCall[i] = struct {
+		result1 models.ServiceBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceBackupSchedule(arg1 models.ServiceBackupScheduleRequest, arg2 string, arg3 string) error {
+	fake.serviceBackupScheduleMutex.Lock()
+	ret, specificReturn := fake.serviceBackupScheduleReturnsOnCall[len(fake.serviceBackupScheduleArgsForCall)]
+	fake.serviceBackupScheduleArgsForCall = append(fake.serviceBackupScheduleArgsForCall, struct {
+		arg1 models.ServiceBackupScheduleRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceBackupScheduleStub
+	fakeReturns := fake.serviceBackupScheduleReturns
+	fake.recordInvocation("ServiceBackupSchedule", []interface{}{arg1, arg2, arg3})
+	fake.serviceBackupScheduleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceBackupScheduleCallCount() int {
+	fake.serviceBackupScheduleMutex.RLock()
+	defer fake.serviceBackupScheduleMutex.RUnlock()
+	return len(fake.serviceBackupScheduleArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceBackupScheduleCalls(stub func(models.ServiceBackupScheduleRequest, string, string) error) {
+	fake.serviceBackupScheduleMutex.Lock()
+	defer fake.serviceBackupScheduleMutex.Unlock()
+	fake.ServiceBackupScheduleStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceBackupScheduleArgsForCall(i int) (models.ServiceBackupScheduleRequest, string, string) {
+	fake.serviceBackupScheduleMutex.RLock()
+	defer fake.serviceBackupScheduleMutex.RUnlock()
+	argsForCall := fake.serviceBackupScheduleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceBackupScheduleReturns(result1 error) {
+	fake.serviceBackupScheduleMutex.Lock()
+	defer fake.serviceBackupScheduleMutex.Unlock()
+	fake.ServiceBackupScheduleStub = nil
+	fake.serviceBackupScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceBackupScheduleReturnsOnCall(i int, result1 error) {
+	fake.serviceBackupScheduleMutex.Lock()
+	defer fake.serviceBackupScheduleMutex.Unlock()
+	fake.ServiceBackupScheduleStub = nil
+	if fake.serviceBackupScheduleReturnsOnCall == nil {
+		fake.serviceBackupScheduleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceBackupScheduleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceBackups(arg1 string, arg2 string) (models.ServiceBackupList, error) {
+	fake.serviceBackupsMutex.Lock()
+	ret, specificReturn := fake.serviceBackupsReturnsOnCall[len(fake.serviceBackupsArgsForCall)]
+	fake.serviceBackupsArgsForCall = append(fake.serviceBackupsArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	stub := fake.NamespacesMatchStub
-	fakeReturns := fake.namespacesMatchReturns
-	fake.recordInvocation("NamespacesMatch", []interface{}{arg1})
-	fake.namespacesMatchMutex.Unlock()
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceBackupsStub
+	fakeReturns := fake.serviceBackupsReturns
+	fake.recordInvocation("ServiceBackups", []interface{}{arg1, arg2})
+	fake.serviceBackupsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3557,47 +5284,47 @@ func (fake *FakeAPIClient) NamespacesMatch(arg1 string) (models.NamespacesMatchR
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespacesMatchCallCount() int {
-	fake.namespacesMatchMutex.RLock()
-	defer fake.namespacesMatchMutex.RUnlock()
-	return len(fake.namespacesMatchArgsForCall)
+func (fake *FakeAPIClient) ServiceBackupsCallCount() int {
+	fake.serviceBackupsMutex.RLock()
+	defer fake.serviceBackupsMutex.RUnlock()
+	return len(fake.serviceBackupsArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespacesMatchCalls(stub func(string) (models.NamespacesMatchResponse, error)) {
-	fake.namespacesMatchMutex.Lock()
-	defer fake.namespacesMatchMutex.Unlock()
-	fake.NamespacesMatchStub = stub
+func (fake *FakeAPIClient) ServiceBackupsCalls(stub func(string, string) (models.ServiceBackupList, error)) {
+	fake.serviceBackupsMutex.Lock()
+	defer fake.serviceBackupsMutex.Unlock()
+	fake.ServiceBackupsStub = stub
 }
 
-func (fake *FakeAPIClient) NamespacesMatchArgsForCall(i int) string {
-	fake.namespacesMatchMutex.RLock()
-	defer fake.namespacesMatchMutex.RUnlock()
-	argsForCall := fake.namespacesMatchArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) ServiceBackupsArgsForCall(i int) (string, string) {
+	fake.serviceBackupsMutex.RLock()
+	defer fake.serviceBackupsMutex.RUnlock()
+	argsForCall := fake.serviceBackupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) NamespacesMatchReturns(result1 models.NamespacesMatchResponse, result2 error) {
-	fake.namespacesMatchMutex.Lock()
-	defer fake.namespacesMatchMutex.Unlock()
-	fake.NamespacesMatchStub = nil
-	fake.namespacesMatchReturns = struct {
-		result1 models.NamespacesMatchResponse
+func (fake *FakeAPIClient) ServiceBackupsReturns(result1 models.ServiceBackupList, result2 error) {
+	fake.serviceBackupsMutex.Lock()
+	defer fake.serviceBackupsMutex.Unlock()
+	fake.ServiceBackupsStub = nil
+	fake.serviceBackupsReturns = struct {
+		result1 models.ServiceBackupList
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespacesMatchReturnsOnCall(i int, result1 models.NamespacesMatchResponse, result2 error) {
-	fake.namespacesMatchMutex.Lock()
-	defer fake.namespacesMatchMutex.Unlock()
-	fake.NamespacesMatchStub = nil
-	if fake.namespacesMatchReturnsOnCall == nil {
-		fake.namespacesMatchReturnsOnCall = make(map[int]struct {
-			result1 models.NamespacesMatchResponse
+func (fake *FakeAPIClient) ServiceBackupsReturnsOnCall(i int, result1 models.ServiceBackupList, result2 error) {
+	fake.serviceBackupsMutex.Lock()
+	defer fake.serviceBackupsMutex.Unlock()
+	fake.ServiceBackupsStub = nil
+	if fake.serviceBackupsReturnsOnCall == nil {
+		fake.serviceBackupsReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceBackupList
 			result2 error
 		})
 	}
-	fake.namespacesMatchReturnsOnCall[i] = struct {
-		result1 models.NamespacesMatchResponse
+	fake.serviceBackupsReturnsOnCall[i] = struct {
+		result1 models.ServiceBackupList
 		result2 error
 	}{result1, result2}
 }
@@ -4112,6 +5839,325 @@ func (fake *FakeAPIClient) ServiceMatchReturnsOnCall(i int, result1 models.Servi
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) ServicePortForward(arg1 string, arg2 string, arg3 *client.PortForwardOpts) error {
+	fake.servicePortForwardMutex.Lock()
+	ret, specificReturn := fake.servicePortForwardReturnsOnCall[len(fake.servicePortForwardArgsForCall)]
+	fake.servicePortForwardArgsForCall = append(fake.servicePortForwardArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *client.PortForwardOpts
+	}{arg1, arg2, arg3})
+	stub := fake.ServicePortForwardStub
+	fakeReturns := fake.servicePortForwardReturns
+	fake.recordInvocation("ServicePortForward", []interface{}{arg1, arg2, arg3})
+	fake.servicePortForwardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServicePortForwardCallCount() int {
+	fake.servicePortForwardMutex.RLock()
+	defer fake.servicePortForwardMutex.RUnlock()
+	return len(fake.servicePortForwardArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServicePortForwardCalls(stub func(string, string, *client.PortForwardOpts) error) {
+	fake.servicePortForwardMutex.Lock()
+	defer fake.servicePortForwardMutex.Unlock()
+	fake.ServicePortForwardStub = stub
+}
+
+func (fake *FakeAPIClient) ServicePortForwardArgsForCall(i int) (string, string, *client.PortForwardOpts) {
+	fake.servicePortForwardMutex.RLock()
+	defer fake.servicePortForwardMutex.RUnlock()
+	argsForCall := fake.servicePortForwardArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServicePortForwardReturns(result1 error) {
+	fake.servicePortForwardMutex.Lock()
+	defer fake.servicePortForwardMutex.Unlock()
+	fake.ServicePortForwardStub = nil
+	fake.servicePortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServicePortForwardReturnsOnCall(i int, result1 error) {
+	fake.servicePortForwardMutex.Lock()
+	defer fake.servicePortForwardMutex.Unlock()
+	fake.ServicePortForwardStub = nil
+	if fake.servicePortForwardReturnsOnCall == nil {
+		fake.servicePortForwardReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.servicePortForwardReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceReady(arg1 string, arg2 string) error {
+	fake.serviceReadyMutex.Lock()
+	ret, specificReturn := fake.serviceReadyReturnsOnCall[len(fake.serviceReadyArgsForCall)]
+	fake.serviceReadyArgsForCall = append(fake.serviceReadyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceReadyStub
+	fakeReturns := fake.serviceReadyReturns
+	fake.recordInvocation("ServiceReady", []interface{}{arg1, arg2})
+	fake.serviceReadyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceReadyCallCount() int {
+	fake.serviceReadyMutex.RLock()
+	defer fake.serviceReadyMutex.RUnlock()
+	return len(fake.serviceReadyArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceReadyCalls(stub func(string, string) error) {
+	fake.serviceReadyMutex.Lock()
+	defer fake.serviceReadyMutex.Unlock()
+	fake.ServiceReadyStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceReadyArgsForCall(i int) (string, string) {
+	fake.serviceReadyMutex.RLock()
+	defer fake.serviceReadyMutex.RUnlock()
+	argsForCall := fake.serviceReadyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ServiceReadyReturns(result1 error) {
+	fake.serviceReadyMutex.Lock()
+	defer fake.serviceReadyMutex.Unlock()
+	fake.ServiceReadyStub = nil
+	fake.serviceReadyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceReadyReturnsOnCall(i int, result1 error) {
+	fake.serviceReadyMutex.Lock()
+	defer fake.serviceReadyMutex.Unlock()
+	fake.ServiceReadyStub = nil
+	if fake.serviceReadyReturnsOnCall == nil {
+		fake.serviceReadyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceReadyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceRestore(arg1 string, arg2 string, arg3 string) error {
+	fake.serviceRestoreMutex.Lock()
+	ret, specificReturn := fake.serviceRestoreReturnsOnCall[len(fake.serviceRestoreArgsForCall)]
+	fake.serviceRestoreArgsForCall = append(fake.serviceRestoreArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceRestoreStub
+	fakeReturns := fake.serviceRestoreReturns
+	fake.recordInvocation("ServiceRestore", []interface{}{arg1, arg2, arg3})
+	fake.serviceRestoreMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceRestoreCallCount() int {
+	fake.serviceRestoreMutex.RLock()
+	defer fake.serviceRestoreMutex.RUnlock()
+	return len(fake.serviceRestoreArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceRestoreCalls(stub func(string, string, string) error) {
+	fake.serviceRestoreMutex.Lock()
+	defer fake.serviceRestoreMutex.Unlock()
+	fake.ServiceRestoreStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceRestoreArgsForCall(i int) (string, string, string) {
+	fake.serviceRestoreMutex.RLock()
+	defer fake.serviceRestoreMutex.RUnlock()
+	argsForCall := fake.serviceRestoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceRestoreReturns(result1 error) {
+	fake.serviceRestoreMutex.Lock()
+	defer fake.serviceRestoreMutex.Unlock()
+	fake.ServiceRestoreStub = nil
+	fake.serviceRestoreReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceRestoreReturnsOnCall(i int, result1 error) {
+	fake.serviceRestoreMutex.Lock()
+	defer fake.serviceRestoreMutex.Unlock()
+	fake.ServiceRestoreStub = nil
+	if fake.serviceRestoreReturnsOnCall == nil {
+		fake.serviceRestoreReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceRestoreReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceRotateCredentials(arg1 string, arg2 string) (models.ServiceRotateCredentialsResponse, error) {
+	fake.serviceRotateCredentialsMutex.Lock()
+	ret, specificReturn := fake.serviceRotateCredentialsReturnsOnCall[len(fake.serviceRotateCredentialsArgsForCall)]
+	fake.serviceRotateCredentialsArgsForCall = append(fake.serviceRotateCredentialsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceRotateCredentialsStub
+	fakeReturns := fake.serviceRotateCredentialsReturns
+	fake.recordInvocation("ServiceRotateCredentials", []interface{}{arg1, arg2})
+	fake.serviceRotateCredentialsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceRotateCredentialsCallCount() int {
+	fake.serviceRotateCredentialsMutex.RLock()
+	defer fake.serviceRotateCredentialsMutex.RUnlock()
+	return len(fake.serviceRotateCredentialsArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceRotateCredentialsCalls(stub func(string, string) (models.ServiceRotateCredentialsResponse, error)) {
+	fake.serviceRotateCredentialsMutex.Lock()
+	defer fake.serviceRotateCredentialsMutex.Unlock()
+	fake.ServiceRotateCredentialsStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceRotateCredentialsArgsForCall(i int) (string, string) {
+	fake.serviceRotateCredentialsMutex.RLock()
+	defer fake.serviceRotateCredentialsMutex.RUnlock()
+	argsForCall := fake.serviceRotateCredentialsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ServiceRotateCredentialsReturns(result1 models.ServiceRotateCredentialsResponse, result2 error) {
+	fake.serviceRotateCredentialsMutex.Lock()
+	defer fake.serviceRotateCredentialsMutex.Unlock()
+	fake.ServiceRotateCredentialsStub = nil
+	fake.serviceRotateCredentialsReturns = struct {
+		result1 models.ServiceRotateCredentialsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceRotateCredentialsReturnsOnCall(i int, result1 models.ServiceRotateCredentialsResponse, result2 error) {
+	fake.serviceRotateCredentialsMutex.Lock()
+	defer fake.serviceRotateCredentialsMutex.Unlock()
+	fake.ServiceRotateCredentialsStub = nil
+	if fake.serviceRotateCredentialsReturnsOnCall == nil {
+		fake.serviceRotateCredentialsReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceRotateCredentialsResponse
+			result2 error
+		})
+	}
+	fake.serviceRotateCredentialsReturnsOnCall[i] = struct {
+		result1 models.ServiceRotateCredentialsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceShare(arg1 models.ServiceShareRequest, arg2 string, arg3 string) (models.ServiceShareResponse, error) {
+	fake.serviceShareMutex.Lock()
+	ret, specificReturn := fake.serviceShareReturnsOnCall[len(fake.serviceShareArgsForCall)]
+	fake.serviceShareArgsForCall = append(fake.serviceShareArgsForCall, struct {
+		arg1 models.ServiceShareRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceShareStub
+	fakeReturns := fake.serviceShareReturns
+	fake.recordInvocation("ServiceShare", []interface{}{arg1, arg2, arg3})
+	fake.serviceShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceShareCallCount() int {
+	fake.serviceShareMutex.RLock()
+	defer fake.serviceShareMutex.RUnlock()
+	return len(fake.serviceShareArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceShareCalls(stub func(models.ServiceShareRequest, string, string) (models.ServiceShareResponse, error)) {
+	fake.serviceShareMutex.Lock()
+	defer fake.serviceShareMutex.Unlock()
+	fake.ServiceShareStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceShareArgsForCall(i int) (models.ServiceShareRequest, string, string) {
+	fake.serviceShareMutex.RLock()
+	defer fake.serviceShareMutex.RUnlock()
+	argsForCall := fake.serviceShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceShareReturns(result1 models.ServiceShareResponse, result2 error) {
+	fake.serviceShareMutex.Lock()
+	defer fake.serviceShareMutex.Unlock()
+	fake.ServiceShareStub = nil
+	fake.serviceShareReturns = struct {
+		result1 models.ServiceShareResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceShareReturnsOnCall(i int, result1 models.ServiceShareResponse, result2 error) {
+	fake.serviceShareMutex.Lock()
+	defer fake.serviceShareMutex.Unlock()
+	fake.ServiceShareStub = nil
+	if fake.serviceShareReturnsOnCall == nil {
+		fake.serviceShareReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceShareResponse
+			result2 error
+		})
+	}
+	fake.serviceShareReturnsOnCall[i] = struct {
+		result1 models.ServiceShareResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) ServiceShow(arg1 *models.ServiceShowRequest, arg2 string) (*models.Service, error) {
 	fake.serviceShowMutex.Lock()
 	ret, specificReturn := fake.serviceShowReturnsOnCall[len(fake.serviceShowArgsForCall)]
@@ -4240,6 +6286,138 @@ func (fake *FakeAPIClient) ServiceUnbindReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeAPIClient) ServiceUnshare(arg1 string, arg2 string, arg3 string) (models.ServiceUnshareResponse, error) {
+	fake.serviceUnshareMutex.Lock()
+	ret, specificReturn := fake.serviceUnshareReturnsOnCall[len(fake.serviceUnshareArgsForCall)]
+	fake.serviceUnshareArgsForCall = append(fake.serviceUnshareArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceUnshareStub
+	fakeReturns := fake.serviceUnshareReturns
+	fake.recordInvocation("ServiceUnshare", []interface{}{arg1, arg2, arg3})
+	fake.serviceUnshareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceUnshareCallCount() int {
+	fake.serviceUnshareMutex.RLock()
+	defer fake.serviceUnshareMutex.RUnlock()
+	return len(fake.serviceUnshareArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceUnshareCalls(stub func(string, string, string) (models.ServiceUnshareResponse, error)) {
+	fake.serviceUnshareMutex.Lock()
+	defer fake.serviceUnshareMutex.Unlock()
+	fake.ServiceUnshareStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceUnshareArgsForCall(i int) (string, string, string) {
+	fake.serviceUnshareMutex.RLock()
+	defer fake.serviceUnshareMutex.RUnlock()
+	argsForCall := fake.serviceUnshareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceUnshareReturns(result1 models.ServiceUnshareResponse, result2 error) {
+	fake.serviceUnshareMutex.Lock()
+	defer fake.serviceUnshareMutex.Unlock()
+	fake.ServiceUnshareStub = nil
+	fake.serviceUnshareReturns = struct {
+		result1 models.ServiceUnshareResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceUnshareReturnsOnCall(i int, result1 models.ServiceUnshareResponse, result2 error) {
+	fake.serviceUnshareMutex.Lock()
+	defer fake.serviceUnshareMutex.Unlock()
+	fake.ServiceUnshareStub = nil
+	if fake.serviceUnshareReturnsOnCall == nil {
+		fake.serviceUnshareReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceUnshareResponse
+			result2 error
+		})
+	}
+	fake.serviceUnshareReturnsOnCall[i] = struct {
+		result1 models.ServiceUnshareResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceUpdate(arg1 models.ServiceUpdateRequest, arg2 string, arg3 string) (models.ServiceUpdateResponse, error) {
+	fake.serviceUpdateMutex.Lock()
+	ret, specificReturn := fake.serviceUpdateReturnsOnCall[len(fake.serviceUpdateArgsForCall)]
+	fake.serviceUpdateArgsForCall = append(fake.serviceUpdateArgsForCall, struct {
+		arg1 models.ServiceUpdateRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceUpdateStub
+	fakeReturns := fake.serviceUpdateReturns
+	fake.recordInvocation("ServiceUpdate", []interface{}{arg1, arg2, arg3})
+	fake.serviceUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceUpdateCallCount() int {
+	fake.serviceUpdateMutex.RLock()
+	defer fake.serviceUpdateMutex.RUnlock()
+	return len(fake.serviceUpdateArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceUpdateCalls(stub func(models.ServiceUpdateRequest, string, string) (models.ServiceUpdateResponse, error)) {
+	fake.serviceUpdateMutex.Lock()
+	defer fake.serviceUpdateMutex.Unlock()
+	fake.ServiceUpdateStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceUpdateArgsForCall(i int) (models.ServiceUpdateRequest, string, string) {
+	fake.serviceUpdateMutex.RLock()
+	defer fake.serviceUpdateMutex.RUnlock()
+	argsForCall := fake.serviceUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceUpdateReturns(result1 models.ServiceUpdateResponse, result2 error) {
+	fake.serviceUpdateMutex.Lock()
+	defer fake.serviceUpdateMutex.Unlock()
+	fake.ServiceUpdateStub = nil
+	fake.serviceUpdateReturns = struct {
+		result1 models.ServiceUpdateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceUpdateReturnsOnCall(i int, result1 models.ServiceUpdateResponse, result2 error) {
+	fake.serviceUpdateMutex.Lock()
+	defer fake.serviceUpdateMutex.Unlock()
+	fake.ServiceUpdateStub = nil
+	if fake.serviceUpdateReturnsOnCall == nil {
+		fake.serviceUpdateReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceUpdateResponse
+			result2 error
+		})
+	}
+	fake.serviceUpdateReturnsOnCall[i] = struct {
+		result1 models.ServiceUpdateResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) StagingComplete(arg1 string, arg2 string) (models.Response, error) {
 	fake.stagingCompleteMutex.Lock()
 	ret, specificReturn := fake.stagingCompleteReturnsOnCall[len(fake.stagingCompleteArgsForCall)]
@@ -4369,12 +6547,16 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.allServicesMutex.RUnlock()
 	fake.appCreateMutex.RLock()
 	defer fake.appCreateMutex.RUnlock()
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
 	fake.appDeleteMutex.RLock()
 	defer fake.appDeleteMutex.RUnlock()
 	fake.appDeployMutex.RLock()
 	defer fake.appDeployMutex.RUnlock()
 	fake.appExecMutex.RLock()
 	defer fake.appExecMutex.RUnlock()
+	fake.appExecCommandMutex.RLock()
+	defer fake.appExecCommandMutex.RUnlock()
 	fake.appGetPartMutex.RLock()
 	defer fake.appGetPartMutex.RUnlock()
 	fake.appImportGitMutex.RLock()
@@ -4399,10 +6581,26 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.appUploadMutex.RUnlock()
 	fake.appValidateCVMutex.RLock()
 	defer fake.appValidateCVMutex.RUnlock()
+	fake.appWebhookMutex.RLock()
+	defer fake.appWebhookMutex.RUnlock()
 	fake.appsMutex.RLock()
 	defer fake.appsMutex.RUnlock()
 	fake.authTokenMutex.RLock()
 	defer fake.authTokenMutex.RUnlock()
+	fake.blobPruneMutex.RLock()
+	defer fake.blobPruneMutex.RUnlock()
+	fake.buildEnvListMutex.RLock()
+	defer fake.buildEnvListMutex.RUnlock()
+	fake.buildEnvSetMutex.RLock()
+	defer fake.buildEnvSetMutex.RUnlock()
+	fake.buildEnvUnsetMutex.RLock()
+	defer fake.buildEnvUnsetMutex.RUnlock()
+	fake.catalogServiceAddMutex.RLock()
+	defer fake.catalogServiceAddMutex.RUnlock()
+	fake.catalogServiceDeleteMutex.RLock()
+	defer fake.catalogServiceDeleteMutex.RUnlock()
+	fake.catalogServiceUpdateMutex.RLock()
+	defer fake.catalogServiceUpdateMutex.RUnlock()
 	fake.chartListMutex.RLock()
 	defer fake.chartListMutex.RUnlock()
 	fake.chartMatchMutex.RLock()
@@ -4439,18 +6637,40 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.envShowMutex.RUnlock()
 	fake.envUnsetMutex.RLock()
 	defer fake.envUnsetMutex.RUnlock()
+	fake.imageGCMutex.RLock()
+	defer fake.imageGCMutex.RUnlock()
 	fake.infoMutex.RLock()
 	defer fake.infoMutex.RUnlock()
 	fake.namespaceCreateMutex.RLock()
 	defer fake.namespaceCreateMutex.RUnlock()
 	fake.namespaceDeleteMutex.RLock()
 	defer fake.namespaceDeleteMutex.RUnlock()
+	fake.namespaceEventsMutex.RLock()
+	defer fake.namespaceEventsMutex.RUnlock()
+	fake.namespaceMemberAddMutex.RLock()
+	defer fake.namespaceMemberAddMutex.RUnlock()
+	fake.namespaceMemberRemoveMutex.RLock()
+	defer fake.namespaceMemberRemoveMutex.RUnlock()
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
 	fake.namespaceShowMutex.RLock()
 	defer fake.namespaceShowMutex.RUnlock()
 	fake.namespacesMutex.RLock()
 	defer fake.namespacesMutex.RUnlock()
 	fake.namespacesMatchMutex.RLock()
 	defer fake.namespacesMatchMutex.RUnlock()
+	fake.registryLoginMutex.RLock()
+	defer fake.registryLoginMutex.RUnlock()
+	fake.registryLoginsMutex.RLock()
+	defer fake.registryLoginsMutex.RUnlock()
+	fake.registryLogoutMutex.RLock()
+	defer fake.registryLogoutMutex.RUnlock()
+	fake.serviceBackupMutex.RLock()
+	defer fake.serviceBackupMutex.RUnlock()
+	fake.serviceBackupScheduleMutex.RLock()
+	defer fake.serviceBackupScheduleMutex.RUnlock()
+	fake.serviceBackupsMutex.RLock()
+	defer fake.serviceBackupsMutex.RUnlock()
 	fake.serviceBindMutex.RLock()
 	defer fake.serviceBindMutex.RUnlock()
 	fake.serviceCatalogMutex.RLock()
@@ -4467,10 +6687,24 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.serviceListMutex.RUnlock()
 	fake.serviceMatchMutex.RLock()
 	defer fake.serviceMatchMutex.RUnlock()
+	fake.servicePortForwardMutex.RLock()
+	defer fake.servicePortForwardMutex.RUnlock()
+	fake.serviceReadyMutex.RLock()
+	defer fake.serviceReadyMutex.RUnlock()
+	fake.serviceRestoreMutex.RLock()
+	defer fake.serviceRestoreMutex.RUnlock()
+	fake.serviceRotateCredentialsMutex.RLock()
+	defer fake.serviceRotateCredentialsMutex.RUnlock()
+	fake.serviceShareMutex.RLock()
+	defer fake.serviceShareMutex.RUnlock()
 	fake.serviceShowMutex.RLock()
 	defer fake.serviceShowMutex.RUnlock()
 	fake.serviceUnbindMutex.RLock()
 	defer fake.serviceUnbindMutex.RUnlock()
+	fake.serviceUnshareMutex.RLock()
+	defer fake.serviceUnshareMutex.RUnlock()
+	fake.serviceUpdateMutex.RLock()
+	defer fake.serviceUpdateMutex.RUnlock()
 	fake.stagingCompleteMutex.RLock()
 	defer fake.stagingCompleteMutex.RUnlock()
 	fake.versionWarningEnabledMutex.RLock()
@@ -11,6 +11,7 @@ package configurations
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -31,19 +32,30 @@ const (
 	ConfigurationLabelKey       = "epinio.io/configuration"
 	ConfigurationTypeLabelKey   = "epinio.io/configuration-type"
 	ConfigurationOriginLabelKey = "epinio.io/configuration-origin"
+
+	// ConfigurationOriginNamespaceLabelKey marks the read-only configurations projected
+	// into other namespaces by sharing a service. It holds the namespace of the service.
+	ConfigurationOriginNamespaceLabelKey = "epinio.io/configuration-origin-namespace"
+
+	// ConfigurationRestartAnnotation holds the restart policy of the configuration, if
+	// not the default.
+	ConfigurationRestartAnnotation = "epinio.io/configuration-restart"
 )
 
 type ConfigurationList []*Configuration
 
 // Configuration contains the information needed for Epinio to address a specific configuration.
 type Configuration struct {
-	Name       string
-	namespace  string
-	Username   string
-	Type       string
-	Origin     string
-	CreatedAt  metav1.Time
-	kubeClient *kubernetes.Cluster
+	Name            string
+	namespace       string
+	Username        string
+	Type            string
+	Origin          string
+	OriginNamespace string            // Set for the configurations of a shared service
+	External        map[string]string // Keys taken from external secret stores, to their references
+	Restart         models.ConfigurationRestartPolicy
+	CreatedAt       metav1.Time
+	kubeClient      *kubernetes.Cluster
 }
 
 // Lookup locates a Configuration by namespace and name.
@@ -63,6 +75,9 @@ func Lookup(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, conf
 	c.Username = s.ObjectMeta.Annotations[models.EpinioCreatedByAnnotation]
 	c.Type = s.ObjectMeta.Labels["epinio.io/configuration-type"]
 	c.Origin = s.ObjectMeta.Labels["epinio.io/configuration-origin"]
+	c.OriginNamespace = s.ObjectMeta.Labels[ConfigurationOriginNamespaceLabelKey]
+	c.External = externalReferences(s)
+	c.Restart = models.ConfigurationRestartPolicy(s.ObjectMeta.Annotations[ConfigurationRestartAnnotation])
 	c.CreatedAt = s.ObjectMeta.CreationTimestamp
 
 	return c, nil
@@ -97,19 +112,23 @@ func List(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (C
 
 	result := ConfigurationList{}
 
-	for _, c := range secrets.Items {
+	for i, c := range secrets.Items {
 		username := c.ObjectMeta.Annotations[models.EpinioCreatedByAnnotation]
 		ctype := c.ObjectMeta.Labels["epinio.io/configuration-type"]
 		origin := c.ObjectMeta.Labels["epinio.io/configuration-origin"]
+		originNamespace := c.ObjectMeta.Labels[ConfigurationOriginNamespaceLabelKey]
 
 		result = append(result, &Configuration{
-			CreatedAt:  c.ObjectMeta.CreationTimestamp,
-			Name:       c.Name,
-			namespace:  c.Namespace,
-			Username:   username,
-			kubeClient: cluster,
-			Type:       ctype,
-			Origin:     origin,
+			CreatedAt:       c.ObjectMeta.CreationTimestamp,
+			Name:            c.Name,
+			namespace:       c.Namespace,
+			Username:        username,
+			kubeClient:      cluster,
+			Type:            ctype,
+			Origin:          origin,
+			OriginNamespace: originNamespace,
+			External:        externalReferences(&secrets.Items[i]),
+			Restart:         models.ConfigurationRestartPolicy(c.ObjectMeta.Annotations[ConfigurationRestartAnnotation]),
 		})
 	}
 
@@ -117,9 +136,10 @@ func List(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (C
 }
 
 // CreateConfiguration creates a new  configuration instance from namespace,
-// name, and a map of parameters.
+// name, and a map of parameters. The references of the parameters taken from external
+// secret stores are recorded for their synchronization, see SyncExternal.
 func CreateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, name, namespace, username string,
-	data map[string]string) (*Configuration, error) {
+	data, external map[string]string, restart models.ConfigurationRestartPolicy) (*Configuration, error) {
 
 	_, err := cluster.GetSecret(ctx, namespace, name)
 	if err == nil {
@@ -142,11 +162,20 @@ func CreateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, name,
 		// FIXME: Move version info to separate package!
 	}
 
-	annotations := map[string]string{
-		models.EpinioCreatedByAnnotation: username,
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				models.EpinioCreatedByAnnotation: username,
+			},
+		},
 	}
+	err = setExternalReferences(secret, external)
+	if err != nil {
+		return nil, err
+	}
+	setRestartPolicy(secret, restart)
 
-	err = cluster.CreateLabeledSecret(ctx, namespace, name, sdata, labels, annotations)
+	err = cluster.CreateLabeledSecret(ctx, namespace, name, sdata, labels, secret.Annotations)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +188,8 @@ func CreateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, name,
 }
 
 // UpdateConfiguration modifies an existing configuration as per the instructions and writes
-// the result back to the resource.
+// the result back to the resource. Changed keys are no longer taken from external secret
+// stores.
 func UpdateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, changes models.ConfigurationUpdateRequest) error {
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		secret, err := configuration.GetSecret(ctx)
@@ -174,13 +204,30 @@ func UpdateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, confi
 			secret.Data[key] = []byte(value)
 		}
 
+		references := externalReferences(secret)
+		for _, key := range changes.Remove {
+			delete(references, key)
+		}
+		for key := range changes.Set {
+			delete(references, key)
+		}
+		err = setExternalReferences(secret, references)
+		if err != nil {
+			return err
+		}
+
+		if changes.Restart != "" {
+			setRestartPolicy(secret, changes.Restart)
+		}
+
 		_, err = cluster.Kubectl.CoreV1().Secrets(configuration.Namespace()).Update(
 			ctx, secret, metav1.UpdateOptions{})
 		return err
 	})
 }
 
-// ReplaceConfiguration replaces an existing configuration
+// ReplaceConfiguration replaces an existing configuration. None of its keys are taken from
+// external secret stores anymore.
 func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, data map[string]string) (bool, error) {
 	secret, err := configuration.GetSecret(ctx)
 	if err != nil {
@@ -193,10 +240,15 @@ func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, conf
 	for k, v := range data {
 		secret.Data[k] = []byte(v)
 	}
-	if reflect.DeepEqual(oldData, secret.Data) {
+	if reflect.DeepEqual(oldData, secret.Data) && len(externalReferences(secret)) == 0 {
 		return false, nil
 	}
 
+	err = setExternalReferences(secret, nil)
+	if err != nil {
+		return false, err
+	}
+
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		_, err = cluster.Kubectl.CoreV1().Secrets(configuration.Namespace()).Update(
 			ctx, secret, metav1.UpdateOptions{})
@@ -209,6 +261,20 @@ func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, conf
 	return true, nil
 }
 
+// setRestartPolicy records the restart policy in the secret of the configuration. The
+// default policy removes the annotation.
+func setRestartPolicy(secret *v1.Secret, restart models.ConfigurationRestartPolicy) {
+	if restart == "" || restart == models.ConfigurationRestartAlways {
+		delete(secret.Annotations, ConfigurationRestartAnnotation)
+		return
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[ConfigurationRestartAnnotation] = restart.String()
+}
+
 // User returns the configuration's username
 func (c *Configuration) User() string {
 	return c.Username
@@ -316,6 +382,78 @@ func LabelServiceSecrets(ctx context.Context, kubeClient *kubernetes.Cluster, se
 	return filteredSecrets, nil
 }
 
+// ShareServiceSecrets projects the configuration secrets of the service into the target
+// namespace, as read-only configurations of the same names. Existing projections are
+// refreshed with the current data of the service's secrets. It returns the names of the
+// projected configurations.
+func ShareServiceSecrets(ctx context.Context, kubeClient *kubernetes.Cluster, service *models.Service, namespace, username string) ([]string, error) {
+	serviceSecrets, err := ForService(ctx, kubeClient, service)
+	if err != nil {
+		return nil, err
+	}
+
+	projected := []string{}
+	for _, secret := range serviceSecrets {
+		existing, err := kubeClient.GetSecret(ctx, namespace, secret.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			if existing.Labels[ConfigurationOriginLabelKey] != service.Meta.Name ||
+				existing.Labels[ConfigurationOriginNamespaceLabelKey] != service.Meta.Namespace {
+				return nil, fmt.Errorf("a secret %s not belonging to the service exists in namespace %s",
+					secret.Name, namespace)
+			}
+
+			existing.Data = secret.Data
+			_, err = kubeClient.Kubectl.CoreV1().Secrets(namespace).Update(ctx, existing, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			labels := map[string]string{
+				ConfigurationLabelKey:                "true",
+				ConfigurationTypeLabelKey:            "service",
+				ConfigurationOriginLabelKey:          service.Meta.Name,
+				ConfigurationOriginNamespaceLabelKey: service.Meta.Namespace,
+				"app.kubernetes.io/name":             "epinio",
+			}
+			annotations := map[string]string{
+				models.EpinioCreatedByAnnotation: username,
+			}
+
+			err = kubeClient.CreateLabeledSecret(ctx, namespace, secret.Name, secret.Data, labels, annotations)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		projected = append(projected, secret.Name)
+	}
+
+	return projected, nil
+}
+
+// ForServiceShare returns the configuration secrets projected into the namespace by
+// sharing the given Service. See ShareServiceSecrets.
+func ForServiceShare(ctx context.Context, kubeClient *kubernetes.Cluster, service *models.Service, namespace string) ([]v1.Secret, error) {
+	secretSelector := labels.Set(map[string]string{
+		ConfigurationLabelKey:                "true",
+		ConfigurationOriginLabelKey:          service.Meta.Name,
+		ConfigurationOriginNamespaceLabelKey: service.Meta.Namespace,
+	}).AsSelector()
+
+	secretList, err := kubeClient.Kubectl.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: secretSelector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secretList.Items, nil
+}
+
 // filterSecretsByType will return a filtered slice of the provided secrets, with the specified secretTypes.
 // It's not possible to use the `in` operator with the FieldSelector during the query
 // so we need to filter them manually.
@@ -0,0 +1,328 @@
+package configurations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+)
+
+// ConfigurationExternalAnnotation on the secret of a configuration holds the references of
+// the values taken from external secret stores, as a JSON object mapping the keys of the
+// configuration to their references.
+const ConfigurationExternalAnnotation = "epinio.io/configuration-external"
+
+// SecretStore resolves the paths of references into an external secret store to values.
+// References are scoped to the namespace of their configuration, a store must not resolve
+// paths belonging to other namespaces.
+type SecretStore interface {
+	Resolve(ctx context.Context, namespace, path string) (string, error)
+}
+
+var (
+	secretStoresMutex sync.RWMutex
+	secretStores      = map[string]SecretStore{}
+)
+
+// RegisterSecretStore makes the secret store available for references of the form
+// `scheme:path`. The server registers the stores it was configured for.
+func RegisterSecretStore(scheme string, store SecretStore) {
+	secretStoresMutex.Lock()
+	defer secretStoresMutex.Unlock()
+
+	secretStores[scheme] = store
+}
+
+// ParseReference splits a reference into the scheme of its secret store and the path
+// within the store.
+func ParseReference(reference string) (string, string, error) {
+	scheme, path, found := strings.Cut(reference, ":")
+	if !found || scheme == "" || path == "" {
+		return "", "", fmt.Errorf("bad reference %s, expected SCHEME:PATH", reference)
+	}
+	return scheme, path, nil
+}
+
+// ResolveExternal resolves the references of the keys, made by a configuration of the
+// namespace, into their values.
+func ResolveExternal(ctx context.Context, namespace string, references map[string]string) (map[string]string, error) {
+	secretStoresMutex.RLock()
+	defer secretStoresMutex.RUnlock()
+
+	values := map[string]string{}
+	for key, reference := range references {
+		scheme, path, err := ParseReference(reference)
+		if err != nil {
+			return nil, err
+		}
+
+		store, ok := secretStores[scheme]
+		if !ok {
+			return nil, fmt.Errorf("unknown secret store %s in reference %s", scheme, reference)
+		}
+
+		value, err := store.Resolve(ctx, namespace, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving %s", reference)
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// FileStore resolves references to the files below its directory, for example secrets
+// mounted into the Epinio server. The files of a namespace are in the sub directory of the
+// same name.
+type FileStore struct {
+	Dir string
+}
+
+// Resolve returns the content of the file. Paths are relative to the directory of the
+// namespace, and cannot leave it.
+func (f FileStore) Resolve(ctx context.Context, namespace, path string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(f.Dir, namespace, filepath.Clean("/"+path)))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// VaultStore resolves references to the fields of the secrets in a HashiCorp Vault, in the
+// form `PATH#FIELD`. Both versions of the key/value secrets engine are supported. The
+// secrets of a namespace are below the path of the same name in the engine, i.e. their
+// paths are `MOUNT/NAMESPACE/...`, or `MOUNT/data/NAMESPACE/...` for version 2.
+type VaultStore struct {
+	Address string
+	Token   string
+	Client  *http.Client
+}
+
+// Resolve returns the value of the field of the secret.
+func (v VaultStore) Resolve(ctx context.Context, namespace, path string) (string, error) {
+	secretPath, field, found := strings.Cut(path, "#")
+	if !found || field == "" {
+		return "", fmt.Errorf("the vault reference %s lacks the #FIELD", path)
+	}
+
+	secretPath = strings.TrimPrefix(pathpkg.Clean("/"+secretPath), "/")
+	if !vaultPathOf(secretPath, namespace) {
+		return "", fmt.Errorf("the vault secret %s is not below the namespace %s", secretPath, namespace)
+	}
+
+	url := strings.TrimSuffix(v.Address, "/") + "/v1/" + secretPath
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("X-Vault-Token", v.Token)
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with %s for %s", response.Status, secretPath)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding the vault response")
+	}
+
+	// Version 2 of the engine nests the fields of the secret next to its metadata.
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("the vault secret %s has no field %s", secretPath, field)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+
+// vaultPathOf returns true if the cleaned secret path is below the namespace, i.e. of the
+// form `MOUNT/NAMESPACE/...`, or `MOUNT/data/NAMESPACE/...`.
+func vaultPathOf(secretPath, namespace string) bool {
+	segments := strings.Split(secretPath, "/")
+	if len(segments) > 2 && segments[1] == "data" {
+		return len(segments) > 3 && segments[2] == namespace
+	}
+	return len(segments) > 2 && segments[1] == namespace
+}
+
+// externalReferences returns the references recorded in the secret of the configuration,
+// if any.
+func externalReferences(secret *v1.Secret) map[string]string {
+	annotation, ok := secret.Annotations[ConfigurationExternalAnnotation]
+	if !ok {
+		return nil
+	}
+
+	references := map[string]string{}
+	if err := json.Unmarshal([]byte(annotation), &references); err != nil {
+		return nil
+	}
+	return references
+}
+
+// setExternalReferences records the references in the secret of the configuration. No
+// references remove the annotation.
+func setExternalReferences(secret *v1.Secret, references map[string]string) error {
+	if len(references) == 0 {
+		delete(secret.Annotations, ConfigurationExternalAnnotation)
+		return nil
+	}
+
+	encoded, err := json.Marshal(references)
+	if err != nil {
+		return err
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[ConfigurationExternalAnnotation] = string(encoded)
+	return nil
+}
+
+// ExternalChangeHandler is called by SyncExternal for each configuration whose values it
+// changed.
+type ExternalChangeHandler func(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string)
+
+// SyncExternal resolves the references of all configurations with external values again,
+// and saves the changed values. Applications see the changes in the mounted files without
+// restart. The onChange handler, if any, is called for each changed configuration, to
+// restart the applications taking its values from the environment. Configurations whose
+// references cannot be resolved are skipped and reported.
+func SyncExternal(ctx context.Context, cluster *kubernetes.Cluster, onChange ExternalChangeHandler) error {
+	log := requestctx.Logger(ctx).WithName("ConfigurationSync")
+
+	secretSelector := labels.Set(map[string]string{
+		ConfigurationLabelKey: "true",
+	}).AsSelector()
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: secretSelector.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+
+		references := externalReferences(secret)
+		if len(references) == 0 {
+			continue
+		}
+
+		values, err := ResolveExternal(ctx, secret.Namespace, references)
+		if err != nil {
+			log.Error(err, "resolving external values", "namespace", secret.Namespace, "configuration", secret.Name)
+			continue
+		}
+
+		changed := false
+		for key, value := range values {
+			if string(secret.Data[key]) != value {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		log.Info("updating external values", "namespace", secret.Namespace, "configuration", secret.Name)
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			current, err := cluster.GetSecret(ctx, secret.Namespace, secret.Name)
+			if err != nil {
+				return err
+			}
+
+			if current.Data == nil {
+				current.Data = map[string][]byte{}
+			}
+			for key, value := range values {
+				current.Data[key] = []byte(value)
+			}
+
+			_, err = cluster.Kubectl.CoreV1().Secrets(secret.Namespace).Update(ctx, current, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			log.Error(err, "updating external values", "namespace", secret.Namespace, "configuration", secret.Name)
+			continue
+		}
+
+		if onChange != nil {
+			onChange(ctx, cluster, secret.Namespace, secret.Name)
+		}
+	}
+
+	return nil
+}
+
+// WatchExternal periodically synchronizes the configurations with external values, see
+// SyncExternal. It returns when the context is canceled.
+func WatchExternal(ctx context.Context, logger logr.Logger, interval time.Duration, onChange ExternalChangeHandler) {
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cluster, err := kubernetes.GetCluster(ctx)
+		if err != nil {
+			logger.Error(err, "configuration sync, no cluster access")
+			continue
+		}
+
+		err = SyncExternal(ctx, cluster, onChange)
+		if err != nil {
+			logger.Error(err, "configuration sync failed")
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package configurations_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/internal/configurations"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseReference", func() {
+	It("splits the scheme from the path", func() {
+		scheme, path, err := configurations.ParseReference("vault:secret/data/db#password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scheme).To(Equal("vault"))
+		Expect(path).To(Equal("secret/data/db#password"))
+	})
+
+	It("rejects references without scheme", func() {
+		_, _, err := configurations.ParseReference("secret/data/db")
+		Expect(err).To(MatchError(ContainSubstring("expected SCHEME:PATH")))
+	})
+})
+
+var _ = Describe("ResolveExternal", func() {
+	It("rejects unknown secret stores", func() {
+		_, err := configurations.ResolveExternal(context.Background(), "workspace", map[string]string{
+			"password": "unknown:db",
+		})
+		Expect(err).To(MatchError(ContainSubstring("unknown secret store unknown")))
+	})
+})
+
+var _ = Describe("FileStore", func() {
+	var store configurations.FileStore
+
+	BeforeEach(func() {
+		store = configurations.FileStore{Dir: GinkgoT().TempDir()}
+		for _, namespace := range []string{"workspace", "other"} {
+			err := os.MkdirAll(filepath.Join(store.Dir, namespace), 0700)
+			Expect(err).ToNot(HaveOccurred())
+			err = os.WriteFile(filepath.Join(store.Dir, namespace, "password"), []byte(namespace+"-s3cret"), 0600)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("returns the content of the file of the namespace", func() {
+		value, err := store.Resolve(context.Background(), "workspace", "password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("workspace-s3cret"))
+	})
+
+	It("stays in the directory of the namespace", func() {
+		value, err := store.Resolve(context.Background(), "workspace", "../other/password")
+		Expect(err).To(HaveOccurred())
+		Expect(value).To(BeEmpty())
+
+		value, err = store.Resolve(context.Background(), "workspace", "../../"+filepath.Base(store.Dir)+"/other/password")
+		Expect(err).To(HaveOccurred())
+		Expect(value).To(BeEmpty())
+	})
+})
+
+var _ = Describe("VaultStore", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			switch r.URL.Path {
+			case "/v1/secret/data/workspace/db":
+				_, _ = w.Write([]byte(`{"data":{"data":{"password":"s3cret"},"metadata":{"version":1}}}`))
+			case "/v1/kv/workspace/db":
+				_, _ = w.Write([]byte(`{"data":{"password":"0ld"}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("returns the field of a secret of either engine version", func() {
+		store := configurations.VaultStore{Address: server.URL, Token: "token"}
+
+		value, err := store.Resolve(context.Background(), "workspace", "secret/data/workspace/db#password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("s3cret"))
+
+		value, err = store.Resolve(context.Background(), "workspace", "kv/workspace/db#password")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal("0ld"))
+	})
+
+	It("reports missing fields and secrets", func() {
+		store := configurations.VaultStore{Address: server.URL, Token: "token"}
+
+		_, err := store.Resolve(context.Background(), "workspace", "kv/workspace/db#user")
+		Expect(err).To(MatchError(ContainSubstring("has no field user")))
+
+		_, err = store.Resolve(context.Background(), "workspace", "kv/workspace/other#password")
+		Expect(err).To(MatchError(ContainSubstring("404")))
+
+		_, err = store.Resolve(context.Background(), "workspace", "kv/workspace/db")
+		Expect(err).To(MatchError(ContainSubstring("lacks the #FIELD")))
+	})
+
+	It("rejects secrets of other namespaces", func() {
+		requested := false
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+		})
+		store := configurations.VaultStore{Address: server.URL, Token: "token"}
+
+		for _, path := range []string{
+			"kv/other/db#password",
+			"secret/data/other/db#password",
+			"kv/workspace/../other/db#password",
+			"kv/db#password",
+			"workspace/db#password",
+		} {
+			_, err := store.Resolve(context.Background(), "workspace", path)
+			Expect(err).To(MatchError(ContainSubstring("is not below the namespace workspace")), path)
+		}
+		Expect(requested).To(BeFalse())
+	})
+})
@@ -0,0 +1,521 @@
+// Package events watches the resources of a namespace and translates their changes into
+// the typed events of the namespace event stream, i.e. changes of applications, their
+// staging and instances, services, and configurations.
+package events
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	thekubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Watcher translates the changes of the resources of a namespace into events.
+type Watcher struct {
+	kube      thekubernetes.Interface
+	apps      dynamic.NamespaceableResourceInterface
+	namespace string
+	// desired returns the number of desired instances of the named application.
+	desired func(ctx context.Context, appName string) (int32, error)
+	// start is the time the watch began. Resources created before are not reported
+	// as created.
+	start time.Time
+	// pods holds the instances of all applications, to count the ready ones. It is
+	// the store of the shared pod informer of the namespace.
+	pods cache.Store
+}
+
+// NewWatcher returns a watcher for the namespace.
+func NewWatcher(cluster *kubernetes.Cluster, namespace string) (*Watcher, error) {
+	apps, err := cluster.ClientApp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		kube:      cluster.Kubectl,
+		apps:      apps,
+		namespace: namespace,
+		desired: func(ctx context.Context, appName string) (int32, error) {
+			return application.Scaling(ctx, cluster, models.NewAppRef(appName, namespace))
+		},
+	}, nil
+}
+
+// Watch sends the events of the namespace to the channel, until the context is done.
+// Only changes after the start of the watch are reported, not the current state.
+// All watches of a namespace share the same informers, see subscribe.
+func (w *Watcher) Watch(ctx context.Context, out chan<- models.Event) error {
+	w.start = time.Now().Truncate(time.Second)
+
+	sub := newSubscriber()
+	set := subscribe(w.kube, w.apps, w.namespace, sub)
+	defer unsubscribe(w.namespace, set, sub)
+	w.pods = set.pods
+
+	if !cache.WaitForCacheSync(ctx.Done(), set.synced...) {
+		return errors.New("failed to sync the informers")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.ready:
+		}
+
+		for _, c := range sub.pop() {
+			for _, event := range w.translate(ctx, c) {
+				event.Namespace = w.namespace
+				event.Time = metav1.Now()
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// translate returns the events for a change of a watched resource.
+func (w *Watcher) translate(ctx context.Context, c change) []models.Event {
+	switch c.kind {
+	case kindApp:
+		return w.appEvents(c.old, c.new)
+	case kindJob:
+		return w.stagingEvents(c.old, c.new)
+	case kindPod:
+		return w.instanceEvents(ctx, c.old, c.new)
+	case kindConfiguration:
+		return w.configurationEvents(c.old, c.new)
+	case kindService:
+		return w.serviceEvents(c.old, c.new)
+	}
+	return nil
+}
+
+// resourceKind identifies the informer a change comes from.
+type resourceKind int
+
+const (
+	kindApp resourceKind = iota
+	kindJob
+	kindPod
+	kindConfiguration
+	kindService
+)
+
+// change is a change of a watched resource. An addition has no old object, and a
+// deletion no new object.
+type change struct {
+	kind     resourceKind
+	old, new interface{}
+}
+
+// subscriber queues the changes for a single watch. The queue is unbounded, so that a
+// slow watch never holds up the informers, and with them the other watches.
+type subscriber struct {
+	mutex   sync.Mutex
+	pending []change
+	// ready is signaled when changes are pending.
+	ready chan struct{}
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{ready: make(chan struct{}, 1)}
+}
+
+// push queues the change.
+func (s *subscriber) push(c change) {
+	s.mutex.Lock()
+	s.pending = append(s.pending, c)
+	s.mutex.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns and clears the pending changes.
+func (s *subscriber) pop() []change {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending := s.pending
+	s.pending = nil
+	return pending
+}
+
+// informerSet holds the informers of a namespace, and the watches subscribed to them.
+// The set runs while it has subscribers.
+type informerSet struct {
+	stop   context.CancelFunc
+	synced []cache.InformerSynced
+	// pods holds the instances of all applications, to count the ready ones.
+	pods cache.Store
+
+	mutex       sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+var (
+	// informerSetsMutex protects informerSets.
+	informerSetsMutex sync.Mutex
+	// informerSets holds the running informer sets, by namespace.
+	informerSets = map[string]*informerSet{}
+)
+
+// subscribe adds the subscriber to the informers of the namespace, starting them if it is
+// the first one.
+func subscribe(kube thekubernetes.Interface, apps dynamic.NamespaceableResourceInterface, namespace string, sub *subscriber) *informerSet {
+	informerSetsMutex.Lock()
+	defer informerSetsMutex.Unlock()
+
+	set, ok := informerSets[namespace]
+	if !ok {
+		set = startInformers(kube, apps, namespace)
+		informerSets[namespace] = set
+	}
+
+	set.mutex.Lock()
+	set.subscribers[sub] = struct{}{}
+	set.mutex.Unlock()
+
+	return set
+}
+
+// unsubscribe removes the subscriber from the informers of the namespace, stopping them if
+// it was the last one.
+func unsubscribe(namespace string, set *informerSet, sub *subscriber) {
+	informerSetsMutex.Lock()
+	defer informerSetsMutex.Unlock()
+
+	set.mutex.Lock()
+	delete(set.subscribers, sub)
+	remaining := len(set.subscribers)
+	set.mutex.Unlock()
+
+	if remaining == 0 {
+		set.stop()
+		delete(informerSets, namespace)
+	}
+}
+
+// startInformers starts the informers of the namespace. They run until the set is stopped.
+func startInformers(kube thekubernetes.Interface, apps dynamic.NamespaceableResourceInterface, namespace string) *informerSet {
+	ctx, stop := context.WithCancel(context.Background())
+
+	selector := func(set map[string]string) func(*metav1.ListOptions) {
+		return func(options *metav1.ListOptions) {
+			options.LabelSelector = labels.Set(set).String()
+		}
+	}
+
+	appInformer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return apps.Namespace(namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return apps.Namespace(namespace).Watch(ctx, options)
+		},
+	}, &unstructured.Unstructured{}, 0, cache.Indexers{})
+
+	jobs := batchinformers.NewFilteredJobInformer(kube, namespace, 0, cache.Indexers{},
+		selector(map[string]string{"app.kubernetes.io/component": "staging"}))
+
+	pods := coreinformers.NewFilteredPodInformer(kube, namespace, 0, cache.Indexers{},
+		selector(map[string]string{"app.kubernetes.io/component": "application"}))
+
+	configurationSecrets := coreinformers.NewFilteredSecretInformer(kube, namespace, 0, cache.Indexers{},
+		selector(map[string]string{configurations.ConfigurationLabelKey: "true"}))
+
+	serviceSecrets := coreinformers.NewFilteredSecretInformer(kube, namespace, 0, cache.Indexers{},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = services.ServiceNameLabelKey
+		})
+
+	set := &informerSet{
+		stop:        stop,
+		pods:        pods.GetStore(),
+		subscribers: map[*subscriber]struct{}{},
+	}
+
+	informers := map[resourceKind]cache.SharedIndexInformer{
+		kindApp:           appInformer,
+		kindJob:           jobs,
+		kindPod:           pods,
+		kindConfiguration: configurationSecrets,
+		kindService:       serviceSecrets,
+	}
+	for kind, informer := range informers {
+		informer.AddEventHandler(set.handler(kind))
+		set.synced = append(set.synced, informer.HasSynced)
+		go informer.Run(ctx.Done())
+	}
+
+	return set
+}
+
+// handler returns the informer handler passing the changes of a resource to all
+// subscribers.
+func (set *informerSet) handler(kind resourceKind) cache.ResourceEventHandler {
+	publish := func(old, new interface{}) {
+		set.mutex.Lock()
+		defer set.mutex.Unlock()
+
+		for sub := range set.subscribers {
+			sub.push(change{kind: kind, old: old, new: new})
+		}
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			publish(nil, obj)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			publish(old, new)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			publish(obj, nil)
+		},
+	}
+}
+
+// created returns true if the resource was created after the start of the watch.
+func (w *Watcher) created(object metav1.Object) bool {
+	return !object.GetCreationTimestamp().Time.Before(w.start)
+}
+
+// appEvents translates the changes of application resources.
+func (w *Watcher) appEvents(old, new interface{}) []models.Event {
+	switch {
+	case old == nil:
+		app, ok := new.(*unstructured.Unstructured)
+		if ok && w.created(app) {
+			return []models.Event{{Type: models.EventAppCreated, Name: app.GetName()}}
+		}
+	case new == nil:
+		if app, ok := old.(*unstructured.Unstructured); ok {
+			return []models.Event{{Type: models.EventAppDeleted, Name: app.GetName()}}
+		}
+	}
+	return nil
+}
+
+// stagingEvents translates the changes of staging jobs.
+func (w *Watcher) stagingEvents(old, new interface{}) []models.Event {
+	job, ok := new.(*batchv1.Job)
+	if !ok {
+		return nil
+	}
+
+	event := models.Event{
+		Name:    job.Labels["app.kubernetes.io/name"],
+		StageID: job.Labels[models.EpinioStageIDLabel],
+	}
+
+	if old == nil {
+		if !w.created(job) {
+			return nil
+		}
+		event.Type = models.EventStagingStarted
+		return []models.Event{event}
+	}
+
+	oldJob, ok := old.(*batchv1.Job)
+	if !ok || jobOutcome(oldJob) != "" {
+		return nil
+	}
+
+	event.Message = jobOutcome(job)
+	if event.Message == "" {
+		return nil
+	}
+	event.Type = models.EventStagingFinished
+	return []models.Event{event}
+}
+
+// jobOutcome returns `succeeded` or `failed` for a finished job, and the empty string
+// for a running one.
+func jobOutcome(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return models.EventMessageSucceeded
+		case batchv1.JobFailed:
+			return models.EventMessageFailed
+		}
+	}
+	return ""
+}
+
+// instanceEvents translates the changes of application pods, i.e. instances, into
+// readiness changes and the deployment progress of their application.
+func (w *Watcher) instanceEvents(ctx context.Context, old, new interface{}) []models.Event {
+	oldPod, _ := old.(*corev1.Pod)
+	newPod, _ := new.(*corev1.Pod)
+
+	pod := newPod
+	if pod == nil {
+		pod = oldPod
+	}
+	if pod == nil {
+		return nil
+	}
+
+	// Existing instances are not reported, only new ones.
+	if oldPod == nil && !w.created(pod) {
+		return nil
+	}
+
+	wasReady := oldPod != nil && podReady(oldPod)
+	isReady := newPod != nil && podReady(newPod)
+	if oldPod != nil && newPod != nil && wasReady == isReady {
+		return nil
+	}
+
+	appName := pod.Labels["app.kubernetes.io/name"]
+	result := []models.Event{}
+
+	if wasReady != isReady {
+		event := models.Event{Type: models.EventInstanceNotReady, Name: appName, Instance: pod.Name}
+		if isReady {
+			event.Type = models.EventInstanceReady
+		}
+		result = append(result, event)
+	}
+
+	progress := models.Event{
+		Type:  models.EventDeploymentProgress,
+		Name:  appName,
+		Ready: w.readyInstances(appName),
+	}
+	if desired, err := w.desired(ctx, appName); err == nil {
+		progress.Desired = desired
+	}
+
+	return append(result, progress)
+}
+
+// readyInstances returns the number of ready instances of the application.
+func (w *Watcher) readyInstances(appName string) int32 {
+	ready := int32(0)
+	for _, obj := range w.pods.List() {
+		pod, ok := obj.(*corev1.Pod)
+		if ok && pod.Labels["app.kubernetes.io/name"] == appName && podReady(pod) {
+			ready++
+		}
+	}
+	return ready
+}
+
+// podReady returns true if the pod is ready, and not terminating.
+func podReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// serviceEvents translates the changes of service secrets, i.e. the phase recorded by
+// the provisioning of the service.
+func (w *Watcher) serviceEvents(old, new interface{}) []models.Event {
+	oldSecret, ok := old.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+	secret, ok := new.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+
+	phase := secret.Annotations[services.ServicePhaseAnnotation]
+	if phase == oldSecret.Annotations[services.ServicePhaseAnnotation] {
+		return nil
+	}
+
+	event := models.Event{Name: secret.Labels[services.ServiceNameLabelKey]}
+	switch models.ServicePhase(phase) {
+	case models.ServicePhaseReady:
+		event.Type = models.EventServiceReady
+	case models.ServicePhaseFailed:
+		event.Type = models.EventServiceFailed
+		event.Message = secret.Annotations[services.ServiceFailureAnnotation]
+	default:
+		return nil
+	}
+
+	return []models.Event{event}
+}
+
+// configurationEvents translates the changes of configuration secrets.
+func (w *Watcher) configurationEvents(old, new interface{}) []models.Event {
+	event := models.Event{Type: models.EventConfigurationChanged}
+
+	switch {
+	case old == nil:
+		secret, ok := new.(*corev1.Secret)
+		if !ok || !w.created(secret) {
+			return nil
+		}
+		event.Name = secret.Name
+		event.Message = models.EventMessageCreated
+	case new == nil:
+		secret, ok := old.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+		event.Name = secret.Name
+		event.Message = models.EventMessageDeleted
+	default:
+		oldSecret, ok := old.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+		secret, ok := new.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+		// Changes of labels and annotations, e.g. by bindings, do not change the
+		// configuration.
+		if reflect.DeepEqual(oldSecret.Data, secret.Data) {
+			return nil
+		}
+		event.Name = secret.Name
+		event.Message = models.EventMessageUpdated
+	}
+
+	return []models.Event{event}
+}
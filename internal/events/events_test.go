@@ -0,0 +1,320 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Watcher", func() {
+	var w *Watcher
+	var before, after metav1.Time
+
+	BeforeEach(func() {
+		start := time.Now().Truncate(time.Second)
+		before = metav1.NewTime(start.Add(-time.Hour))
+		after = metav1.NewTime(start)
+
+		w = &Watcher{
+			namespace: "workspace",
+			start:     start,
+			pods:      cache.NewStore(cache.MetaNamespaceKeyFunc),
+			desired: func(ctx context.Context, appName string) (int32, error) {
+				return 2, nil
+			},
+		}
+	})
+
+	newApp := func(created metav1.Time) *unstructured.Unstructured {
+		app := &unstructured.Unstructured{}
+		app.SetName("myapp")
+		app.SetCreationTimestamp(created)
+		return app
+	}
+
+	newJob := func(created metav1.Time, conditions ...batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:              "stage-1",
+			CreationTimestamp: created,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":  "myapp",
+				models.EpinioStageIDLabel: "1",
+			},
+		}}
+		for _, condition := range conditions {
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+				Type: condition, Status: corev1.ConditionTrue,
+			})
+		}
+		return job
+	}
+
+	newPod := func(name string, created metav1.Time, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "workspace",
+				CreationTimestamp: created,
+				Labels:            map[string]string{"app.kubernetes.io/name": "myapp"},
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status},
+			}},
+		}
+	}
+
+	newServiceSecret := func(phase models.ServicePhase, reason string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:   "s-mydb",
+			Labels: map[string]string{services.ServiceNameLabelKey: "mydb"},
+			Annotations: map[string]string{
+				services.ServicePhaseAnnotation:   phase.String(),
+				services.ServiceFailureAnnotation: reason,
+			},
+		}}
+	}
+
+	Describe("appEvents", func() {
+		It("reports applications created after the start", func() {
+			Expect(w.appEvents(nil, newApp(after))).To(Equal([]models.Event{
+				{Type: models.EventAppCreated, Name: "myapp"},
+			}))
+			Expect(w.appEvents(nil, newApp(before))).To(BeEmpty())
+		})
+
+		It("reports deleted applications", func() {
+			Expect(w.appEvents(newApp(before), nil)).To(Equal([]models.Event{
+				{Type: models.EventAppDeleted, Name: "myapp"},
+			}))
+		})
+	})
+
+	Describe("stagingEvents", func() {
+		It("reports the start of new staging jobs", func() {
+			Expect(w.stagingEvents(nil, newJob(after))).To(Equal([]models.Event{
+				{Type: models.EventStagingStarted, Name: "myapp", StageID: "1"},
+			}))
+			Expect(w.stagingEvents(nil, newJob(before))).To(BeEmpty())
+		})
+
+		It("reports the outcome of finishing jobs once", func() {
+			Expect(w.stagingEvents(newJob(before), newJob(before, batchv1.JobComplete))).To(Equal([]models.Event{
+				{Type: models.EventStagingFinished, Name: "myapp", StageID: "1", Message: "succeeded"},
+			}))
+			Expect(w.stagingEvents(newJob(before), newJob(before, batchv1.JobFailed))).To(Equal([]models.Event{
+				{Type: models.EventStagingFinished, Name: "myapp", StageID: "1", Message: "failed"},
+			}))
+			Expect(w.stagingEvents(newJob(before, batchv1.JobComplete), newJob(before, batchv1.JobComplete))).To(BeEmpty())
+			Expect(w.stagingEvents(newJob(before), newJob(before))).To(BeEmpty())
+		})
+	})
+
+	Describe("instanceEvents", func() {
+		It("reports readiness changes with the deployment progress", func() {
+			other := newPod("myapp-1", before, true)
+			pod := newPod("myapp-0", before, true)
+			Expect(w.pods.Add(other)).To(Succeed())
+			Expect(w.pods.Add(pod)).To(Succeed())
+
+			Expect(w.instanceEvents(context.Background(), newPod("myapp-0", before, false), pod)).To(Equal([]models.Event{
+				{Type: models.EventInstanceReady, Name: "myapp", Instance: "myapp-0"},
+				{Type: models.EventDeploymentProgress, Name: "myapp", Ready: 2, Desired: 2},
+			}))
+		})
+
+		It("ignores changes without readiness change", func() {
+			Expect(w.instanceEvents(context.Background(),
+				newPod("myapp-0", before, true), newPod("myapp-0", before, true))).To(BeEmpty())
+		})
+
+		It("reports the progress for new instances only", func() {
+			Expect(w.instanceEvents(context.Background(), nil, newPod("myapp-0", after, false))).To(Equal([]models.Event{
+				{Type: models.EventDeploymentProgress, Name: "myapp", Ready: 0, Desired: 2},
+			}))
+			Expect(w.instanceEvents(context.Background(), nil, newPod("myapp-0", before, false))).To(BeEmpty())
+		})
+
+		It("reports removed ready instances", func() {
+			Expect(w.instanceEvents(context.Background(), newPod("myapp-0", before, true), nil)).To(Equal([]models.Event{
+				{Type: models.EventInstanceNotReady, Name: "myapp", Instance: "myapp-0"},
+				{Type: models.EventDeploymentProgress, Name: "myapp", Ready: 0, Desired: 2},
+			}))
+		})
+	})
+
+	Describe("serviceEvents", func() {
+		It("reports the end of the provisioning", func() {
+			Expect(w.serviceEvents(newServiceSecret(models.ServicePhaseInstalling, ""),
+				newServiceSecret(models.ServicePhaseReady, ""))).To(Equal([]models.Event{
+				{Type: models.EventServiceReady, Name: "mydb"},
+			}))
+			Expect(w.serviceEvents(newServiceSecret(models.ServicePhaseInstalling, ""),
+				newServiceSecret(models.ServicePhaseFailed, "timeout"))).To(Equal([]models.Event{
+				{Type: models.EventServiceFailed, Name: "mydb", Message: "timeout"},
+			}))
+		})
+
+		It("ignores other changes", func() {
+			Expect(w.serviceEvents(newServiceSecret(models.ServicePhaseReady, ""),
+				newServiceSecret(models.ServicePhaseReady, ""))).To(BeEmpty())
+			Expect(w.serviceEvents(newServiceSecret(models.ServicePhasePending, ""),
+				newServiceSecret(models.ServicePhaseInstalling, ""))).To(BeEmpty())
+			Expect(w.serviceEvents(nil, newServiceSecret(models.ServicePhaseReady, ""))).To(BeEmpty())
+		})
+	})
+
+	Describe("configurationEvents", func() {
+		newConfiguration := func(data map[string][]byte, annotations map[string]string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "myconfig", Annotations: annotations},
+				Data:       data,
+			}
+		}
+
+		It("reports changes of the data", func() {
+			Expect(w.configurationEvents(newConfiguration(map[string][]byte{"user": []byte("a")}, nil),
+				newConfiguration(map[string][]byte{"user": []byte("b")}, nil))).To(Equal([]models.Event{
+				{Type: models.EventConfigurationChanged, Name: "myconfig", Message: models.EventMessageUpdated},
+			}))
+		})
+
+		It("ignores changes of the metadata only", func() {
+			Expect(w.configurationEvents(newConfiguration(map[string][]byte{"user": []byte("a")}, nil),
+				newConfiguration(map[string][]byte{"user": []byte("a")}, map[string]string{"bound": "myapp"}))).To(BeEmpty())
+		})
+	})
+
+	Describe("Watch", func() {
+		It("streams the events of the namespace", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			kube := kubefake.NewSimpleClientset()
+			appsGVR := schema.GroupVersionResource{Group: "application.epinio.io", Version: "v1", Resource: "apps"}
+			dynamic := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{appsGVR: "AppList"})
+
+			w.kube = kube
+			w.apps = dynamic.Resource(appsGVR)
+
+			out := make(chan models.Event)
+			go func() {
+				defer GinkgoRecover()
+				Expect(w.Watch(ctx, out)).To(Succeed())
+			}()
+
+			// The informers start asynchronously. Create until the event is seen.
+			var event models.Event
+			attempt := 0
+			Eventually(func() models.EventType {
+				attempt++
+				secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:              fmt.Sprintf("config-%d", attempt),
+					CreationTimestamp: metav1.Now(),
+					Labels:            map[string]string{configurations.ConfigurationLabelKey: "true"},
+				}}
+				_, err := kube.CoreV1().Secrets("workspace").Create(ctx, secret, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				select {
+				case event = <-out:
+					return event.Type
+				case <-time.After(100 * time.Millisecond):
+					return ""
+				}
+			}, 5*time.Second).Should(Equal(models.EventConfigurationChanged))
+
+			Expect(event.Namespace).To(Equal("workspace"))
+			Expect(event.Message).To(Equal(models.EventMessageCreated))
+		})
+
+		It("shares the informers of the namespace between watches", func() {
+			kube := kubefake.NewSimpleClientset()
+			appsGVR := schema.GroupVersionResource{Group: "application.epinio.io", Version: "v1", Resource: "apps"}
+			dynamic := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{appsGVR: "AppList"})
+
+			watch := func(ctx context.Context, out chan models.Event) {
+				watcher := &Watcher{kube: kube, apps: dynamic.Resource(appsGVR), namespace: "workspace", desired: w.desired}
+				go func() {
+					defer GinkgoRecover()
+					Expect(watcher.Watch(ctx, out)).To(Succeed())
+				}()
+			}
+
+			subscribers := func() int {
+				informerSetsMutex.Lock()
+				defer informerSetsMutex.Unlock()
+
+				set, ok := informerSets["workspace"]
+				if !ok {
+					return 0
+				}
+				set.mutex.Lock()
+				defer set.mutex.Unlock()
+				return len(set.subscribers)
+			}
+
+			// The watch of the previous test stops asynchronously.
+			Eventually(subscribers).Should(Equal(0))
+
+			firstCtx, firstCancel := context.WithCancel(context.Background())
+			defer firstCancel()
+			secondCtx, secondCancel := context.WithCancel(context.Background())
+			defer secondCancel()
+
+			first := make(chan models.Event, 10)
+			second := make(chan models.Event, 10)
+			watch(firstCtx, first)
+			watch(secondCtx, second)
+			Eventually(subscribers).Should(Equal(2))
+
+			informerSetsMutex.Lock()
+			set := informerSets["workspace"]
+			informerSetsMutex.Unlock()
+			Eventually(func() bool {
+				for _, synced := range set.synced {
+					if !synced() {
+						return false
+					}
+				}
+				return true
+			}, 5*time.Second).Should(BeTrue())
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:              "config",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+				Labels:            map[string]string{configurations.ConfigurationLabelKey: "true"},
+			}}
+			_, err := kube.CoreV1().Secrets("workspace").Create(context.Background(), secret, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(first, 5*time.Second).Should(Receive(HaveField("Name", "config")))
+			Eventually(second, 5*time.Second).Should(Receive(HaveField("Name", "config")))
+
+			firstCancel()
+			Eventually(subscribers).Should(Equal(1))
+			secondCancel()
+			Eventually(subscribers).Should(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,189 @@
+package gc
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Blobs prunes the orphaned application source blobs from the S3 storage. A blob is
+// orphaned when neither an application nor a staging job references it. This is the case
+// for failed or abandoned uploads, and for the blobs of deleted namespaces. Only orphans
+// older than `olderThan` are deleted, to not race uploads about to be staged. A dry run
+// only reports what would be deleted.
+func Blobs(ctx context.Context, cluster *kubernetes.Cluster, olderThan time.Duration, dryRun bool) (*models.BlobPruneResponse, error) {
+	log := requestctx.Logger(ctx).WithName("BlobPrune")
+
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := s3manager.New(connectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// The objects are listed before the references are collected. Any blob uploaded and
+	// staged in between is then either not listed, or referenced.
+	objects, err := manager.ListObjects(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Service backups are stored in the same bucket, and are managed separately.
+	blobs := []s3manager.Object{}
+	for _, object := range objects {
+		if !strings.HasPrefix(object.Key, services.BackupPrefix) {
+			blobs = append(blobs, object)
+		}
+	}
+
+	referenced, err := referencedBlobs(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	kept, orphans := selectBlobs(blobs, referenced, time.Now().Add(-olderThan))
+
+	result := &models.BlobPruneResponse{
+		DryRun:  dryRun,
+		Deleted: []models.GCBlob{},
+		Kept:    len(kept),
+	}
+
+	for _, orphan := range orphans {
+		blob := models.GCBlob{
+			BlobUID:      orphan.Key,
+			Size:         orphan.Size,
+			LastModified: metav1.NewTime(orphan.LastModified),
+		}
+
+		// The meta data is informational only, blobs without it are still orphans.
+		meta, err := manager.Meta(ctx, orphan.Key)
+		if err == nil {
+			blob.App = meta["App"]
+			blob.Namespace = meta["Namespace"]
+		}
+
+		result.Deleted = append(result.Deleted, blob)
+		result.Reclaimed += orphan.Size
+
+		if dryRun {
+			continue
+		}
+
+		log.Info("deleting blob", "blobUID", orphan.Key, "app", blob.App, "namespace", blob.Namespace)
+
+		err = manager.DeleteObject(ctx, orphan.Key)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+// WatchBlobs periodically prunes the orphaned application source blobs from the S3
+// storage. It returns when the context is canceled.
+func WatchBlobs(ctx context.Context, logger logr.Logger, interval, olderThan time.Duration) {
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cluster, err := kubernetes.GetCluster(ctx)
+		if err != nil {
+			logger.Error(err, "blob prune, no cluster access")
+			continue
+		}
+
+		result, err := Blobs(ctx, cluster, olderThan, false)
+		if err != nil {
+			logger.Error(err, "blob prune failed")
+			continue
+		}
+
+		logger.Info("blob prune done", "deleted", len(result.Deleted), "kept", result.Kept,
+			"reclaimed", result.Reclaimed, "errors", result.Errors)
+	}
+}
+
+// referencedBlobs returns the uids of all blobs in use. These are the blobs of the
+// applications, and the blobs of the staging jobs.
+func referencedBlobs(ctx context.Context, cluster *kubernetes.Cluster) (map[string]struct{}, error) {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := map[string]struct{}{}
+	for _, app := range list.Items {
+		blobUID, _, err := unstructured.NestedString(app.UnstructuredContent(), "spec", "blobuid")
+		if err != nil {
+			return nil, err
+		}
+		if blobUID != "" {
+			referenced[blobUID] = struct{}{}
+		}
+	}
+
+	jobs, err := cluster.ListJobs(ctx, helmchart.Namespace(), models.EpinioStageBlobUIDLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobs.Items {
+		if blobUID := job.Labels[models.EpinioStageBlobUIDLabel]; blobUID != "" {
+			referenced[blobUID] = struct{}{}
+		}
+	}
+
+	return referenced, nil
+}
+
+// selectBlobs splits the objects into the objects to keep and the orphans to delete.
+// Kept are the referenced objects, and the objects modified after the cutoff. The orphans
+// are sorted by age, oldest first.
+func selectBlobs(objects []s3manager.Object, referenced map[string]struct{}, cutoff time.Time) ([]s3manager.Object, []s3manager.Object) {
+	kept := []s3manager.Object{}
+	orphans := []s3manager.Object{}
+	for _, object := range objects {
+		_, isReferenced := referenced[object.Key]
+		if isReferenced || object.LastModified.After(cutoff) {
+			kept = append(kept, object)
+		} else {
+			orphans = append(orphans, object)
+		}
+	}
+
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return orphans[i].LastModified.Before(orphans[j].LastModified)
+	})
+
+	return kept, orphans
+}
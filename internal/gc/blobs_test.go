@@ -0,0 +1,49 @@
+package gc
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/internal/s3manager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func keys(objects []s3manager.Object) []string {
+	result := []string{}
+	for _, object := range objects {
+		result = append(result, object.Key)
+	}
+	return result
+}
+
+var _ = Describe("selectBlobs", func() {
+	var objects []s3manager.Object
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Now()
+		objects = []s3manager.Object{
+			{Key: "old", LastModified: now.Add(-48 * time.Hour)},
+			{Key: "older", LastModified: now.Add(-72 * time.Hour)},
+			{Key: "recent", LastModified: now.Add(-time.Hour)},
+			{Key: "staged", LastModified: now.Add(-96 * time.Hour)},
+		}
+	})
+
+	It("deletes the unreferenced objects older than the cutoff, oldest first", func() {
+		kept, orphans := selectBlobs(objects, map[string]struct{}{"staged": {}}, now.Add(-24*time.Hour))
+
+		Expect(keys(kept)).To(Equal([]string{"recent", "staged"}))
+		Expect(keys(orphans)).To(Equal([]string{"older", "old"}))
+	})
+
+	It("keeps everything referenced", func() {
+		kept, orphans := selectBlobs(objects, map[string]struct{}{
+			"old": {}, "older": {}, "recent": {}, "staged": {},
+		}, now)
+
+		Expect(kept).To(HaveLen(4))
+		Expect(orphans).To(BeEmpty())
+	})
+})
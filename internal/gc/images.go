@@ -0,0 +1,341 @@
+// Package gc implements the garbage collection of the data Epinio keeps outside of the
+// cluster, i.e. the application images in the Epinio registry, and the application
+// source blobs in the S3 storage.
+package gc
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// image is an application image found in the Epinio registry.
+type image struct {
+	models.GCImage
+	staged  time.Time             // Start of the stage building the image, zero when unknown
+	created time.Time             // Creation time from the image configuration
+	blobs   []registry.Descriptor // Layers and configuration
+}
+
+// repositoryStages are the stages of an application, for the images in its repository.
+type repositoryStages struct {
+	protected map[string]struct{}  // Stages whose images must not be deleted
+	times     map[string]time.Time // Start times of the recent stages
+}
+
+// Images garbage collects the application images in the Epinio registry. For each
+// application the images of the `keep` newest stages are kept, plus the images of the
+// stages referenced by the application and the revisions of its helm release, i.e. the
+// rollback candidates. The images of deleted
+// applications are removed completely, regardless of `keep`. A dry run only reports what
+// would be deleted.
+func Images(ctx context.Context, cluster *kubernetes.Cluster, keep int, dryRun bool) (*models.ImageGCResponse, error) {
+	log := requestctx.Logger(ctx).WithName("ImageGC")
+
+	if keep < 1 {
+		keep = 1
+	}
+
+	client, registryNamespace, err := registry.EpinioClient(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := appStages(ctx, cluster, log, registryNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repositories of deleted applications are only found through the registry catalog.
+	// Not all registries provide it, and without registry namespace the repositories
+	// cannot be told apart from repositories not belonging to Epinio.
+	deletedApps := map[string]struct{}{}
+	if registryNamespace != "" {
+		catalog, err := client.Catalog(ctx)
+		if err != nil {
+			log.Info("registry catalog not available, skipping deleted applications", "error", err.Error())
+		}
+		deletedApps = deletedRepositories(catalog, stages, registryNamespace)
+		for repository := range deletedApps {
+			stages[repository] = repositoryStages{}
+		}
+	}
+
+	repositories := make([]string, 0, len(stages))
+	for repository := range stages {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+
+	result := &models.ImageGCResponse{
+		DryRun:  dryRun,
+		Deleted: []models.GCImage{},
+	}
+
+	var allKept, allDeleted []image
+	for _, repository := range repositories {
+		images, err := repositoryImages(ctx, client, repository)
+		if err != nil {
+			if registry.IsNotFound(err) {
+				// Applications which were never staged have no repository.
+				continue
+			}
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+
+		repositoryKeep := keep
+		if _, ok := deletedApps[repository]; ok {
+			repositoryKeep = 0
+		}
+
+		for i := range images {
+			images[i].staged = stages[repository].times[images[i].Tag]
+		}
+
+		kept, deleted := selectImages(images, repositoryKeep, stages[repository].protected)
+		allKept = append(allKept, kept...)
+		allDeleted = append(allDeleted, deleted...)
+	}
+
+	result.Kept = len(allKept)
+	result.Reclaimed = reclaimable(allKept, allDeleted)
+
+	// Deletion is by digest, removing all tags of the digest at once.
+	done := map[string]struct{}{}
+	for _, deleted := range allDeleted {
+		result.Deleted = append(result.Deleted, deleted.GCImage)
+
+		key := deleted.Repository + "@" + deleted.Digest
+		if _, ok := done[key]; ok || dryRun {
+			continue
+		}
+		done[key] = struct{}{}
+
+		log.Info("deleting image", "repository", deleted.Repository, "tag", deleted.Tag, "digest", deleted.Digest)
+
+		err := client.DeleteManifest(ctx, deleted.Repository, deleted.Digest)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+// WatchImages periodically garbage collects the application images in the Epinio
+// registry. It returns when the context is canceled.
+func WatchImages(ctx context.Context, logger logr.Logger, interval time.Duration, keep int) {
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cluster, err := kubernetes.GetCluster(ctx)
+		if err != nil {
+			logger.Error(err, "image gc, no cluster access")
+			continue
+		}
+
+		result, err := Images(ctx, cluster, keep, false)
+		if err != nil {
+			logger.Error(err, "image gc failed")
+			continue
+		}
+
+		logger.Info("image gc done", "deleted", len(result.Deleted), "kept", result.Kept,
+			"reclaimed", result.Reclaimed, "errors", result.Errors)
+	}
+}
+
+// appStages returns the stages of all applications, organized by repository. Protected
+// are the images of the current stage, and of the stages deployed by the revisions of the
+// application's helm release.
+func appStages(ctx context.Context, cluster *kubernetes.Cluster, log logr.Logger, registryNamespace string) (map[string]repositoryStages, error) {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]repositoryStages{}
+	for i := range list.Items {
+		app := &list.Items[i]
+		appRef := models.NewAppRef(app.GetName(), app.GetNamespace())
+
+		stageIDs, err := helm.StageIDs(cluster, log, appRef)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := application.StageID(app)
+		if err != nil {
+			return nil, err
+		}
+
+		times, err := application.StageTimes(app)
+		if err != nil {
+			log.Info("ignoring stage times", "namespace", appRef.Namespace, "app", appRef.Name, "error", err.Error())
+			times = map[string]time.Time{}
+		}
+
+		protected := map[string]struct{}{}
+		for _, stageID := range append(stageIDs, current) {
+			if stageID != "" {
+				protected[stageID] = struct{}{}
+			}
+		}
+
+		// See stageParam.ImageURL for the naming of application images.
+		repository := path.Join(registryNamespace, appRef.Namespace+"-"+appRef.Name)
+		result[repository] = repositoryStages{
+			protected: protected,
+			times:     times,
+		}
+	}
+
+	return result, nil
+}
+
+// deletedRepositories returns the repositories of the catalog which belong to Epinio,
+// i.e. are in the registry namespace, but to no existing application.
+func deletedRepositories(catalog []string, apps map[string]repositoryStages, registryNamespace string) map[string]struct{} {
+	deleted := map[string]struct{}{}
+	for _, repository := range catalog {
+		if _, ok := apps[repository]; ok {
+			continue
+		}
+		if strings.HasPrefix(repository, registryNamespace+"/") {
+			deleted[repository] = struct{}{}
+		}
+	}
+	return deleted
+}
+
+// repositoryImages returns the images of the repository.
+func repositoryImages(ctx context.Context, client *registry.Client, repository string) ([]image, error) {
+	tags, err := client.Tags(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	images := []image{}
+	for _, tag := range tags {
+		manifest, err := client.Manifest(ctx, repository, tag)
+		if err != nil {
+			if registry.IsNotFound(err) {
+				continue // Deleted concurrently
+			}
+			return nil, err
+		}
+
+		found := image{
+			GCImage: models.GCImage{
+				Repository: repository,
+				Tag:        tag,
+				Digest:     manifest.Digest,
+			},
+			blobs: append(manifest.Layers, manifest.Manifests...),
+		}
+
+		if manifest.Config.Digest != "" {
+			found.blobs = append(found.blobs, manifest.Config)
+
+			found.created, err = client.Created(ctx, repository, manifest.Config.Digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		images = append(images, found)
+	}
+
+	return images, nil
+}
+
+// selectImages splits the images of a repository into the images to keep and the images
+// to delete. Kept are the `keep` newest images, and the images of the protected stages.
+// As deletion is by digest, images sharing the digest of a kept image are kept as well.
+//
+// The images are ordered by the start of their stage. The creation time of the image is
+// only used for images whose stage time is not known, as buildpacks fix it to a constant.
+// Such images are from before the stage times were recorded, and older than the others.
+func selectImages(images []image, keep int, protected map[string]struct{}) ([]image, []image) {
+	sort.SliceStable(images, func(i, j int) bool {
+		a, b := images[i], images[j]
+		if a.staged.IsZero() != b.staged.IsZero() {
+			return !a.staged.IsZero()
+		}
+		if !a.staged.Equal(b.staged) {
+			return a.staged.After(b.staged)
+		}
+		return a.created.After(b.created)
+	})
+
+	keptDigests := map[string]struct{}{}
+	for i, image := range images {
+		_, isProtected := protected[image.Tag]
+		if i < keep || isProtected {
+			keptDigests[image.Digest] = struct{}{}
+		}
+	}
+
+	kept := []image{}
+	deleted := []image{}
+	for _, image := range images {
+		if _, ok := keptDigests[image.Digest]; ok {
+			kept = append(kept, image)
+		} else {
+			deleted = append(deleted, image)
+		}
+	}
+
+	return kept, deleted
+}
+
+// reclaimable returns the size of the blobs of the deleted images which are not used by
+// any of the kept images. Each blob is counted once.
+func reclaimable(kept, deleted []image) int64 {
+	used := map[string]struct{}{}
+	for _, image := range kept {
+		for _, blob := range image.blobs {
+			used[blob.Digest] = struct{}{}
+		}
+	}
+
+	size := int64(0)
+	for _, image := range deleted {
+		for _, blob := range image.blobs {
+			if _, ok := used[blob.Digest]; ok {
+				continue
+			}
+			used[blob.Digest] = struct{}{}
+			size += blob.Size
+		}
+	}
+
+	return size
+}
@@ -0,0 +1,146 @@
+package gc
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newImage(tag, digest string, age int, blobs ...string) image {
+	found := image{
+		GCImage: models.GCImage{
+			Repository: "apps/workspace-sample",
+			Tag:        tag,
+			Digest:     digest,
+		},
+		created: time.Now().Add(-time.Duration(age) * time.Hour),
+	}
+	for _, blob := range blobs {
+		found.blobs = append(found.blobs, registry.Descriptor{Digest: blob, Size: 10})
+	}
+	return found
+}
+
+func tags(images []image) []string {
+	result := []string{}
+	for _, image := range images {
+		result = append(result, image.Tag)
+	}
+	return result
+}
+
+var _ = Describe("selectImages", func() {
+	var images []image
+
+	BeforeEach(func() {
+		images = []image{
+			newImage("old", "sha256:1", 30),
+			newImage("newest", "sha256:4", 1),
+			newImage("older", "sha256:2", 20),
+			newImage("newer", "sha256:3", 10),
+		}
+	})
+
+	It("keeps the newest images", func() {
+		kept, deleted := selectImages(images, 2, map[string]struct{}{})
+
+		Expect(tags(kept)).To(Equal([]string{"newest", "newer"}))
+		Expect(tags(deleted)).To(Equal([]string{"older", "old"}))
+	})
+
+	It("keeps the images of protected stages", func() {
+		kept, deleted := selectImages(images, 1, map[string]struct{}{"old": {}})
+
+		Expect(tags(kept)).To(Equal([]string{"newest", "old"}))
+		Expect(tags(deleted)).To(Equal([]string{"newer", "older"}))
+	})
+
+	It("keeps images sharing the digest of a kept image", func() {
+		images[2].Digest = "sha256:4"
+
+		kept, deleted := selectImages(images, 1, map[string]struct{}{})
+
+		Expect(tags(kept)).To(Equal([]string{"newest", "older"}))
+		Expect(tags(deleted)).To(Equal([]string{"newer", "old"}))
+	})
+
+	When("the images are built by buildpacks", func() {
+		// The lifecycle fixes the creation time of the images for reproducible builds.
+		BeforeEach(func() {
+			fixed := time.Date(1980, 1, 1, 0, 0, 1, 0, time.UTC)
+			for i := range images {
+				images[i].created = fixed
+			}
+		})
+
+		It("keeps the images of the newest stages", func() {
+			now := time.Now()
+			images[0].staged = now.Add(-30 * time.Hour) // old
+			images[1].staged = now.Add(-1 * time.Hour)  // newest
+			images[2].staged = now.Add(-20 * time.Hour) // older
+			images[3].staged = now.Add(-10 * time.Hour) // newer
+
+			kept, deleted := selectImages(images, 2, map[string]struct{}{})
+
+			Expect(tags(kept)).To(Equal([]string{"newest", "newer"}))
+			Expect(tags(deleted)).To(Equal([]string{"older", "old"}))
+		})
+
+		It("considers images of unknown stages older", func() {
+			images[0].staged = time.Now().Add(-30 * time.Hour) // old
+
+			kept, deleted := selectImages(images, 1, map[string]struct{}{})
+
+			Expect(tags(kept)).To(Equal([]string{"old"}))
+			Expect(tags(deleted)).To(ConsistOf("newest", "older", "newer"))
+		})
+
+		It("keeps the rollback candidates", func() {
+			now := time.Now()
+			for i := range images {
+				images[i].staged = now.Add(-time.Duration(i) * time.Hour)
+			}
+
+			kept, deleted := selectImages(images, 1, map[string]struct{}{"newer": {}})
+
+			Expect(tags(kept)).To(Equal([]string{"old", "newer"}))
+			Expect(tags(deleted)).To(Equal([]string{"newest", "older"}))
+		})
+	})
+})
+
+var _ = Describe("deletedRepositories", func() {
+	It("returns the repositories of the registry namespace without application", func() {
+		apps := map[string]repositoryStages{"apps/workspace-live": {}}
+		catalog := []string{"apps/workspace-live", "apps/workspace-gone", "other/image"}
+
+		Expect(deletedRepositories(catalog, apps, "apps")).To(Equal(map[string]struct{}{
+			"apps/workspace-gone": {},
+		}))
+	})
+
+	It("deletes all images of deleted applications", func() {
+		images := []image{newImage("newest", "sha256:2", 1), newImage("old", "sha256:1", 30)}
+
+		kept, deleted := selectImages(images, 0, map[string]struct{}{})
+
+		Expect(kept).To(BeEmpty())
+		Expect(tags(deleted)).To(Equal([]string{"newest", "old"}))
+	})
+})
+
+var _ = Describe("reclaimable", func() {
+	It("counts the blobs used only by deleted images, once", func() {
+		kept := []image{newImage("a", "sha256:1", 1, "base", "app-a")}
+		deleted := []image{
+			newImage("b", "sha256:2", 2, "base", "app-b"),
+			newImage("c", "sha256:3", 3, "base", "app-b", "app-c"),
+		}
+
+		Expect(reclaimable(kept, deleted)).To(Equal(int64(20)))
+	})
+})
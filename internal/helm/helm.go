@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/epinio/epinio/internal/appchart"
 	"github.com/epinio/epinio/internal/domain"
 	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/helmchart"
 	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/internal/routes"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -22,6 +24,8 @@ import (
 	hc "github.com/mittwald/go-helm-client"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	helmrelease "helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
 	helmdriver "helm.sh/helm/v3/pkg/storage/driver"
@@ -43,6 +47,50 @@ type ConfigParameter struct {
 	Path string `yaml:"path"` // Mounting path for configuration
 }
 
+// ConfigEnvParameter exposes the keys of a bound configuration as environment variables.
+// Without keys the chart references all keys of the configuration's secret (`envFrom`),
+// with the prefix. Otherwise it references the selected keys (`valueFrom`).
+//
+// The parameters are handed to the app chart as `epinio.configenv`. Only app charts whose
+// templates use these values support the mapping, see SupportsConfigEnv. Deploying with
+// such bindings is refused for other charts.
+type ConfigEnvParameter struct {
+	Name   string              `yaml:"name"`             // Configuration name
+	Prefix string              `yaml:"prefix,omitempty"` // Prefix of the variables of all keys
+	Keys   []ConfigEnvKeyParam `yaml:"keys,omitempty"`   // Selected keys and their variables
+}
+
+type ConfigEnvKeyParam struct {
+	Key  string `yaml:"key"`  // Key of the configuration
+	Name string `yaml:"name"` // Name of the environment variable
+}
+
+// NewConfigEnvParameter returns the environment variables of the bound configuration, as
+// per the mapping of its binding. The selected keys are ordered by name, for stable values.
+func NewConfigEnvParameter(name string, mapping models.ConfigurationEnvMapping) ConfigEnvParameter {
+	if len(mapping.Keys) == 0 {
+		return ConfigEnvParameter{
+			Name:   name,
+			Prefix: mapping.Prefix,
+		}
+	}
+
+	keys := []string{}
+	for key := range mapping.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	param := ConfigEnvParameter{Name: name}
+	for _, key := range keys {
+		param.Keys = append(param.Keys, ConfigEnvKeyParam{
+			Key:  key,
+			Name: mapping.EnvName(key),
+		})
+	}
+	return param
+}
+
 type ChartParameters struct {
 	models.AppRef                        // Application: name & namespace
 	Context        context.Context       // Operation context
@@ -54,6 +102,7 @@ type ChartParameters struct {
 	StageID        string                // Stage ID that produced ImageURL
 	Environment    models.EnvVariableMap // App Environment
 	Configurations []ConfigParameter     // Bound Configurations (list of names and paths)
+	ConfigEnv      []ConfigEnvParameter  // Bound Configurations exposed as environment variables
 	Routes         []string              // Desired application routes
 	Domains        domain.DomainMap      // Map of domains with secrets covering them
 	Start          *int64                // Nano-epoch of deployment. Optional. Used to force a restart, even when nothing else has changed.
@@ -81,6 +130,36 @@ func Values(cluster *kubernetes.Cluster, logger logr.Logger, app models.AppRef)
 	return yaml, nil
 }
 
+// StageIDs returns the ids of the stages deployed by the revisions of the application's
+// helm release. An application without release has no stages.
+func StageIDs(cluster *kubernetes.Cluster, logger logr.Logger, app models.AppRef) ([]string, error) {
+	client, err := GetHelmClient(cluster.RestConfig, logger, app.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := client.ListReleaseHistory(names.ReleaseName(app.Name), 0)
+	if err != nil {
+		if err == helmdriver.ErrReleaseNotFound {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	stageIDs := []string{}
+	for _, release := range releases {
+		epinio, ok := release.Config["epinio"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if stageID, ok := epinio["stageID"].(string); ok && stageID != "" {
+			stageIDs = append(stageIDs, stageID)
+		}
+	}
+
+	return stageIDs, nil
+}
+
 func Remove(cluster *kubernetes.Cluster, logger logr.Logger, app models.AppRef) error {
 	client, err := GetHelmClient(cluster.RestConfig, logger, app.Namespace)
 	if err != nil {
@@ -116,21 +195,14 @@ func DeployService(logger logr.Logger, parameters ServiceParameters) error {
 		return errors.Wrap(err, "create a helm client")
 	}
 
-	helmChart := parameters.Chart
 	helmVersion := parameters.Version
 	releaseName := names.ServiceReleaseName(parameters.Name)
 
-	if parameters.Repository != "" {
-		name := names.GenerateResourceName("hr-" + base64.StdEncoding.EncodeToString([]byte(parameters.Repository)))
-		if err := client.AddOrUpdateChartRepo(repo.Entry{
-			Name: name,
-			URL:  parameters.Repository,
-		}); err != nil {
-			return errors.Wrap(err, "creating the chart repository")
-		}
-
-		helmChart = fmt.Sprintf("%s/%s", name, helmChart)
+	helmChart, err := serviceChart(client, parameters.Repository, parameters.Chart)
+	if err != nil {
+		return err
 	}
+
 	err = cleanupReleaseIfNeeded(logger, client, releaseName)
 	if err != nil {
 		return errors.Wrap(err, "cleaning up release")
@@ -152,6 +224,48 @@ func DeployService(logger logr.Logger, parameters ServiceParameters) error {
 	return err
 }
 
+// ResolveServiceChart checks that the chart of a catalog service can be pulled in the
+// requested version, from the helm repository, if any.
+func ResolveServiceChart(logger logr.Logger, cluster *kubernetes.Cluster, repository, chart, version string) error {
+	client, err := GetHelmClient(cluster.RestConfig, logger, helmchart.Namespace())
+	if err != nil {
+		return errors.Wrap(err, "create a helm client")
+	}
+
+	helmChart, err := serviceChart(client, repository, chart)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.GetChart(helmChart, &action.ChartPathOptions{
+		Version: version,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "resolving chart %s, version %s", chart, version)
+	}
+
+	return nil
+}
+
+// serviceChart returns the name of the service chart to hand to the helm client. A chart
+// from a helm repository is prefixed with the name of the repository, which is added to
+// the client if needed.
+func serviceChart(client hc.Client, repository, chart string) (string, error) {
+	if repository == "" {
+		return chart, nil
+	}
+
+	name := names.GenerateResourceName("hr-" + base64.StdEncoding.EncodeToString([]byte(repository)))
+	if err := client.AddOrUpdateChartRepo(repo.Entry{
+		Name: name,
+		URL:  repository,
+	}); err != nil {
+		return "", errors.Wrap(err, "creating the chart repository")
+	}
+
+	return fmt.Sprintf("%s/%s", name, chart), nil
+}
+
 func Deploy(logger logr.Logger, parameters ChartParameters) error {
 	// Find the app chart to use for the deployment.
 	appChart, err := appchart.Lookup(parameters.Context, parameters.Cluster, parameters.Chart)
@@ -175,6 +289,7 @@ func Deploy(logger logr.Logger, parameters ChartParameters) error {
 		AppName        string               `yaml:"appName"`
 		Configurations []string             `yaml:"configurations"`
 		ConfigPaths    []ConfigParameter    `yaml:"configpaths"`
+		ConfigEnv      []ConfigEnvParameter `yaml:"configenv,omitempty"`
 		Env            []models.EnvVariable `yaml:"env"`
 		ImageUrl       string               `yaml:"imageURL"`
 		Ingress        string               `yaml:"ingress,omitempty"`
@@ -215,6 +330,7 @@ func Deploy(logger logr.Logger, parameters ChartParameters) error {
 			ReplicaCount:   parameters.Instances,
 			Configurations: configurationNames,
 			ConfigPaths:    parameters.Configurations,
+			ConfigEnv:      parameters.ConfigEnv,
 			StageID:        parameters.StageID,
 			TlsIssuer:      viper.GetString("tls-issuer"),
 			Username:       parameters.Username,
@@ -331,6 +447,18 @@ func Deploy(logger logr.Logger, parameters ChartParameters) error {
 		helmChart = fmt.Sprintf("%s/%s", name, helmChart)
 	}
 
+	// Refuse the configurations bound as environment variables if the app chart ignores
+	// them, instead of silently deploying the application without them.
+	if len(parameters.ConfigEnv) > 0 {
+		loaded, _, err := client.GetChart(helmChart, &action.ChartPathOptions{Version: helmVersion})
+		if err != nil {
+			return errors.Wrap(err, "loading the app chart")
+		}
+		if !SupportsConfigEnv(loaded) {
+			return fmt.Errorf("Unable to deploy. The app chart %s does not support configurations bound as environment variables. Bind them without environment mapping, or use an app chart supporting `epinio.configenv`", parameters.Chart)
+		}
+	}
+
 	releaseName := names.ReleaseName(parameters.Name)
 
 	err = cleanupReleaseIfNeeded(logger, client, releaseName)
@@ -355,6 +483,27 @@ func Deploy(logger logr.Logger, parameters ChartParameters) error {
 	return err
 }
 
+// SupportsConfigEnv returns true if the templates of the app chart use the configurations
+// bound as environment variables, i.e. the `epinio.configenv` values.
+func SupportsConfigEnv(appChart *chart.Chart) bool {
+	for _, template := range appChart.Templates {
+		if strings.Contains(string(template.Data), ".epinio.configenv") {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceRelease returns the helm release of the service instance.
+func ServiceRelease(cluster *kubernetes.Cluster, logger logr.Logger, service models.AppRef) (*helmrelease.Release, error) {
+	client, err := GetHelmClient(cluster.RestConfig, logger, service.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetRelease(names.ServiceReleaseName(service.Name))
+}
+
 func Status(ctx context.Context, logger logr.Logger, cluster *kubernetes.Cluster, namespace, releaseName string) (helmrelease.Status, error) {
 	client, err := GetHelmClient(cluster.RestConfig, logger, namespace)
 	if err != nil {
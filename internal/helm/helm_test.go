@@ -2,6 +2,7 @@ package helm
 
 import (
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"helm.sh/helm/v3/pkg/chart"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -209,3 +210,50 @@ var _ = Describe("ValidateField()", func() {
 		Expect(err.Error()).To(Equal(`Setting "field": Expected boolean, got "hound"`))
 	})
 })
+
+var _ = Describe("NewConfigEnvParameter()", func() {
+
+	It("exposes all keys with the prefix", func() {
+		param := NewConfigEnvParameter("db", models.ConfigurationEnvMapping{
+			Prefix: "DB_",
+		})
+		Expect(param).To(Equal(ConfigEnvParameter{
+			Name:   "db",
+			Prefix: "DB_",
+		}))
+	})
+
+	It("exposes the selected keys, renamed and ordered", func() {
+		param := NewConfigEnvParameter("db", models.ConfigurationEnvMapping{
+			Prefix: "APP_",
+			Keys: map[string]string{
+				"url":      "DATABASE_URL",
+				"password": "",
+			},
+		})
+		Expect(param).To(Equal(ConfigEnvParameter{
+			Name: "db",
+			Keys: []ConfigEnvKeyParam{
+				{Key: "password", Name: "APP_password"},
+				{Key: "url", Name: "APP_DATABASE_URL"},
+			},
+		}))
+	})
+})
+
+var _ = Describe("SupportsConfigEnv()", func() {
+	It("accepts an app chart using the configurations bound as environment variables", func() {
+		appChart := &chart.Chart{Templates: []*chart.File{
+			{Name: "templates/service.yaml", Data: []byte(`port: 8080`)},
+			{Name: "templates/deployment.yaml", Data: []byte(`{{- range .Values.epinio.configenv }}`)},
+		}}
+		Expect(SupportsConfigEnv(appChart)).To(BeTrue())
+	})
+
+	It("rejects an app chart ignoring them", func() {
+		appChart := &chart.Chart{Templates: []*chart.File{
+			{Name: "templates/deployment.yaml", Data: []byte(`{{- range .Values.epinio.configpaths }}`)},
+		}}
+		Expect(SupportsConfigEnv(appChart)).To(BeFalse())
+	})
+})
@@ -187,6 +187,44 @@ func UpdateSources(manifest models.ApplicationManifest, cmd *cobra.Command) (mod
 		}
 	}
 
+	// Git options - Modify the git origin, from options or manifest
+
+	credentials, err := cmd.Flags().GetString("git-credentials")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --git-credentials")
+	}
+
+	submodules, err := cmd.Flags().GetBool("git-submodules")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --git-submodules")
+	}
+
+	if credentials != "" || cmd.Flags().Changed("git-submodules") {
+		if manifest.Origin.Kind != models.OriginGit {
+			return manifest, errors.New("Cannot use `--git-credentials` and `--git-submodules` without git origin")
+		}
+		if credentials != "" {
+			manifest.Origin.Git.Credentials = credentials
+		}
+		if cmd.Flags().Changed("git-submodules") {
+			manifest.Origin.Git.Submodules = submodules
+		}
+	}
+
+	// Container options - Modify the container origin, from options or manifest
+
+	track, err := cmd.Flags().GetBool("container-image-track")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --container-image-track")
+	}
+
+	if cmd.Flags().Changed("container-image-track") {
+		if manifest.Origin.Kind != models.OriginContainer {
+			return manifest, errors.New("Cannot use `--container-image-track` without container origin")
+		}
+		manifest.Origin.Track = track
+	}
+
 	return manifest, nil
 }
 
@@ -330,6 +368,12 @@ func UpdateChartValues(manifest models.ApplicationManifest, cmd *cobra.Command)
 // memory. Note that a missing file is not an error. It simply maps to
 // an empty manifest.
 func Get(manifestPath string) (models.ApplicationManifest, error) {
+	return Load(manifestPath, Options{})
+}
+
+// Load is Get with templating. The overlay is merged over the manifest, and the
+// variables are interpolated, before the manifest is read into memory. See Render.
+func Load(manifestPath string, options Options) (models.ApplicationManifest, error) {
 
 	// Empty manifest, for errors
 	empty := models.ApplicationManifest{}
@@ -353,20 +397,26 @@ func Get(manifestPath string) (models.ApplicationManifest, error) {
 	// Note: Builder defaults to empty string - Insertion of Default builder happens server side.
 	manifest := models.ApplicationManifest{
 		Self:    "<<Defaults>>",
-		Origin:  defaultOrigin,
 		Staging: models.ApplicationStage{},
 	}
+	if !options.NoDefaultOrigin {
+		manifest.Origin = defaultOrigin
+	}
 
 	if !manifestExists {
+		if options.Overlay != "" {
+			return empty, errors.Errorf("the overlay %s requires the manifest %s", options.Overlay, manifestPath)
+		}
+
 		// Without manifest we simply provide the defaults for app sources and
 		// builder.
 
 		return manifest, nil
 	}
 
-	yamlFile, err := os.ReadFile(manifestPath)
+	yamlFile, err := Render(manifestPath, options)
 	if err != nil {
-		return empty, errors.Wrapf(err, "filesystem error")
+		return empty, err
 	}
 
 	// Modified manifest 2. Remove default origin - would clash with the unmarshalled
@@ -379,44 +429,55 @@ func Get(manifestPath string) (models.ApplicationManifest, error) {
 		return empty, errors.Wrapf(err, "bad yaml")
 	}
 
-	// Verify that origin information is one-of only.
-
 	manifest.Self = manifestPath
 
+	hasOrigin, err := resolveOrigin(&manifest.Origin, filepath.Dir(manifestPath))
+	if err != nil {
+		return empty, err
+	}
+
+	// Add default location (manifest directory) back, if needed
+	if !hasOrigin && !options.NoDefaultOrigin {
+		manifest.Origin = defaultOrigin
+	}
+
+	return manifest, nil
+}
+
+// resolveOrigin sets the kind of the origin read from a manifest, and resolves a relative
+// path to the app sources against the directory of the manifest file. The result is
+// false when the manifest specified no origin.
+func resolveOrigin(origin *models.ApplicationOrigin, dir string) (bool, error) {
+
+	// Verify that origin information is one-of only.
+
 	origins := 0
-	if manifest.Origin.Path != "" {
-		manifest.Origin.Kind = models.OriginPath
+	if origin.Path != "" {
+		origin.Kind = models.OriginPath
 		origins++
 	}
 
-	if manifest.Origin.Container != "" {
-		manifest.Origin.Kind = models.OriginContainer
+	if origin.Container != "" {
+		origin.Kind = models.OriginContainer
 		origins++
 	}
 
-	if manifest.Origin.Git != nil && manifest.Origin.Git.URL != "" {
-		manifest.Origin.Kind = models.OriginGit
+	if origin.Git != nil && origin.Git.URL != "" {
+		origin.Kind = models.OriginGit
 		origins++
 	}
 
 	if origins > 1 {
-		return empty, errors.New("Cannot use `path`, `git`, and `container` keys together")
-	}
-
-	// Add default location (manifest directory) back, if needed
-	if origins == 0 {
-		manifest.Origin = defaultOrigin
+		return false, errors.New("Cannot use `path`, `git`, and `container` keys together")
 	}
 
 	// Resolve relative path to app sources, relative to manifest file directory
-	if manifest.Origin.Kind == models.OriginPath &&
-		!filepath.IsAbs(manifest.Origin.Path) {
-		manifest.Origin.Path = filepath.Join(
-			filepath.Dir(manifestPath),
-			manifest.Origin.Path)
+	if origin.Kind == models.OriginPath &&
+		!filepath.IsAbs(origin.Path) {
+		origin.Path = filepath.Join(dir, origin.Path)
 	}
 
-	return manifest, nil
+	return origins > 0, nil
 }
 
 // instances checks if the user provided an instance count. If they didn't, then we'll
@@ -85,6 +85,8 @@ d: e`), 0600)
 				err := os.WriteFile("goodyaml.yml", []byte(`name: foo
 staging:
   builder: snafu
+  environment:
+    NPM_TOKEN: secret
 origin:
   git:
     revision: off
@@ -135,10 +137,42 @@ configuration:
 					},
 					Staging: models.ApplicationStage{
 						Builder: "snafu",
+						Environment: models.EnvVariableMap{
+							"NPM_TOKEN": "secret",
+						},
 					},
 				}))
 
 			})
 		})
 	})
+
+	Describe("Load", func() {
+		When("the manifest specifies no origin", func() {
+			BeforeEach(func() {
+				err := os.WriteFile("noorigin.yml", []byte("name: foo\n"), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				err := os.Remove("noorigin.yml")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("defaults the origin to the directory of the manifest", func() {
+				m, err := manifest.Load("noorigin.yml", manifest.Options{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(m.Origin).To(Equal(models.ApplicationOrigin{
+					Kind: models.OriginPath,
+					Path: workdir,
+				}))
+			})
+
+			It("leaves the origin unset on request", func() {
+				m, err := manifest.Load("noorigin.yml", manifest.Options{NoDefaultOrigin: true})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(m.Origin).To(Equal(models.ApplicationOrigin{}))
+			})
+		})
+	})
 })
@@ -0,0 +1,108 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// GetStack reads the multi-document stack manifest at the specified path into memory.
+// Each document declares its `kind`, one of `app`, `configuration`, and `service`. The
+// remainder of an app document is an application manifest. Contrary to Get, a missing
+// file is an error, and applications without origin have none, instead of defaulting to
+// the directory of the manifest.
+func GetStack(stackPath string) (models.StackManifest, error) {
+	empty := models.StackManifest{}
+
+	stackPath, err := filepath.Abs(stackPath)
+	if err != nil {
+		return empty, errors.Wrapf(err, "filesystem error")
+	}
+
+	content, err := os.ReadFile(stackPath)
+	if err != nil {
+		return empty, errors.Wrapf(err, "filesystem error")
+	}
+
+	stack := models.StackManifest{Self: stackPath}
+	names := map[string]map[string]struct{}{
+		models.StackKindApp:           {},
+		models.StackKindConfiguration: {},
+		models.StackKindService:       {},
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for index := 1; ; index++ {
+		var document map[string]interface{}
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return empty, errors.Wrapf(err, "bad yaml, document %d", index)
+		}
+		if len(document) == 0 {
+			// Empty documents, i.e. separators without content, are ignored.
+			continue
+		}
+
+		kind, _ := document["kind"].(string)
+		delete(document, "kind")
+
+		// Round trip the remainder of the document into the structure of its kind.
+		remainder, err := yaml.Marshal(document)
+		if err != nil {
+			return empty, errors.Wrapf(err, "bad yaml, document %d", index)
+		}
+
+		var name string
+		switch kind {
+		case models.StackKindApp:
+			app := models.ApplicationManifest{}
+			if err := yaml.Unmarshal(remainder, &app); err != nil {
+				return empty, errors.Wrapf(err, "bad yaml, document %d", index)
+			}
+			if _, err := resolveOrigin(&app.Origin, filepath.Dir(stackPath)); err != nil {
+				return empty, errors.Wrapf(err, "app %s", app.Name)
+			}
+			app.Self = stackPath
+			name = app.Name
+			stack.Apps = append(stack.Apps, app)
+		case models.StackKindConfiguration:
+			configuration := models.ConfigurationManifest{}
+			if err := yaml.Unmarshal(remainder, &configuration); err != nil {
+				return empty, errors.Wrapf(err, "bad yaml, document %d", index)
+			}
+			name = configuration.Name
+			stack.Configurations = append(stack.Configurations, configuration)
+		case models.StackKindService:
+			service := models.ServiceManifest{}
+			if err := yaml.Unmarshal(remainder, &service); err != nil {
+				return empty, errors.Wrapf(err, "bad yaml, document %d", index)
+			}
+			if service.CatalogService == "" {
+				return empty, fmt.Errorf("service %s has no catalog_service", service.Name)
+			}
+			name = service.Name
+			stack.Services = append(stack.Services, service)
+		default:
+			return empty, fmt.Errorf("document %d has unknown kind '%s', expected one of app, configuration, service", index, kind)
+		}
+
+		if name == "" {
+			return empty, fmt.Errorf("document %d, %s has no name", index, kind)
+		}
+		if _, ok := names[kind][name]; ok {
+			return empty, fmt.Errorf("%s %s is declared more than once", kind, name)
+		}
+		names[kind][name] = struct{}{}
+	}
+
+	return stack, nil
+}
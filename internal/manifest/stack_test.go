@@ -0,0 +1,88 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/internal/manifest"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stack manifest", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	write := func(content string) string {
+		path := filepath.Join(dir, "stack.yml")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("reads the documents by kind", func() {
+		path := write(`kind: configuration
+name: db
+data:
+  user: admin
+restart: rolling
+---
+kind: service
+name: pg
+catalog_service: postgresql-dev
+bound_apps: [web]
+---
+kind: app
+name: web
+configuration:
+  configurations: [db]
+origin:
+  path: src
+---
+kind: app
+name: worker
+origin:
+  container: splatform/sample-app
+`)
+
+		stack, err := manifest.GetStack(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stack.Self).To(Equal(path))
+		Expect(stack.Configurations).To(Equal([]models.ConfigurationManifest{{
+			Name:    "db",
+			Data:    map[string]string{"user": "admin"},
+			Restart: models.ConfigurationRestartRolling,
+		}}))
+		Expect(stack.Services).To(Equal([]models.ServiceManifest{{
+			Name:           "pg",
+			CatalogService: "postgresql-dev",
+			BoundApps:      []string{"web"},
+		}}))
+		Expect(stack.Apps).To(HaveLen(2))
+		Expect(stack.Apps[0].Name).To(Equal("web"))
+		Expect(stack.Apps[0].Configuration.Configurations).To(Equal([]string{"db"}))
+		Expect(stack.Apps[0].Origin).To(Equal(models.ApplicationOrigin{
+			Kind: models.OriginPath,
+			Path: filepath.Join(dir, "src"),
+		}))
+		Expect(stack.Apps[1].Origin.Kind).To(Equal(models.OriginContainer))
+	})
+
+	It("rejects unknown kinds", func() {
+		_, err := manifest.GetStack(write("kind: route\nname: web\n"))
+		Expect(err).To(MatchError(ContainSubstring("unknown kind 'route'")))
+	})
+
+	It("rejects names declared twice", func() {
+		_, err := manifest.GetStack(write("kind: app\nname: web\n---\nkind: app\nname: web\n"))
+		Expect(err).To(MatchError("app web is declared more than once"))
+	})
+
+	It("fails for a missing file", func() {
+		_, err := manifest.GetStack(filepath.Join(dir, "missing.yml"))
+		Expect(err).To(HaveOccurred())
+	})
+})
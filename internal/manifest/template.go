@@ -0,0 +1,218 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Options control the loading of a manifest, mainly its templating.
+type Options struct {
+	// Overlay names the overlay merged over the manifest, e.g. `prod` for the file
+	// `epinio.prod.yml` next to the manifest `epinio.yml`.
+	Overlay string
+	// Vars are the values of the `((name))` variables of the manifest and overlay.
+	Vars map[string]string
+	// NoDefaultOrigin leaves the origin unset when the manifest specifies none, instead
+	// of defaulting it to the directory of the manifest.
+	NoDefaultOrigin bool
+}
+
+// variable matches the `((name))` references to variables.
+var variable = regexp.MustCompile(`\(\(\s*([A-Za-z0-9_.-]+)\s*\)\)`)
+
+// Render returns the content of the manifest at the specified path, with the variables
+// interpolated, and the overlay, if any, merged over it. Maps are merged by key,
+// recursively. All other values of the overlay replace those of the manifest, with
+// `null` removing them. It is an error to reference a variable without value.
+func Render(manifestPath string, options Options) ([]byte, error) {
+	content, err := interpolateFile(manifestPath, options.Vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Overlay == "" {
+		return content, nil
+	}
+
+	overlayContent, err := interpolateFile(OverlayPath(manifestPath, options.Overlay), options.Vars)
+	if err != nil {
+		return nil, err
+	}
+
+	base := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(content, &base); err != nil {
+		return nil, errors.Wrapf(err, "bad yaml")
+	}
+	overlay := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal(overlayContent, &overlay); err != nil {
+		return nil, errors.Wrapf(err, "bad yaml in overlay %s", options.Overlay)
+	}
+
+	return yaml.Marshal(merge(base, overlay))
+}
+
+// OverlayPath returns the path of the named overlay of the manifest, i.e. the manifest
+// path with the name inserted before the extension.
+func OverlayPath(manifestPath, overlay string) string {
+	ext := filepath.Ext(manifestPath)
+	return strings.TrimSuffix(manifestPath, ext) + "." + overlay + ext
+}
+
+// OptionsFrom returns the templating options given by the --overlay, --vars-file, and
+// --var options of the command. Variables given by --var override those from the
+// files, and later files override earlier ones.
+func OptionsFrom(cmd *cobra.Command) (Options, error) {
+	options := Options{Vars: map[string]string{}}
+
+	overlay, err := cmd.Flags().GetString("overlay")
+	if err != nil {
+		return options, errors.Wrap(err, "could not read overlay parameter")
+	}
+	options.Overlay = overlay
+
+	varsFiles, err := cmd.Flags().GetStringArray("vars-file")
+	if err != nil {
+		return options, errors.Wrap(err, "could not read vars-file parameter")
+	}
+	for _, path := range varsFiles {
+		vars, err := varsFromFile(path)
+		if err != nil {
+			return options, err
+		}
+		for name, value := range vars {
+			options.Vars[name] = value
+		}
+	}
+
+	assignments, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		return options, errors.Wrap(err, "could not read var parameter")
+	}
+	for _, assignment := range assignments {
+		pieces := strings.SplitN(assignment, "=", 2)
+		if len(pieces) != 2 || pieces[0] == "" {
+			return options, fmt.Errorf("bad --var '%s', expected NAME=VALUE", assignment)
+		}
+		options.Vars[pieces[0]] = pieces[1]
+	}
+
+	return options, nil
+}
+
+// varsFromFile returns the variables of the yaml file, a map of names to scalar values.
+func varsFromFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "filesystem error")
+	}
+
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, errors.Wrapf(err, "bad yaml in vars file %s", path)
+	}
+
+	vars := map[string]string{}
+	for name, value := range raw {
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return nil, fmt.Errorf("variable %s of vars file %s is not a scalar", name, path)
+		case nil:
+			vars[name] = ""
+		default:
+			vars[name] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	return vars, nil
+}
+
+// interpolateFile returns the content of the file with the variables replaced by their
+// values. The values are substituted into the scalars of the parsed yaml, not into its
+// text, and quoted as needed when it is rendered again. They cannot change the structure
+// of the yaml.
+func interpolateFile(path string, vars map[string]string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "filesystem error")
+	}
+
+	if !variable.Match(content) {
+		return content, nil
+	}
+
+	document := yamlv3.Node{}
+	if err := yamlv3.Unmarshal(content, &document); err != nil {
+		return nil, errors.Wrapf(err, "bad yaml")
+	}
+	if len(document.Content) == 0 {
+		return content, nil
+	}
+
+	missing := map[string]struct{}{}
+	interpolate(&document, vars, missing)
+
+	if len(missing) > 0 {
+		names := []string{}
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%s: no value for the variables %s", path, strings.Join(names, ", "))
+	}
+
+	return yamlv3.Marshal(&document)
+}
+
+// interpolate replaces the variables in the scalars of the node, keys included, and
+// records the references to variables without value in missing. The implicit tags of
+// changed scalars are dropped, i.e. their type is resolved from their new value, as if it
+// was written in the manifest.
+func interpolate(node *yamlv3.Node, vars map[string]string, missing map[string]struct{}) {
+	for _, child := range node.Content {
+		interpolate(child, vars, missing)
+	}
+
+	if node.Kind != yamlv3.ScalarNode || !variable.MatchString(node.Value) {
+		return
+	}
+
+	node.Value = variable.ReplaceAllStringFunc(node.Value, func(reference string) string {
+		name := variable.FindStringSubmatch(reference)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing[name] = struct{}{}
+			return reference
+		}
+		return value
+	})
+	if node.Style&yamlv3.TaggedStyle == 0 {
+		node.Tag = ""
+	}
+}
+
+// merge merges the overlay into the base, recursively for maps, and returns the base.
+func merge(base, overlay map[interface{}]interface{}) map[interface{}]interface{} {
+	for key, value := range overlay {
+		if value == nil {
+			delete(base, key)
+			continue
+		}
+		if sub, ok := value.(map[interface{}]interface{}); ok {
+			if baseSub, ok := base[key].(map[interface{}]interface{}); ok {
+				base[key] = merge(baseSub, sub)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
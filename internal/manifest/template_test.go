@@ -0,0 +1,124 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/internal/manifest"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Manifest templating", func() {
+	var dir, manifestPath string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		manifestPath = filepath.Join(dir, "epinio.yml")
+
+		err := os.WriteFile(manifestPath, []byte(`name: web
+configuration:
+  instances: ((replicas))
+  environment:
+    MODE: ((mode))
+    DEBUG: "1"
+  routes:
+  - web.((domain))
+origin:
+  container: web:1.0
+`), 0600)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = os.WriteFile(filepath.Join(dir, "epinio.prod.yml"), []byte(`configuration:
+  environment:
+    DEBUG: null
+  routes:
+  - www.((domain))
+`), 0600)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	vars := map[string]string{"replicas": "3", "mode": "prod", "domain": "example.com"}
+
+	It("interpolates the variables", func() {
+		m, err := manifest.Load(manifestPath, manifest.Options{Vars: vars})
+		Expect(err).ToNot(HaveOccurred())
+
+		instances := int32(3)
+		Expect(m.Configuration).To(Equal(models.ApplicationUpdateRequest{
+			Instances:   &instances,
+			Environment: models.EnvVariableMap{"MODE": "prod", "DEBUG": "1"},
+			Routes:      []string{"web.example.com"},
+		}))
+		Expect(m.Origin.Kind).To(Equal(models.OriginContainer))
+	})
+
+	It("substitutes values without changing the structure of the manifest", func() {
+		m, err := manifest.Load(manifestPath, manifest.Options{Vars: map[string]string{
+			"replicas": "3",
+			"mode":     "prod\nname: evil\norigin:\n  path: /",
+			"domain":   "example.com: x",
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(m.Name).To(Equal("web"))
+		Expect(m.Configuration.Environment).To(Equal(models.EnvVariableMap{
+			"MODE":  "prod\nname: evil\norigin:\n  path: /",
+			"DEBUG": "1",
+		}))
+		Expect(m.Configuration.Routes).To(Equal([]string{"web.example.com: x"}))
+		Expect(m.Origin).To(Equal(models.ApplicationOrigin{Kind: models.OriginContainer, Container: "web:1.0"}))
+	})
+
+	It("resolves the type of a substituted value as if it was written in the manifest", func() {
+		m, err := manifest.Load(manifestPath, manifest.Options{Vars: map[string]string{
+			"replicas": "3",
+			"mode":     "1.10",
+			"domain":   "example.com",
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(*m.Configuration.Instances).To(Equal(int32(3)))
+		Expect(m.Configuration.Environment["MODE"]).To(Equal("1.10"))
+	})
+
+	It("merges the overlay by key", func() {
+		m, err := manifest.Load(manifestPath, manifest.Options{Vars: vars, Overlay: "prod"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(m.Name).To(Equal("web"))
+		Expect(*m.Configuration.Instances).To(Equal(int32(3)))
+		Expect(m.Configuration.Environment).To(Equal(models.EnvVariableMap{"MODE": "prod"}))
+		Expect(m.Configuration.Routes).To(Equal([]string{"www.example.com"}))
+	})
+
+	It("rejects variables without value", func() {
+		_, err := manifest.Load(manifestPath, manifest.Options{Vars: map[string]string{"mode": "dev"}})
+		Expect(err).To(MatchError(ContainSubstring("no value for the variables domain, replicas")))
+	})
+
+	It("rejects missing overlays", func() {
+		_, err := manifest.Load(manifestPath, manifest.Options{Vars: vars, Overlay: "staging"})
+		Expect(err).To(MatchError(ContainSubstring("epinio.staging.yml")))
+	})
+
+	It("takes the variables from files and options, options last", func() {
+		varsPath := filepath.Join(dir, "prod-vars.yml")
+		Expect(os.WriteFile(varsPath, []byte("replicas: 2\nmode: prod\n"), 0600)).To(Succeed())
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("overlay", "", "")
+		cmd.Flags().StringArray("vars-file", []string{}, "")
+		cmd.Flags().StringArray("var", []string{}, "")
+		Expect(cmd.Flags().Parse([]string{"--vars-file", varsPath, "--var", "replicas=3", "--overlay", "prod"})).To(Succeed())
+
+		options, err := manifest.OptionsFrom(cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options).To(Equal(manifest.Options{
+			Overlay: "prod",
+			Vars:    map[string]string{"replicas": "3", "mode": "prod"},
+		}))
+	})
+})
@@ -19,6 +19,7 @@ import (
 type Namespace struct {
 	Name      string
 	CreatedAt metav1.Time
+	Owners    []string
 }
 
 func (n Namespace) Namespace() string {
@@ -40,6 +41,7 @@ func List(ctx context.Context, kubeClient *kubernetes.Cluster) ([]Namespace, err
 		result = append(result, Namespace{
 			Name:      namespace.ObjectMeta.Name,
 			CreatedAt: namespace.ObjectMeta.CreationTimestamp,
+			Owners:    ownersOf(namespace.ObjectMeta.Annotations),
 		})
 	}
 
@@ -0,0 +1,74 @@
+package namespaces
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// OwnersAnnotation on the namespace holds the comma-separated names of the users owning
+// it. Owners manage the members of the namespace. The user creating a namespace owns it.
+const OwnersAnnotation = "epinio.io/namespace-owners"
+
+// SetOwner makes the user an owner of the namespace, or removes the ownership.
+func SetOwner(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, username string, owner bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		owners := WithOwner(ownersOf(ns.Annotations), username, owner)
+		if len(owners) == 0 {
+			delete(ns.Annotations, OwnersAnnotation)
+		} else {
+			if ns.Annotations == nil {
+				ns.Annotations = map[string]string{}
+			}
+			ns.Annotations[OwnersAnnotation] = strings.Join(owners, ",")
+		}
+
+		_, err = kubeClient.Kubectl.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// WithOwner returns the sorted owners, with the user added or removed.
+func WithOwner(owners []string, username string, owner bool) []string {
+	result := []string{}
+	for _, name := range owners {
+		if name != username {
+			result = append(result, name)
+		}
+	}
+	if owner {
+		result = append(result, username)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// IsOwner returns true if the user is an owner of the namespace.
+func (n Namespace) IsOwner(username string) bool {
+	for _, owner := range n.Owners {
+		if owner == username {
+			return true
+		}
+	}
+	return false
+}
+
+// ownersOf returns the owners recorded in the annotations of a namespace.
+func ownersOf(annotations map[string]string) []string {
+	owners := []string{}
+	for _, owner := range strings.Split(annotations[OwnersAnnotation], ",") {
+		if owner != "" {
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
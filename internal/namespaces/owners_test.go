@@ -0,0 +1,21 @@
+package namespaces_test
+
+import (
+	"github.com/epinio/epinio/internal/namespaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithOwner", func() {
+	It("adds the owner, sorted", func() {
+		Expect(namespaces.WithOwner([]string{"zoe"}, "adam", true)).To(Equal([]string{"adam", "zoe"}))
+	})
+
+	It("does not add an owner twice", func() {
+		Expect(namespaces.WithOwner([]string{"adam"}, "adam", true)).To(Equal([]string{"adam"}))
+	})
+
+	It("removes the owner", func() {
+		Expect(namespaces.WithOwner([]string{"adam", "zoe"}, "adam", false)).To(Equal([]string{"zoe"}))
+	})
+})
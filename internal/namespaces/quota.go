@@ -0,0 +1,228 @@
+package namespaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// QuotaMaxAppsAnnotation and QuotaMaxServicesAnnotation on the namespace hold the
+	// number of applications and services the namespace may have. Epinio enforces them
+	// when creating applications and services.
+	QuotaMaxAppsAnnotation     = "epinio.io/quota-max-apps"
+	QuotaMaxServicesAnnotation = "epinio.io/quota-max-services"
+
+	// QuotaName is the name of the ResourceQuota and LimitRange limiting the memory and
+	// cpu of the namespace. Kubernetes enforces them.
+	QuotaName = "epinio-quota"
+)
+
+// The requests given to the containers which do not specify their own. A requests quota
+// rejects pods without requests.
+var (
+	defaultRequestMemory = resource.MustParse("128Mi")
+	defaultRequestCPU    = resource.MustParse("100m")
+)
+
+// ValidateQuota checks that the counts of the quota are not negative, and that memory and
+// cpu are quantities.
+func ValidateQuota(quota models.NamespaceQuota) error {
+	if quota.MaxApps < 0 {
+		return fmt.Errorf("bad maximum number of applications %d", quota.MaxApps)
+	}
+	if quota.MaxServices < 0 {
+		return fmt.Errorf("bad maximum number of services %d", quota.MaxServices)
+	}
+	if quota.Memory != "" {
+		if _, err := resource.ParseQuantity(quota.Memory); err != nil {
+			return errors.Wrapf(err, "bad memory quota %s", quota.Memory)
+		}
+	}
+	if quota.CPU != "" {
+		if _, err := resource.ParseQuantity(quota.CPU); err != nil {
+			return errors.Wrapf(err, "bad cpu quota %s", quota.CPU)
+		}
+	}
+	return nil
+}
+
+// SetQuota limits the namespace as per the quota. The parts of the quota which are not set
+// remove the respective limits.
+func SetQuota(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string, quota models.NamespaceQuota) error {
+	if err := ValidateQuota(quota); err != nil {
+		return err
+	}
+
+	// Counts. A nil value removes the annotation.
+	annotations := map[string]interface{}{
+		QuotaMaxAppsAnnotation:     nil,
+		QuotaMaxServicesAnnotation: nil,
+	}
+	if quota.MaxApps > 0 {
+		annotations[QuotaMaxAppsAnnotation] = strconv.Itoa(quota.MaxApps)
+	}
+	if quota.MaxServices > 0 {
+		annotations[QuotaMaxServicesAnnotation] = strconv.Itoa(quota.MaxServices)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = kubeClient.Kubectl.CoreV1().Namespaces().Patch(ctx, namespace, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrap(err, "recording the quota")
+	}
+
+	// Resources.
+	hard := corev1.ResourceList{}
+	defaults := corev1.ResourceList{}
+	if quota.Memory != "" {
+		memory := resource.MustParse(quota.Memory)
+		hard[corev1.ResourceRequestsMemory] = memory
+		defaults[corev1.ResourceMemory] = minQuantity(defaultRequestMemory, memory)
+	}
+	if quota.CPU != "" {
+		cpu := resource.MustParse(quota.CPU)
+		hard[corev1.ResourceRequestsCPU] = cpu
+		defaults[corev1.ResourceCPU] = minQuantity(defaultRequestCPU, cpu)
+	}
+
+	if len(hard) == 0 {
+		return deleteResourceQuota(ctx, kubeClient, namespace)
+	}
+
+	resourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: QuotaName},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}
+	_, err = kubeClient.Kubectl.CoreV1().ResourceQuotas(namespace).Create(ctx, resourceQuota, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = kubeClient.Kubectl.CoreV1().ResourceQuotas(namespace).Update(ctx, resourceQuota, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return errors.Wrap(err, "creating the resource quota")
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: QuotaName},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					DefaultRequest: defaults,
+				},
+			},
+		},
+	}
+	_, err = kubeClient.Kubectl.CoreV1().LimitRanges(namespace).Create(ctx, limitRange, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = kubeClient.Kubectl.CoreV1().LimitRanges(namespace).Update(ctx, limitRange, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return errors.Wrap(err, "creating the limit range")
+	}
+
+	return nil
+}
+
+// Quota returns the quota of the namespace, or nil if the namespace is not limited.
+func Quota(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (*models.NamespaceQuota, error) {
+	ns, err := kubeClient.Kubectl.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	quota := models.NamespaceQuota{}
+	if value, ok := ns.Annotations[QuotaMaxAppsAnnotation]; ok {
+		quota.MaxApps, _ = strconv.Atoi(value)
+	}
+	if value, ok := ns.Annotations[QuotaMaxServicesAnnotation]; ok {
+		quota.MaxServices, _ = strconv.Atoi(value)
+	}
+
+	resourceQuota, err := getResourceQuota(ctx, kubeClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if resourceQuota != nil {
+		if memory, ok := resourceQuota.Spec.Hard[corev1.ResourceRequestsMemory]; ok {
+			quota.Memory = memory.String()
+		}
+		if cpu, ok := resourceQuota.Spec.Hard[corev1.ResourceRequestsCPU]; ok {
+			quota.CPU = cpu.String()
+		}
+	}
+
+	if quota == (models.NamespaceQuota{}) {
+		return nil, nil
+	}
+	return &quota, nil
+}
+
+// ResourceUsage returns the memory and cpu requested by the pods of the namespace, as
+// counted by the resource quota. Both are empty if the namespace has no resource quota.
+func ResourceUsage(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (string, string, error) {
+	resourceQuota, err := getResourceQuota(ctx, kubeClient, namespace)
+	if err != nil || resourceQuota == nil {
+		return "", "", err
+	}
+
+	memory, cpu := "", ""
+	if used, ok := resourceQuota.Status.Used[corev1.ResourceRequestsMemory]; ok {
+		memory = used.String()
+	}
+	if used, ok := resourceQuota.Status.Used[corev1.ResourceRequestsCPU]; ok {
+		cpu = used.String()
+	}
+	return memory, cpu, nil
+}
+
+// getResourceQuota returns the resource quota of the namespace, or nil if there is none.
+func getResourceQuota(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) (*corev1.ResourceQuota, error) {
+	resourceQuota, err := kubeClient.Kubectl.CoreV1().ResourceQuotas(namespace).Get(ctx, QuotaName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resourceQuota, nil
+}
+
+// deleteResourceQuota removes the resource quota and limit range of the namespace, if any.
+func deleteResourceQuota(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) error {
+	err := kubeClient.Kubectl.CoreV1().ResourceQuotas(namespace).Delete(ctx, QuotaName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err = kubeClient.Kubectl.CoreV1().LimitRanges(namespace).Delete(ctx, QuotaName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// minQuantity returns the smaller of the quantities.
+func minQuantity(a, b resource.Quantity) resource.Quantity {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,34 @@
+package namespaces_test
+
+import (
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateQuota", func() {
+	It("accepts counts and quantities", func() {
+		err := namespaces.ValidateQuota(models.NamespaceQuota{
+			MaxApps:     20,
+			MaxServices: 5,
+			Memory:      "16Gi",
+			CPU:         "500m",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("accepts no limits", func() {
+		Expect(namespaces.ValidateQuota(models.NamespaceQuota{})).To(Succeed())
+	})
+
+	It("rejects negative counts", func() {
+		err := namespaces.ValidateQuota(models.NamespaceQuota{MaxApps: -1})
+		Expect(err).To(MatchError(ContainSubstring("bad maximum number of applications")))
+	})
+
+	It("rejects bad quantities", func() {
+		err := namespaces.ValidateQuota(models.NamespaceQuota{Memory: "16 gigs"})
+		Expect(err).To(MatchError(ContainSubstring("bad memory quota")))
+	})
+})
@@ -0,0 +1,414 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// manifestMediaTypes are the manifest formats accepted when retrieving manifests. Lists
+// and indices are preferred, to get the digest of multi-platform images, instead of the
+// digest of a single platform.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// challengeParam matches the parameters of a WWW-Authenticate challenge.
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// linkNext matches the url of the next page in a Link header.
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Client talks to the HTTP API of a container registry. Authentication challenges of the
+// registry are answered with the credentials, if any.
+type Client struct {
+	httpClient     *http.Client
+	host           string
+	credentials    *RegistryCredentials
+	authorizations map[string]string // Authorization header per scope
+}
+
+// Descriptor references a blob or manifest of the registry.
+type Descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// Manifest is the part of an image manifest, or manifest list, used by Epinio.
+type Manifest struct {
+	Digest    string       `json:"-"`
+	MediaType string       `json:"mediaType"`
+	Config    Descriptor   `json:"config"`
+	Layers    []Descriptor `json:"layers"`
+	Manifests []Descriptor `json:"manifests"`
+}
+
+// NewClient returns a client for the registry at the host. The credentials are optional.
+func NewClient(httpClient *http.Client, host string, credentials *RegistryCredentials) *Client {
+	host = NormalizeURL(host)
+	if canonicalHost(host) == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	return &Client{
+		httpClient:     httpClient,
+		host:           host,
+		credentials:    credentials,
+		authorizations: map[string]string{},
+	}
+}
+
+// Catalog returns the names of all repositories of the registry.
+func (c *Client) Catalog(ctx context.Context) ([]string, error) {
+	var repositories []string
+
+	path := "/v2/_catalog?n=1000"
+	for path != "" {
+		var page struct {
+			Repositories []string `json:"repositories"`
+		}
+
+		next, err := c.getJSON(ctx, path, "registry:catalog:*", &page)
+		if err != nil {
+			return nil, err
+		}
+
+		repositories = append(repositories, page.Repositories...)
+		path = next
+	}
+
+	return repositories, nil
+}
+
+// Tags returns the tags of the repository.
+func (c *Client) Tags(ctx context.Context, repository string) ([]string, error) {
+	var tags []string
+
+	path := fmt.Sprintf("/v2/%s/tags/list?n=1000", repository)
+	for path != "" {
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+
+		next, err := c.getJSON(ctx, path, pullScope(repository), &page)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, page.Tags...)
+		path = next
+	}
+
+	return tags, nil
+}
+
+// Digest returns the digest of the manifest the reference (tag or digest) refers to.
+func (c *Client) Digest(ctx context.Context, repository, reference string) (string, error) {
+	response, err := c.do(ctx, http.MethodHead,
+		fmt.Sprintf("/v2/%s/manifests/%s", repository, reference), pullScope(repository),
+		map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")})
+	if err != nil {
+		return "", err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", statusError(response, c.host, repository+":"+reference)
+	}
+
+	digest := response.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", errors.Errorf("registry '%s' did not report the digest of '%s:%s'",
+			c.host, repository, reference)
+	}
+
+	return digest, nil
+}
+
+// Manifest returns the manifest the reference (tag or digest) refers to.
+func (c *Client) Manifest(ctx context.Context, repository, reference string) (*Manifest, error) {
+	response, err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf("/v2/%s/manifests/%s", repository, reference), pullScope(repository),
+		map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")})
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, statusError(response, c.host, repository+":"+reference)
+	}
+
+	var manifest Manifest
+	err = json.NewDecoder(response.Body).Decode(&manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad manifest")
+	}
+	manifest.Digest = response.Header.Get("Docker-Content-Digest")
+
+	return &manifest, nil
+}
+
+// Created returns the creation time recorded in the image configuration blob.
+func (c *Client) Created(ctx context.Context, repository, configDigest string) (time.Time, error) {
+	var config struct {
+		Created time.Time `json:"created"`
+	}
+
+	_, err := c.getJSON(ctx, fmt.Sprintf("/v2/%s/blobs/%s", repository, configDigest),
+		pullScope(repository), &config)
+
+	return config.Created, err
+}
+
+// DeleteManifest removes the manifest with the digest from the repository, together with
+// all tags referring to it. Note that the registry reclaims the space of the layers only
+// when it runs its own garbage collection.
+func (c *Client) DeleteManifest(ctx context.Context, repository, digest string) error {
+	response, err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/v2/%s/manifests/%s", repository, digest),
+		fmt.Sprintf("repository:%s:pull,delete", repository), nil)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusAccepted && response.StatusCode != http.StatusOK {
+		return statusError(response, c.host, repository+"@"+digest)
+	}
+
+	return nil
+}
+
+// getJSON retrieves the JSON document at the path and decodes it into the result. It
+// returns the path of the next page, if the registry reported one.
+func (c *Client) getJSON(ctx context.Context, path, scope string, result interface{}) (string, error) {
+	response, err := c.do(ctx, http.MethodGet, path, scope, nil)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", statusError(response, c.host, path)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return "", errors.Wrapf(err, "bad response for '%s'", path)
+	}
+
+	match := linkNext.FindStringSubmatch(response.Header.Get("Link"))
+	if match == nil {
+		return "", nil
+	}
+
+	next, err := url.Parse(match[1])
+	if err != nil {
+		return "", errors.Wrap(err, "bad link to next page")
+	}
+
+	return next.RequestURI(), nil
+}
+
+// do performs the request, answering an authentication challenge of the registry and
+// retrying once, if necessary. Authorizations are remembered per scope.
+func (c *Client) do(ctx context.Context, method, path, scope string, header map[string]string) (*http.Response, error) {
+	request := func() (*http.Response, error) {
+		request, err := http.NewRequestWithContext(ctx, method, "https://"+c.host+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range header {
+			request.Header.Set(key, value)
+		}
+		if authorization, ok := c.authorizations[scope]; ok {
+			request.Header.Set("Authorization", authorization)
+		}
+		return c.httpClient.Do(request)
+	}
+
+	response, err := request()
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusUnauthorized {
+		return response, nil
+	}
+	response.Body.Close()
+
+	authorization, err := c.authorize(ctx, response.Header.Get("WWW-Authenticate"), scope)
+	if err != nil {
+		return nil, errors.Wrapf(err, "authenticating with registry '%s'", c.host)
+	}
+	c.authorizations[scope] = authorization
+
+	return request()
+}
+
+// authorize answers the registry's authentication challenge, returning the value of the
+// Authorization header to use for the scope. Registries using basic authentication
+// require credentials. Token servers may hand out tokens for anonymous pulls.
+func (c *Client) authorize(ctx context.Context, challenge, scope string) (string, error) {
+	scheme, _, _ := strings.Cut(challenge, " ")
+
+	params := map[string]string{}
+	for _, match := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.credentials == nil {
+			return "", errors.New("registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString(
+			[]byte(c.credentials.Username+":"+c.credentials.Password)), nil
+
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", errors.Errorf("bad token realm '%s'", params["realm"])
+		}
+
+		query := realm.Query()
+		if service, ok := params["service"]; ok {
+			query.Set("service", service)
+		}
+		query.Set("scope", scope)
+		realm.RawQuery = query.Encode()
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if c.credentials != nil {
+			request.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+		}
+
+		response, err := c.httpClient.Do(request)
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return "", errors.Errorf("token server responded with status %d", response.StatusCode)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		err = json.NewDecoder(response.Body).Decode(&token)
+		if err != nil {
+			return "", errors.Wrap(err, "bad token response")
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return "", errors.New("token server returned no token")
+		}
+
+		return "Bearer " + token.Token, nil
+	}
+
+	return "", errors.Errorf("unsupported authentication scheme '%s'", scheme)
+}
+
+// pullScope returns the token scope for reading from the repository.
+func pullScope(repository string) string {
+	return fmt.Sprintf("repository:%s:pull", repository)
+}
+
+// StatusError is returned for unexpected responses of the registry.
+type StatusError struct {
+	Host    string
+	Subject string
+	Status  int
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("registry '%s' responded with status %d for '%s'", e.Host, e.Status, e.Subject)
+}
+
+// IsNotFound returns true if the error reports a missing repository, manifest or blob.
+func IsNotFound(err error) bool {
+	var statusErr StatusError
+	return errors.As(err, &statusErr) && statusErr.Status == http.StatusNotFound
+}
+
+// statusError returns the error for an unexpected response of the registry.
+func statusError(response *http.Response, host, subject string) error {
+	return StatusError{
+		Host:    host,
+		Subject: subject,
+		Status:  response.StatusCode,
+	}
+}
+
+// EpinioClient returns a client for the Epinio registry, and the registry namespace
+// holding the application images. The client talks to the public location of the
+// registry, trusting the registry certificate, if one is configured.
+func EpinioClient(ctx context.Context, cluster *kubernetes.Cluster) (*Client, string, error) {
+	details, err := GetConnectionDetails(ctx, cluster, helmchart.Namespace(), CredentialsSecretName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	publicURL, err := details.PublicRegistryURL()
+	if err != nil {
+		return nil, "", err
+	}
+	if publicURL == "" {
+		return nil, "", errors.New("no public registry URL found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if secretName := viper.GetString("registry-certificate-secret"); secretName != "" {
+		secret, err := cluster.GetSecret(ctx, helmchart.Namespace(), secretName)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "getting registry certificate secret %s", secretName)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		rootCAs.AppendCertsFromPEM(secret.Data["tls.crt"])
+		rootCAs.AppendCertsFromPEM(secret.Data["ca.crt"])
+
+		tlsConfig := transport.TLSClientConfig.Clone()
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{} // nolint:gosec // defaults are fine
+		}
+		tlsConfig.RootCAs = rootCAs
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   time.Minute,
+	}
+
+	return NewClient(httpClient, publicURL, details.Lookup(publicURL)), details.Namespace, nil
+}
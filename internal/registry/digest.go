@@ -0,0 +1,36 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	parser "github.com/novln/docker-parser"
+)
+
+// ResolveDigest returns the digest of the manifest the tag of the container image refers
+// to, as reported by the registry holding the image. The credentials are optional. An
+// image referenced by digest is not looked up, its digest is returned as is.
+func ResolveDigest(ctx context.Context, client *http.Client, imageURL string, credentials *RegistryCredentials) (string, error) {
+	ref, err := parser.Parse(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(ref.Tag(), "sha256:") {
+		return ref.Tag(), nil
+	}
+
+	return NewClient(client, ref.Registry(), credentials).Digest(ctx, ref.ShortName(), ref.Tag())
+}
+
+// PinnedImage returns the container image URL referencing the image by the digest instead
+// of by tag.
+func PinnedImage(imageURL, digest string) (string, error) {
+	ref, err := parser.Parse(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Repository() + "@" + digest, nil
+}
@@ -0,0 +1,117 @@
+package registry_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/epinio/epinio/internal/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResolveDigest", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	var server *httptest.Server
+	var host string
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("scope") != "repository:team/app:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token":"t0k3n"}`)
+		})
+		mux.HandleFunc("/v2/team/app/manifests/1.0", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer t0k3n" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="https://%s/token",service="test"`, r.Host))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+		})
+
+		server = httptest.NewTLSServer(mux)
+		host = strings.TrimPrefix(server.URL, "https://")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("resolves the tag using the credentials", func() {
+		resolved, err := registry.ResolveDigest(context.Background(), server.Client(),
+			host+"/team/app:1.0", &registry.RegistryCredentials{
+				URL:      host,
+				Username: "user",
+				Password: "secret",
+			})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(Equal(digest))
+	})
+
+	It("fails without credentials", func() {
+		_, err := registry.ResolveDigest(context.Background(), server.Client(),
+			host+"/team/app:1.0", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails for unknown tags", func() {
+		_, err := registry.ResolveDigest(context.Background(), server.Client(),
+			host+"/team/app:2.0", nil)
+		Expect(err).To(MatchError(ContainSubstring("status 404")))
+	})
+
+	It("returns the digest of images referenced by digest", func() {
+		resolved, err := registry.ResolveDigest(context.Background(), server.Client(),
+			"unknown.io/team/app@"+digest, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resolved).To(Equal(digest))
+	})
+})
+
+var _ = Describe("PinnedImage", func() {
+	It("replaces the tag with the digest", func() {
+		pinned, err := registry.PinnedImage("ghcr.io/team/app:1.0", "sha256:1234")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pinned).To(Equal("ghcr.io/team/app@sha256:1234"))
+	})
+
+	It("fully qualifies Docker Hub images", func() {
+		pinned, err := registry.PinnedImage("nginx", "sha256:1234")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pinned).To(Equal("docker.io/library/nginx@sha256:1234"))
+	})
+})
+
+var _ = Describe("Lookup", func() {
+	details := registry.ConnectionDetails{
+		RegistryCredentials: []registry.RegistryCredentials{
+			{URL: "index.docker.io", Username: "hub"},
+			{URL: "https://ghcr.io/", Username: "gh"},
+		},
+	}
+
+	It("finds the credentials of a registry", func() {
+		Expect(details.Lookup("ghcr.io")).To(HaveField("Username", "gh"))
+	})
+
+	It("treats the names of the Docker Hub as the same", func() {
+		Expect(details.Lookup("docker.io")).To(HaveField("Username", "hub"))
+	})
+
+	It("returns nil for unknown registries", func() {
+		Expect(details.Lookup("quay.io")).To(BeNil())
+	})
+})
@@ -0,0 +1,212 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// PullSecretName is the name of the secret holding the pull credentials for the container
+// registries known to a namespace. The secret is referenced by the namespace's service
+// account, making the credentials available to all application workloads of the
+// namespace.
+const PullSecretName = "epinio-registry-pull" // nolint:gosec // not credentials
+
+// NormalizeURL reduces a registry URL to the host (and port) identifying the registry.
+func NormalizeURL(url string) string {
+	url = strings.TrimPrefix(url, "https://")
+	url = strings.TrimPrefix(url, "http://")
+	return strings.TrimSuffix(url, "/")
+}
+
+// Lookup returns the credentials for the registry, or nil if there are none. The various
+// names of the Docker Hub are treated as the same registry.
+func (d *ConnectionDetails) Lookup(registryURL string) *RegistryCredentials {
+	registryURL = canonicalHost(NormalizeURL(registryURL))
+
+	for i := range d.RegistryCredentials {
+		if canonicalHost(NormalizeURL(d.RegistryCredentials[i].URL)) == registryURL {
+			return &d.RegistryCredentials[i]
+		}
+	}
+
+	return nil
+}
+
+// PullCredentials returns the pull credentials stored in the namespace. A namespace
+// without pull secret has no credentials.
+func PullCredentials(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (*ConnectionDetails, error) {
+	details, err := GetConnectionDetails(ctx, cluster, namespace, PullSecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return &ConnectionDetails{RegistryCredentials: []RegistryCredentials{}}, nil
+		}
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// Login adds the credentials for the container registry to the namespace's pull secret,
+// replacing existing credentials for the same registry.
+func Login(ctx context.Context, cluster *kubernetes.Cluster, namespace string, credentials RegistryCredentials) error {
+	credentials.URL = NormalizeURL(credentials.URL)
+	if credentials.URL == "" {
+		return errors.New("url must be specified")
+	}
+	// The kubelet looks up the credentials for the Docker Hub under this name.
+	if canonicalHost(credentials.URL) == "docker.io" {
+		credentials.URL = "index.docker.io"
+	}
+
+	return pullSecretUpdate(ctx, cluster, namespace, func(details *ConnectionDetails) error {
+		if existing := details.Lookup(credentials.URL); existing != nil {
+			*existing = credentials
+			return nil
+		}
+		details.RegistryCredentials = append(details.RegistryCredentials, credentials)
+		return nil
+	})
+}
+
+// Logout removes the credentials for the container registry from the namespace's pull
+// secret. Removing the last credentials removes the pull secret as well.
+func Logout(ctx context.Context, cluster *kubernetes.Cluster, namespace, url string) error {
+	url = NormalizeURL(url)
+
+	return pullSecretUpdate(ctx, cluster, namespace, func(details *ConnectionDetails) error {
+		remaining := []RegistryCredentials{}
+		for _, credentials := range details.RegistryCredentials {
+			if canonicalHost(NormalizeURL(credentials.URL)) != canonicalHost(url) {
+				remaining = append(remaining, credentials)
+			}
+		}
+		if len(remaining) == len(details.RegistryCredentials) {
+			return errors.Errorf("not logged into registry '%s'", url)
+		}
+		details.RegistryCredentials = remaining
+		return nil
+	})
+}
+
+// pullSecretUpdate is the helper for Login and Logout encapsulating the read/modify/write
+// cycle of the namespace's pull secret, and of the service account referencing it.
+func pullSecretUpdate(ctx context.Context, cluster *kubernetes.Cluster, namespace string, modify func(*ConnectionDetails) error) error {
+	secrets := cluster.Kubectl.CoreV1().Secrets(namespace)
+	present := false
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		details := &ConnectionDetails{RegistryCredentials: []RegistryCredentials{}}
+
+		secret, err := secrets.Get(ctx, PullSecretName, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			secret = nil
+		} else {
+			details, err = connectionDetails(secret)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = modify(details)
+		if err != nil {
+			return err
+		}
+
+		present = len(details.RegistryCredentials) > 0
+		if !present {
+			if secret == nil {
+				return nil
+			}
+			return secrets.Delete(ctx, PullSecretName, metav1.DeleteOptions{})
+		}
+
+		config, err := details.DockerConfigJSON()
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+
+		if secret == nil {
+			_, err = secrets.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: PullSecretName,
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "epinio",
+					},
+				},
+				Type: corev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					corev1.DockerConfigJsonKey: data,
+				},
+			}, metav1.CreateOptions{})
+			return err
+		}
+
+		secret.Data = map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		}
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return referencePullSecret(ctx, cluster, namespace, present)
+}
+
+// referencePullSecret adds the pull secret to, or removes it from the image pull secrets
+// of the namespace's service account. The service account has the name of the namespace.
+func referencePullSecret(ctx context.Context, cluster *kubernetes.Cluster, namespace string, present bool) error {
+	accounts := cluster.Kubectl.CoreV1().ServiceAccounts(namespace)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		account, err := accounts.Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrap(err, "failed to get the namespace service account")
+		}
+
+		pullSecrets := []corev1.LocalObjectReference{}
+		for _, reference := range account.ImagePullSecrets {
+			if reference.Name != PullSecretName {
+				pullSecrets = append(pullSecrets, reference)
+			}
+		}
+		if present {
+			pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: PullSecretName})
+		}
+
+		if len(pullSecrets) == len(account.ImagePullSecrets) &&
+			(len(pullSecrets) == 0 || reflect.DeepEqual(pullSecrets, account.ImagePullSecrets)) {
+			return nil
+		}
+
+		account.ImagePullSecrets = pullSecrets
+		_, err = accounts.Update(ctx, account, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// canonicalHost maps the various names of the Docker Hub to a single one.
+func canonicalHost(host string) string {
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", "index.docker.io/v1", "registry.hub.docker.com":
+		return "docker.io"
+	}
+	return host
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	parser "github.com/novln/docker-parser"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -158,15 +159,21 @@ func Validate(url, namespace, username, password string) error {
 
 // GetConnectionDetails retrieves registry connection details from a Kubernetes secret.
 func GetConnectionDetails(ctx context.Context, cluster *kubernetes.Cluster, secretNamespace, secretName string) (*ConnectionDetails, error) {
-	details := ConnectionDetails{RegistryCredentials: []RegistryCredentials{}}
-
 	secret, err := cluster.GetSecret(ctx, secretNamespace, secretName)
 	if err != nil {
 		return nil, err
 	}
 
+	return connectionDetails(secret)
+}
+
+// connectionDetails extracts the registry connection details from the docker config of
+// the secret.
+func connectionDetails(secret *corev1.Secret) (*ConnectionDetails, error) {
+	details := ConnectionDetails{RegistryCredentials: []RegistryCredentials{}}
+
 	var dockerconfigjson DockerConfigJSON
-	err = json.Unmarshal(secret.Data[".dockerconfigjson"], &dockerconfigjson)
+	err := json.Unmarshal(secret.Data[".dockerconfigjson"], &dockerconfigjson)
 	if err != nil {
 		return nil, err
 	}
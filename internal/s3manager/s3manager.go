@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/helmchart"
@@ -22,6 +23,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// streamPartSize is the size of the parts of uploads of unknown size. Each part is
+// buffered in memory.
+const streamPartSize = 16 * 1024 * 1024
+
 type Manager struct {
 	minioClient       *minio.Client
 	connectionDetails ConnectionDetails
@@ -234,6 +239,39 @@ func (m *Manager) Upload(ctx context.Context, filepath string, metadata map[stri
 	return objectName, nil
 }
 
+// UploadStreamAs uploads the given Reader to the S3 endpoint under the object name, and
+// returns the size of the object. A negative size uploads the Reader until its end, in
+// parts.
+func (m *Manager) UploadStreamAs(ctx context.Context, objectName string, file io.Reader, size int64, metadata map[string]string) (int64, error) {
+	if err := m.EnsureBucket(ctx); err != nil {
+		return 0, errors.Wrap(err, "ensuring bucket")
+	}
+
+	info, err := m.minioClient.PutObject(ctx, m.connectionDetails.Bucket,
+		objectName, file, size, minio.PutObjectOptions{
+			ContentType:  "application/octet-stream",
+			UserMetadata: metadata,
+			PartSize:     streamPartSize,
+		})
+	if err != nil {
+		return 0, errors.Wrap(err, "writing the new object")
+	}
+
+	return info.Size, nil
+}
+
+// Download returns a Reader for the contents of the specified object. The caller has to
+// close it.
+func (m *Manager) Download(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	object, err := m.minioClient.GetObject(ctx, m.connectionDetails.Bucket, objectName,
+		minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading the object")
+	}
+
+	return object, nil
+}
+
 // EnsureBucket creates our bucket if it's missing
 func (m *Manager) EnsureBucket(ctx context.Context) error {
 	exists, err := m.minioClient.BucketExists(ctx, m.connectionDetails.Bucket)
@@ -253,3 +291,37 @@ func (m *Manager) DeleteObject(ctx context.Context, objectID string) error {
 	return m.minioClient.RemoveObject(ctx, m.connectionDetails.Bucket, objectID,
 		minio.RemoveObjectOptions{})
 }
+
+// Object describes a blob stored in the bucket
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// ListObjects returns the objects stored in the bucket whose name starts with the prefix.
+// A missing bucket has no objects.
+func (m *Manager) ListObjects(ctx context.Context, prefix string) ([]Object, error) {
+	exists, err := m.minioClient.BucketExists(ctx, m.connectionDetails.Bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "checking bucket %s exists", m.connectionDetails.Bucket)
+	}
+	if !exists {
+		return []Object{}, nil
+	}
+
+	objects := []Object{}
+	for info := range m.minioClient.ListObjects(ctx, m.connectionDetails.Bucket,
+		minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return nil, errors.Wrap(info.Err, "listing the objects")
+		}
+		objects = append(objects, Object{
+			Key:          info.Key,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+		})
+	}
+
+	return objects, nil
+}
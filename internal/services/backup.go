@@ -0,0 +1,398 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/randstr"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+const (
+	// BackupPrefix is the prefix of the names of all service backups in the S3 storage.
+	// The backups of a service are stored under `backups/NAMESPACE/SERVICE/ID`. They are
+	// deleted with the namespace, see DeleteNamespaceBackups.
+	BackupPrefix = "backups/"
+	// BackupIntervalAnnotation and BackupKeepAnnotation on the service secret hold the
+	// schedule of the service backups.
+	BackupIntervalAnnotation = "application.epinio.io/backup-interval"
+	BackupKeepAnnotation     = "application.epinio.io/backup-keep"
+
+	backupIDFormat = "20060102-150405.000"
+)
+
+// NewBackupID returns the ID of a backup created at the given time. The time keeps the
+// IDs ordered, a random suffix keeps concurrent backups apart.
+func NewBackupID(created time.Time) (string, error) {
+	suffix, err := randstr.Hex16()
+	if err != nil {
+		return "", errors.Wrap(err, "generating backup id")
+	}
+
+	return created.UTC().Format(backupIDFormat) + "-" + suffix[:8], nil
+}
+
+// Backup saves the data of the service instance in the S3 storage, using the backup
+// command of the catalog service. The command's output is streamed to the storage.
+func (s *ServiceClient) Backup(ctx context.Context, namespace, name string, catalogService models.CatalogService) (*models.ServiceBackup, error) {
+	if catalogService.Backup == nil {
+		return nil, fmt.Errorf("catalog service %s does not support backups", catalogService.Meta.Name)
+	}
+
+	store, err := backupStore(ctx, s.kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	created := time.Now().UTC()
+	id, err := NewBackupID(created)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := runHook(ctx, s.kubeClient, namespace, names.ServiceReleaseName(name),
+			*catalogService.Backup, nil, writer)
+		// A nil error ends the upload regularly.
+		writer.CloseWithError(err)
+		done <- err
+	}()
+
+	size, err := store.UploadStreamAs(ctx, backupPath(namespace, name)+id, reader, -1,
+		map[string]string{
+			"service": name, "namespace": namespace, "catalog": catalogService.Meta.Name,
+		})
+	// Unblock the command if the upload failed early.
+	reader.CloseWithError(errors.New("upload aborted"))
+
+	if hookErr := <-done; hookErr != nil {
+		return nil, errors.Wrap(hookErr, "running the backup command")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "storing the backup")
+	}
+
+	return &models.ServiceBackup{
+		ID:        id,
+		Size:      size,
+		CreatedAt: metav1.NewTime(created),
+	}, nil
+}
+
+// Backups returns the backups of the service instance, newest first. The backups of a
+// deleted service are kept, and are available to a new service of the same name in the
+// same namespace.
+func (s *ServiceClient) Backups(ctx context.Context, namespace, name string) (models.ServiceBackupList, error) {
+	store, err := backupStore(ctx, s.kubeClient)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := backupPath(namespace, name)
+	objects, err := store.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := models.ServiceBackupList{}
+	for _, object := range objects {
+		backups = append(backups, models.ServiceBackup{
+			ID:        strings.TrimPrefix(object.Key, prefix),
+			Size:      object.Size,
+			CreatedAt: metav1.NewTime(object.LastModified),
+		})
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt.Time)
+	})
+
+	return backups, nil
+}
+
+// Restore restores the data of the service instance from the backup, using the restore
+// command of the catalog service. The backup is streamed to the command's input.
+func (s *ServiceClient) Restore(ctx context.Context, namespace, name, backupID string, catalogService models.CatalogService) error {
+	if catalogService.Restore == nil {
+		return fmt.Errorf("catalog service %s does not support restoring backups", catalogService.Meta.Name)
+	}
+
+	store, err := backupStore(ctx, s.kubeClient)
+	if err != nil {
+		return err
+	}
+
+	archive, err := store.Download(ctx, backupPath(namespace, name)+backupID)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	err = runHook(ctx, s.kubeClient, namespace, names.ServiceReleaseName(name),
+		*catalogService.Restore, archive, io.Discard)
+	return errors.Wrap(err, "running the restore command")
+}
+
+// ScheduleBackups sets the schedule of the backups of the service instance. A zero
+// interval disables the scheduled backups. See WatchBackups.
+func (s *ServiceClient) ScheduleBackups(ctx context.Context, namespace, name string, interval time.Duration, keep int) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		srv, err := s.kubeClient.GetSecret(ctx, namespace, serviceResourceName(name))
+		if err != nil {
+			return err
+		}
+
+		if interval > 0 {
+			if srv.Annotations == nil {
+				srv.Annotations = map[string]string{}
+			}
+			srv.Annotations[BackupIntervalAnnotation] = interval.String()
+			srv.Annotations[BackupKeepAnnotation] = strconv.Itoa(keep)
+		} else {
+			delete(srv.Annotations, BackupIntervalAnnotation)
+			delete(srv.Annotations, BackupKeepAnnotation)
+		}
+
+		_, err = s.kubeClient.Kubectl.CoreV1().Secrets(namespace).Update(ctx, srv, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// WatchBackups periodically backs up the services with a backup schedule, when their
+// newest backup is older than the interval of the schedule. After each backup the older
+// backups beyond the retention of the schedule are deleted. It returns when the context
+// is canceled.
+func WatchBackups(ctx context.Context, logger logr.Logger, interval time.Duration) {
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cluster, err := kubernetes.GetCluster(ctx)
+		if err != nil {
+			logger.Error(err, "service backups, no cluster access")
+			continue
+		}
+
+		client, err := NewKubernetesServiceClient(cluster)
+		if err != nil {
+			logger.Error(err, "service backups, no service client")
+			continue
+		}
+
+		client.scheduledBackups(ctx, logger)
+	}
+}
+
+// scheduledBackups runs the due backups of all services.
+func (s *ServiceClient) scheduledBackups(ctx context.Context, logger logr.Logger) {
+	secrets, err := s.kubeClient.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s,%s", ServiceNameLabelKey, CatalogServiceLabelKey),
+	})
+	if err != nil {
+		logger.Error(err, "listing the service instances")
+		return
+	}
+
+	for _, srv := range secrets.Items {
+		schedule, ok := srv.Annotations[BackupIntervalAnnotation]
+		if !ok {
+			continue
+		}
+
+		namespace := srv.Namespace
+		name := srv.Labels[ServiceNameLabelKey]
+		log := logger.WithValues("namespace", namespace, "service", name)
+
+		interval, err := time.ParseDuration(schedule)
+		if err != nil || interval <= 0 {
+			log.Info("bad backup interval", "interval", schedule)
+			continue
+		}
+		keep, _ := strconv.Atoi(srv.Annotations[BackupKeepAnnotation])
+
+		backups, err := s.Backups(ctx, namespace, name)
+		if err != nil {
+			log.Error(err, "listing the service backups")
+			continue
+		}
+		if len(backups) > 0 && time.Since(backups[0].CreatedAt.Time) < interval {
+			continue
+		}
+
+		catalogService, err := s.GetCatalogService(ctx, srv.Labels[CatalogServiceLabelKey])
+		if err != nil {
+			log.Error(err, "getting the catalog service")
+			continue
+		}
+
+		backup, err := s.Backup(ctx, namespace, name, *catalogService)
+		if err != nil {
+			log.Error(err, "scheduled backup failed")
+			continue
+		}
+		log.Info("scheduled backup done", "backup", backup.ID, "size", backup.Size)
+
+		if keep > 0 {
+			err := s.pruneBackups(ctx, namespace, name, keep)
+			if err != nil {
+				log.Error(err, "pruning the service backups")
+			}
+		}
+	}
+}
+
+// pruneBackups deletes the backups of the service instance beyond the `keep` newest.
+func (s *ServiceClient) pruneBackups(ctx context.Context, namespace, name string, keep int) error {
+	backups, err := s.Backups(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	if len(backups) <= keep {
+		return nil
+	}
+
+	store, err := backupStore(ctx, s.kubeClient)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups[keep:] {
+		err := store.DeleteObject(ctx, backupPath(namespace, name)+backup.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteNamespaceBackups deletes the backups of all services of the namespace, when the
+// namespace is deleted. A later namespace of the same name must not see them.
+func (s *ServiceClient) DeleteNamespaceBackups(ctx context.Context, namespace string) error {
+	store, err := backupStore(ctx, s.kubeClient)
+	if err != nil {
+		return err
+	}
+
+	objects, err := store.ListObjects(ctx, BackupPrefix+namespace+"/")
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		err := store.DeleteObject(ctx, object.Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backupPath returns the prefix of the names of the service's backups.
+func backupPath(namespace, name string) string {
+	return BackupPrefix + namespace + "/" + name + "/"
+}
+
+// backupStore returns the manager of the S3 storage holding the backups.
+func backupStore(ctx context.Context, cluster *kubernetes.Cluster) (*s3manager.Manager, error) {
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the S3 connection details from the Kubernetes secret")
+	}
+
+	manager, err := s3manager.New(connectionDetails)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating an S3 manager")
+	}
+
+	return manager, nil
+}
+
+// runHook executes the command of the hook in a running pod of the helm release. The
+// input is optional. The error of a failed command includes its error output.
+func runHook(ctx context.Context, cluster *kubernetes.Cluster, namespace, release string, hook models.ServiceHook, stdin io.Reader, stdout io.Writer) error {
+	selector := "app.kubernetes.io/instance=" + release
+	if hook.Selector != "" {
+		selector += "," + hook.Selector
+	}
+
+	pods, err := cluster.Kubectl.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing the service pods")
+	}
+
+	pod := ""
+	for _, candidate := range pods.Items {
+		if candidate.Status.Phase == corev1.PodRunning {
+			pod = candidate.Name
+			break
+		}
+	}
+	if pod == "" {
+		return fmt.Errorf("no running pod matching %s", selector)
+	}
+
+	request := cluster.Kubectl.CoreV1().RESTClient().
+		Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: hook.Container,
+			Command:   hook.Command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(cluster.RestConfig, "POST", request.URL())
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "command failed in pod %s: %s", pod, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package services_test
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewBackupID", func() {
+	created := time.Date(2022, 9, 1, 8, 30, 15, 123456789, time.UTC)
+
+	It("starts with the creation time, to the millisecond", func() {
+		id, err := services.NewBackupID(created)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(MatchRegexp(`^20220901-083015\.123-[0-9a-f]{8}$`))
+	})
+
+	It("keeps backups of the same time apart", func() {
+		first, err := services.NewBackupID(created)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := services.NewBackupID(created)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first).ToNot(Equal(second))
+	})
+})
@@ -2,10 +2,12 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	apiv1 "github.com/epinio/application/api/v1"
+	"github.com/epinio/epinio/internal/appchart"
 	"github.com/epinio/epinio/internal/helmchart"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
@@ -20,6 +22,9 @@ const (
 	CatalogServiceLabelKey              = "application.epinio.io/catalog-service-name"
 	CatalogServiceSecretTypesAnnotation = "application.epinio.io/catalog-service-secret-types"
 	CatalogServiceVersionLabelKey       = "application.epinio.io/catalog-service-version"
+	// CatalogServiceExtensionsAnnotation holds, as JSON, the parts of the spec of a catalog
+	// service which the CRD does not know, and which the API server would prune.
+	CatalogServiceExtensionsAnnotation = "application.epinio.io/catalog-service-extensions"
 	// COMPATIBILITY SUPPORT for services from before https://github.com/epinio/epinio/issues/1704 fix
 	TargetNamespaceLabelKey = "application.epinio.io/target-namespace"
 	// ServiceNameLabelKey is used to keep the original name
@@ -55,6 +60,113 @@ func (s *ServiceClient) ListCatalogServices(ctx context.Context) ([]*models.Cata
 	return services, nil
 }
 
+// CreateCatalogService adds the catalog service to the catalog. The entry is expected to
+// be checked by CatalogServiceEntry.
+func (s *ServiceClient) CreateCatalogService(ctx context.Context, entry *unstructured.Unstructured) error {
+	_, err := s.serviceKubeClient.Namespace(helmchart.Namespace()).Create(ctx, entry, metav1.CreateOptions{})
+	return err
+}
+
+// UpdateCatalogService replaces the catalog service with the entry. The entry is expected
+// to be checked by CatalogServiceEntry.
+func (s *ServiceClient) UpdateCatalogService(ctx context.Context, entry *unstructured.Unstructured) error {
+	client := s.serviceKubeClient.Namespace(helmchart.Namespace())
+
+	current, err := client.Get(ctx, entry.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Keep the labels and annotations of the current resource which are not set by the
+	// entry, i.e. those of helm for the catalog services installed with Epinio.
+	labels := current.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range entry.GetLabels() {
+		labels[key] = value
+	}
+	annotations := current.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	delete(annotations, CatalogServiceExtensionsAnnotation)
+	for key, value := range entry.GetAnnotations() {
+		annotations[key] = value
+	}
+
+	current.SetLabels(labels)
+	current.SetAnnotations(annotations)
+	current.Object["spec"] = entry.Object["spec"]
+
+	_, err = client.Update(ctx, current, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteCatalogService removes the catalog service from the catalog.
+func (s *ServiceClient) DeleteCatalogService(ctx context.Context, name string) error {
+	return s.serviceKubeClient.Namespace(helmchart.Namespace()).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// CatalogServiceEntry checks the catalog service to add or update, and returns the custom
+// resource to store, and the catalog service it declares. The name of the resource and the
+// name of the catalog service are the same, missing ones are filled in from each other.
+func CatalogServiceEntry(object map[string]interface{}) (*unstructured.Unstructured, *models.CatalogService, error) {
+	entry := &unstructured.Unstructured{Object: object}
+
+	if entry.GetAPIVersion() == "" {
+		entry.SetAPIVersion("application.epinio.io/v1")
+	}
+	if entry.GetKind() == "" {
+		entry.SetKind("Service")
+	}
+	if entry.GetAPIVersion() != "application.epinio.io/v1" || entry.GetKind() != "Service" {
+		return nil, nil, fmt.Errorf("expected a application.epinio.io/v1 Service, got a %s %s",
+			entry.GetAPIVersion(), entry.GetKind())
+	}
+
+	name := entry.GetName()
+	specName, _, err := unstructured.NestedString(object, "spec", "name")
+	if err != nil {
+		return nil, nil, errors.New("spec.name should be string")
+	}
+	if name == "" {
+		name = specName
+	}
+	if specName == "" {
+		specName = name
+	}
+	if name == "" {
+		return nil, nil, errors.New("the name of the catalog service is missing")
+	}
+	if name != specName {
+		return nil, nil, fmt.Errorf("the names of the resource and the catalog service differ, %s and %s",
+			name, specName)
+	}
+
+	entry.SetName(name)
+	entry.SetNamespace(helmchart.Namespace())
+	err = unstructured.SetNestedField(object, name, "spec", "name")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	catalogService, err := convertUnstructuredIntoCatalogService(*entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	if catalogService.HelmChart == "" {
+		return nil, nil, errors.New("the chart of the catalog service is missing")
+	}
+
+	err = storeCatalogExtensions(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, catalogService, nil
+}
+
 func convertUnstructuredListIntoCatalogService(unstructuredList *unstructured.UnstructuredList) ([]*models.CatalogService, error) {
 	catalogServices := []*models.CatalogService{}
 
@@ -76,6 +188,37 @@ func convertUnstructuredIntoCatalogService(unstructured unstructured.Unstructure
 		return nil, errors.Wrap(err, "error converting catalog service")
 	}
 
+	// The settings, hooks, credentials and namespaces are not part of the CRD, and are
+	// decoded from the raw object, with the parts kept in the annotation merged in.
+	object, err := withCatalogExtensions(unstructured.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting catalog service extensions")
+	}
+
+	settings, err := appchart.Settings(object, "spec", "settings")
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting catalog service settings")
+	}
+
+	backup, err := hook(object, "backup")
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting catalog service backup")
+	}
+	restore, err := hook(object, "restore")
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting catalog service restore")
+	}
+
+	credentials, err := catalogCredentials(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting catalog service credentials")
+	}
+
+	namespaces, err := catalogNamespaces(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting catalog service namespaces")
+	}
+
 	secretTypes := []string{}
 	secretTypesAnnotationValue := catalogService.GetAnnotations()[CatalogServiceSecretTypesAnnotation]
 	if len(secretTypesAnnotationValue) > 0 {
@@ -98,6 +241,155 @@ func convertUnstructuredIntoCatalogService(unstructured unstructured.Unstructure
 			Name: catalogService.Spec.HelmRepo.Name,
 			URL:  catalogService.Spec.HelmRepo.URL,
 		},
-		Values: catalogService.Spec.Values,
+		Values:      catalogService.Spec.Values,
+		Settings:    settings,
+		Backup:      backup,
+		Restore:     restore,
+		Credentials: credentials,
+		Namespaces:  namespaces,
+	}, nil
+}
+
+// catalogExtensions are the fields of the spec of a catalog service which are not part of
+// the CRD.
+var catalogExtensions = []string{"settings", "credentials", "namespaces", "backup", "restore"}
+
+// storeCatalogExtensions moves the fields of the spec of the entry which are not part of the
+// CRD into the CatalogServiceExtensionsAnnotation, where the API server keeps them. Fields
+// already in the annotation are kept, unless the spec sets them too.
+func storeCatalogExtensions(entry *unstructured.Unstructured) error {
+	merged, err := withCatalogExtensions(entry.Object)
+	if err != nil {
+		return errors.Wrap(err, "error decoding catalog service extensions")
+	}
+	spec, ok := merged["spec"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	extensions := map[string]interface{}{}
+	for _, field := range catalogExtensions {
+		if value, ok := spec[field]; ok {
+			extensions[field] = value
+			delete(spec, field)
+		}
+	}
+	entry.Object["spec"] = spec
+
+	annotations := entry.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	delete(annotations, CatalogServiceExtensionsAnnotation)
+
+	if len(extensions) > 0 {
+		value, err := json.Marshal(extensions)
+		if err != nil {
+			return errors.Wrap(err, "error encoding catalog service extensions")
+		}
+		annotations[CatalogServiceExtensionsAnnotation] = string(value)
+	}
+
+	entry.SetAnnotations(annotations)
+	return nil
+}
+
+// withCatalogExtensions returns the object of a catalog service, with the fields kept in
+// the CatalogServiceExtensionsAnnotation merged into its spec. Fields present in the spec
+// itself take precedence. The object is not modified.
+func withCatalogExtensions(object map[string]interface{}) (map[string]interface{}, error) {
+	value, ok, _ := unstructured.NestedString(object, "metadata", "annotations", CatalogServiceExtensionsAnnotation)
+	if !ok || value == "" {
+		return object, nil
+	}
+
+	extensions := map[string]interface{}{}
+	err := json.Unmarshal([]byte(value), &extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{}
+	if current, ok := object["spec"].(map[string]interface{}); ok {
+		for key, value := range current {
+			spec[key] = value
+		}
+	}
+	for key, value := range extensions {
+		if _, ok := spec[key]; !ok {
+			spec[key] = value
+		}
+	}
+
+	merged := map[string]interface{}{}
+	for key, value := range object {
+		merged[key] = value
+	}
+	merged["spec"] = spec
+
+	return merged, nil
+}
+
+// catalogNamespaces decodes the namespaces the catalog service is restricted to. Like the
+// settings they are not part of the CRD struct.
+func catalogNamespaces(object map[string]interface{}) ([]string, error) {
+	namespaces, _, err := unstructured.NestedStringSlice(object, "spec", "namespaces")
+	if err != nil {
+		return nil, errors.New("namespaces should be string slice")
+	}
+	return namespaces, nil
+}
+
+// catalogCredentials decodes the declaration of the credentials of the catalog service.
+// Like the settings it is not part of the CRD struct. Credentials without fields are nil.
+func catalogCredentials(object map[string]interface{}) (*models.ServiceCredentials, error) {
+	fields, _, err := unstructured.NestedStringSlice(object, "spec", "credentials", "fields")
+	if err != nil {
+		return nil, errors.New("fields should be string slice")
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	credentialsHook, err := hook(object, "credentials", "hook")
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ServiceCredentials{
+		Fields: fields,
+		Hook:   credentialsHook,
+	}, nil
+}
+
+// hook decodes the hook of the catalog service found at the path below the spec. Like the
+// settings it is not part of the CRD struct. A missing hook, or a hook without command,
+// is nil.
+func hook(object map[string]interface{}, path ...string) (*models.ServiceHook, error) {
+	field := func(name string) []string {
+		return append(append([]string{"spec"}, path...), name)
+	}
+
+	command, _, err := unstructured.NestedStringSlice(object, field("command")...)
+	if err != nil {
+		return nil, errors.New("command should be string slice")
+	}
+	if len(command) == 0 {
+		return nil, nil
+	}
+
+	container, _, err := unstructured.NestedString(object, field("container")...)
+	if err != nil {
+		return nil, errors.New("container should be string")
+	}
+	selector, _, err := unstructured.NestedString(object, field("selector")...)
+	if err != nil {
+		return nil, errors.New("selector should be string")
+	}
+
+	return &models.ServiceHook{
+		Command:   command,
+		Container: container,
+		Selector:  selector,
 	}, nil
 }
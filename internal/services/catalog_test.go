@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"encoding/json"
+
+	"github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+)
+
+var _ = Describe("CatalogServiceEntry", func() {
+	BeforeEach(func() {
+		viper.Set("namespace", "epinio")
+	})
+
+	AfterEach(func() {
+		viper.Set("namespace", "")
+	})
+
+	It("fills in the missing parts of the resource", func() {
+		entry, catalogService, err := services.CatalogServiceEntry(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"name":       "postgresql-dev",
+				"chart":      "postgresql",
+				"namespaces": []interface{}{"workspace"},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entry.GetAPIVersion()).To(Equal("application.epinio.io/v1"))
+		Expect(entry.GetKind()).To(Equal("Service"))
+		Expect(entry.GetName()).To(Equal("postgresql-dev"))
+		Expect(entry.GetNamespace()).To(Equal("epinio"))
+		Expect(catalogService.Meta.Name).To(Equal("postgresql-dev"))
+		Expect(catalogService.HelmChart).To(Equal("postgresql"))
+		Expect(catalogService.Namespaces).To(ConsistOf("workspace"))
+	})
+
+	It("takes the name of the catalog service from the resource", func() {
+		_, catalogService, err := services.CatalogServiceEntry(map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "postgresql-dev"},
+			"spec":     map[string]interface{}{"chart": "postgresql"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(catalogService.Meta.Name).To(Equal("postgresql-dev"))
+	})
+
+	It("rejects differing names", func() {
+		_, _, err := services.CatalogServiceEntry(map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "postgresql-dev"},
+			"spec":     map[string]interface{}{"name": "postgresql", "chart": "postgresql"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("names of the resource and the catalog service differ")))
+	})
+
+	It("rejects other resources", func() {
+		_, _, err := services.CatalogServiceEntry(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"spec":       map[string]interface{}{"name": "postgresql", "chart": "postgresql"},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("keeps the fields unknown to the CRD in an annotation", func() {
+		entry, _, err := services.CatalogServiceEntry(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"name":       "postgresql-dev",
+				"chart":      "postgresql",
+				"namespaces": []interface{}{"workspace"},
+				"settings": map[string]interface{}{
+					"size": map[string]interface{}{"type": "integer", "minimum": "1"},
+				},
+				"credentials": map[string]interface{}{"fields": []interface{}{"password"}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entry.Object["spec"]).ToNot(HaveKey("namespaces"))
+		Expect(entry.Object["spec"]).ToNot(HaveKey("settings"))
+		Expect(entry.Object["spec"]).ToNot(HaveKey("credentials"))
+		Expect(entry.GetAnnotations()).To(HaveKey(services.CatalogServiceExtensionsAnnotation))
+
+		// The stored resource, as the API server returns it, declares the same service.
+		stored, err := json.Marshal(entry.Object)
+		Expect(err).ToNot(HaveOccurred())
+		object := map[string]interface{}{}
+		Expect(json.Unmarshal(stored, &object)).To(Succeed())
+
+		_, catalogService, err := services.CatalogServiceEntry(object)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(catalogService.Namespaces).To(ConsistOf("workspace"))
+		Expect(catalogService.Settings).To(HaveKey("size"))
+		Expect(catalogService.Settings["size"].Minimum).To(Equal("1"))
+		Expect(catalogService.Credentials).ToNot(BeNil())
+		Expect(catalogService.Credentials.Fields).To(ConsistOf("password"))
+	})
+
+	It("requires a chart", func() {
+		_, _, err := services.CatalogServiceEntry(map[string]interface{}{
+			"spec": map[string]interface{}{"name": "postgresql"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("chart of the catalog service is missing")))
+	})
+})
+
+var _ = Describe("AvailableIn", func() {
+	It("is restricted to the namespaces of the catalog service", func() {
+		_, catalogService, err := services.CatalogServiceEntry(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"name":       "postgresql",
+				"chart":      "postgresql",
+				"namespaces": []interface{}{"workspace"},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(catalogService.AvailableIn("workspace")).To(BeTrue())
+		Expect(catalogService.AvailableIn("other")).To(BeFalse())
+	})
+
+	It("is unrestricted without namespaces", func() {
+		_, catalogService, err := services.CatalogServiceEntry(map[string]interface{}{
+			"spec": map[string]interface{}{"name": "postgresql", "chart": "postgresql"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(catalogService.AvailableIn("other")).To(BeTrue())
+	})
+})
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/epinio/epinio/helpers/randstr"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RotateCredentials sets the credentials of the service instance to new random values, as
+// declared by the catalog service. The hook of the declaration, if any, changes them in
+// the running service first. Then the helm release is upgraded with the new values. The
+// other values, and the chart version, are kept. When the upgrade fails the hook is run
+// again, with the deployed credentials, to keep the service and the release in agreement.
+func (s *ServiceClient) RotateCredentials(ctx context.Context, namespace, name string, catalogService models.CatalogService) error {
+	logger := requestctx.Logger(ctx)
+	ref := models.NewAppRef(name, namespace)
+
+	if catalogService.Credentials == nil {
+		return errors.New("the catalog service does not declare credentials")
+	}
+
+	release, err := helm.ServiceRelease(s.kubeClient, logger, ref)
+	if err != nil {
+		return errors.Wrap(err, "fetching the service helm release")
+	}
+
+	credentials := map[string]string{}
+	for _, field := range catalogService.Credentials.Fields {
+		credentials[field], err = randstr.Hex16()
+		if err != nil {
+			return errors.Wrap(err, "generating the credentials")
+		}
+	}
+
+	values := map[string]interface{}{}
+	for key, value := range release.Config {
+		values[key] = value
+	}
+	for field, value := range credentials {
+		if err := setField(values, strings.Split(field, "."), value); err != nil {
+			return errors.Wrapf(err, `Credential "%s"`, field)
+		}
+	}
+
+	rendered, err := yaml.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "rendering the service values")
+	}
+
+	hook := catalogService.Credentials.Hook
+	runCredentialsHook := func(credentials map[string]string) error {
+		input, err := json.Marshal(credentials)
+		if err != nil {
+			return err
+		}
+		return runHook(ctx, s.kubeClient, namespace, names.ServiceReleaseName(name), *hook,
+			bytes.NewReader(input), io.Discard)
+	}
+
+	if hook != nil {
+		err = runCredentialsHook(credentials)
+		if err != nil {
+			return errors.Wrap(err, "changing the credentials of the service")
+		}
+	}
+
+	err = helm.DeployService(logger,
+		helm.ServiceParameters{
+			AppRef:     ref,
+			Context:    ctx,
+			Cluster:    s.kubeClient,
+			Chart:      catalogService.HelmChart,
+			Version:    chartVersionOf(release),
+			Repository: catalogService.HelmRepo.URL,
+			Values:     string(rendered),
+		})
+	if err == nil || hook == nil {
+		return errors.Wrap(err, "error deploying service helm chart")
+	}
+
+	// The service runs with the new credentials, while the release, and thus the
+	// configurations of the bound applications, still hold the old ones. Change the
+	// service back to them.
+	previous, ok := DeployedCredentials(release.Config, catalogService.Credentials.Fields)
+	if !ok {
+		logger.Info("cannot restore the credentials of the service, the deployed values do not hold them",
+			"namespace", namespace, "service", name)
+		return errors.Wrap(err, "error deploying service helm chart, the credentials of the service are changed and could not be restored")
+	}
+	if restoreErr := runCredentialsHook(previous); restoreErr != nil {
+		return errors.Wrapf(err, "error deploying service helm chart, restoring the credentials of the service failed: %s", restoreErr.Error())
+	}
+
+	return errors.Wrap(err, "error deploying service helm chart, the credentials of the service were restored")
+}
+
+// DeployedCredentials returns the values of the credential fields in the deployed values.
+// The result is false if any of the fields is missing, e.g. because the chart generated
+// it.
+func DeployedCredentials(deployed map[string]interface{}, fields []string) (map[string]string, bool) {
+	credentials := map[string]string{}
+	for _, field := range fields {
+		value, found := lookupField(deployed, strings.Split(field, "."))
+		if !found {
+			return nil, false
+		}
+		credentials[field] = fmt.Sprintf("%v", value)
+	}
+	return credentials, true
+}
+
+// KeepCredentials returns the values with the credentials of the catalog service set to
+// their deployed values, if any. This keeps rotated credentials when the values of the
+// service are recomputed from the catalog service and the settings.
+func KeepCredentials(values string, deployed map[string]interface{}, declaration *models.ServiceCredentials) (string, error) {
+	if declaration == nil {
+		return values, nil
+	}
+
+	result := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(values), &result); err != nil {
+		return "", errors.Wrap(err, "parsing the service values")
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+
+	for _, field := range declaration.Fields {
+		value, found := lookupField(deployed, strings.Split(field, "."))
+		if !found {
+			continue
+		}
+		if err := setField(result, strings.Split(field, "."), value); err != nil {
+			return "", errors.Wrapf(err, `Credential "%s"`, field)
+		}
+	}
+
+	rendered, err := yaml.Marshal(result)
+	if err != nil {
+		return "", errors.Wrap(err, "rendering the service values")
+	}
+
+	return string(rendered), nil
+}
+
+// lookupField returns the value of the field at the path, if present.
+func lookupField(values map[string]interface{}, path []string) (interface{}, bool) {
+	for _, field := range path[:len(path)-1] {
+		child, ok := values[field].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		values = child
+	}
+
+	value, found := values[path[len(path)-1]]
+	return value, found
+}
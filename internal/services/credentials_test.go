@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeepCredentials", func() {
+	declaration := &models.ServiceCredentials{
+		Fields: []string{"auth.password", "auth.replicationPassword"},
+	}
+
+	It("keeps the deployed credentials", func() {
+		deployed := map[string]interface{}{
+			"auth": map[string]interface{}{
+				"password": "rotated",
+			},
+		}
+
+		values, err := services.KeepCredentials("auth:\n  password: initial\n  username: user\n", deployed, declaration)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal("auth:\n  password: rotated\n  username: user\n"))
+	})
+
+	It("keeps the values without deployed credentials", func() {
+		values, err := services.KeepCredentials("auth:\n  password: initial\n", map[string]interface{}{}, declaration)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal("auth:\n  password: initial\n"))
+	})
+
+	It("keeps the values without declaration", func() {
+		values, err := services.KeepCredentials("a: 1", map[string]interface{}{}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal("a: 1"))
+	})
+})
+
+var _ = Describe("DeployedCredentials", func() {
+	fields := []string{"auth.password", "auth.replicationPassword"}
+
+	It("returns the deployed credentials", func() {
+		deployed := map[string]interface{}{
+			"auth": map[string]interface{}{
+				"password":            "old",
+				"replicationPassword": "older",
+			},
+		}
+
+		credentials, ok := services.DeployedCredentials(deployed, fields)
+		Expect(ok).To(BeTrue())
+		Expect(credentials).To(Equal(map[string]string{
+			"auth.password":            "old",
+			"auth.replicationPassword": "older",
+		}))
+	})
+
+	It("fails when a credential is not deployed", func() {
+		deployed := map[string]interface{}{
+			"auth": map[string]interface{}{
+				"password": "old",
+			},
+		}
+
+		_, ok := services.DeployedCredentials(deployed, fields)
+		Expect(ok).To(BeFalse())
+	})
+})
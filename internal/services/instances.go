@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/epinio/epinio/helpers/tracelog"
@@ -12,8 +14,11 @@ import (
 	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	helmapiv1 "github.com/k3s-io/helm-controller/pkg/apis/helm.cattle.io/v1"
@@ -47,7 +52,7 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 	catalogServiceVersion := srv.GetLabels()[CatalogServiceVersionLabelKey]
 
 	var catalogServicePrefix string
-	_, err = s.GetCatalogService(ctx, catalogServiceName)
+	catalogService, err := s.GetCatalogService(ctx, catalogServiceName)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			catalogServicePrefix = "[Missing] "
@@ -68,6 +73,12 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 		return nil, errors.Wrap(err, "fetching the services")
 	}
 
+	// The secret data holds the settings chosen at creation.
+	settings := models.AppSettings{}
+	for key, value := range srv.Data {
+		settings[key] = string(value)
+	}
+
 	service = models.Service{
 		Meta: models.Meta{
 			Name:      name,
@@ -78,6 +89,13 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 		CatalogService:        fmt.Sprintf("%s%s", catalogServicePrefix, catalogServiceName),
 		CatalogServiceVersion: catalogServiceVersion,
 		InternalRoutes:        internalRoutes,
+		Settings:              settings,
+		BackupInterval:        srv.GetAnnotations()[BackupIntervalAnnotation],
+		Shares:                serviceShares(srv.GetAnnotations()[ServiceSharesAnnotation]),
+	}
+
+	if keep, ok := srv.GetAnnotations()[BackupKeepAnnotation]; ok {
+		service.BackupKeep, _ = strconv.Atoi(keep)
 	}
 
 	logger := tracelog.NewLogger().WithName("ServiceStatus")
@@ -91,6 +109,28 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 	}
 
 	service.Status = models.NewServiceStatusFromHelmRelease(serviceStatus)
+	service.Phase = ServicePhaseOf(models.ServicePhase(srv.GetAnnotations()[ServicePhaseAnnotation]), serviceStatus)
+	service.Reason = srv.GetAnnotations()[ServiceFailureAnnotation]
+
+	if service.Phase != models.ServicePhaseReady {
+		service.Warnings, err = s.warnings(ctx, namespace, name)
+		if err != nil {
+			return &service, err
+		}
+	}
+
+	release, err := helm.ServiceRelease(s.kubeClient, logger, models.NewAppRef(name, namespace))
+	if err != nil && !errors.Is(err, helmdriver.ErrReleaseNotFound) {
+		return &service, errors.Wrap(err, "finding helm release")
+	}
+	if release != nil && release.Info != nil {
+		service.Notes = release.Info.Notes
+	}
+
+	service.ChartVersion = chartVersionOf(release)
+	if catalogService != nil && NewerChart(service.ChartVersion, catalogService.ChartVersion) {
+		service.AvailableChartVersion = catalogService.ChartVersion
+	}
 
 	return &service, nil
 }
@@ -118,13 +158,124 @@ func GetInternalRoutes(ctx context.Context, servicesGetter v1.ServiceInterface,
 	return internalRoutes, nil
 }
 
-func (s *ServiceClient) Create(ctx context.Context, namespace, name string, catalogService models.CatalogService) error {
+// GetPortForwardTarget returns the name of a running pod behind the kubernetes services of
+// the service's Helm release, i.e. a pod the ports of the service can be forwarded to, and
+// the ports of the services mapped to the ports of that pod. Named target ports are
+// resolved against the containers of the pod. A non-empty instance restricts the search to
+// the pod of that name. The pod name is empty if there is no such pod.
+func GetPortForwardTarget(ctx context.Context, servicesGetter v1.ServiceInterface, podsGetter v1.PodInterface, name, instance string) (string, map[int32]int32, error) {
+	servicesList, err := servicesGetter.List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/instance=" + names.ServiceReleaseName(name),
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "fetching the services")
+	}
+
+	kubeServices := servicesList.Items
+	sort.Slice(kubeServices, func(i, j int) bool {
+		return kubeServices[i].Name < kubeServices[j].Name
+	})
+
+	var target *corev1.Pod
+	for _, kubeService := range kubeServices {
+		// Services without selector, e.g. headless ones with manual endpoints, have
+		// no pods to forward to.
+		if len(kubeService.Spec.Selector) == 0 {
+			continue
+		}
+
+		pods, err := podsGetter.List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(kubeService.Spec.Selector).String(),
+		})
+		if err != nil {
+			return "", nil, errors.Wrap(err, "fetching the pods")
+		}
+
+		for i, pod := range pods.Items {
+			if instance != "" && pod.Name != instance {
+				continue
+			}
+			if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+				target = &pods.Items[i]
+				break
+			}
+		}
+		if target != nil {
+			break
+		}
+	}
+
+	if target == nil {
+		return "", nil, nil
+	}
+
+	// All services selecting the pod contribute their ports. For the same port of
+	// several services the first one wins.
+	ports := map[int32]int32{}
+	for _, kubeService := range kubeServices {
+		if len(kubeService.Spec.Selector) == 0 ||
+			!labels.SelectorFromSet(kubeService.Spec.Selector).Matches(labels.Set(target.Labels)) {
+			continue
+		}
+		for _, port := range kubeService.Spec.Ports {
+			if _, ok := ports[port.Port]; ok {
+				continue
+			}
+			if podPort, ok := podPort(target, port); ok {
+				ports[port.Port] = podPort
+			}
+		}
+	}
+
+	return target.Name, ports, nil
+}
+
+// podPort returns the port of the pod the service port targets. A target port given by
+// name is resolved against the container ports of the pod, as kubernetes does for the
+// endpoints of the service.
+func podPort(pod *corev1.Pod, port corev1.ServicePort) (int32, bool) {
+	switch {
+	case port.TargetPort.Type == intstr.String && port.TargetPort.StrVal != "":
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				containerProtocol := containerPort.Protocol
+				if containerProtocol == "" {
+					containerProtocol = corev1.ProtocolTCP
+				}
+				if containerPort.Name == port.TargetPort.StrVal && containerProtocol == protocol {
+					return containerPort.ContainerPort, true
+				}
+			}
+		}
+		return 0, false
+	case port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal, true
+	}
+
+	// Without target port, kubernetes targets the port of the service itself.
+	return port.Port, true
+}
+
+// Create creates the service instance, deploying the helm chart of the catalog service in
+// the background. The settings are expected to be validated against the declarations of
+// the catalog service by the caller. They are merged into the values of the catalog
+// service.
+func (s *ServiceClient) Create(ctx context.Context, namespace, name string, settings models.AppSettings, catalogService models.CatalogService) error {
 	// Resources, and names
 	//
-	// |Kind	|Name		|Notes			|
-	// |---		|---		|---			|
-	// |secret	|"s-"+name	|epinio management data	|
-	// |helm release|see above	|active workload	|
+	// |Kind	|Name		|Notes					|
+	// |---		|---		|---					|
+	// |secret	|"s-"+name	|epinio management data, user settings	|
+	// |helm release|see above	|active workload			|
+
+	values, err := MergeSettings(catalogService.Values, settings, catalogService.Settings)
+	if err != nil {
+		return errors.Wrap(err, "error merging the service settings")
+	}
 
 	service := serviceResourceName(name)
 	labels := map[string]string{
@@ -133,38 +284,37 @@ func (s *ServiceClient) Create(ctx context.Context, namespace, name string, cata
 		ServiceNameLabelKey:           name,
 	}
 
-	var annotations map[string]string // default: nil
+	annotations := map[string]string{
+		ServicePhaseAnnotation: models.ServicePhasePending.String(),
+	}
 	if len(catalogService.SecretTypes) > 0 {
-		annotations = map[string]string{
-			CatalogServiceSecretTypesAnnotation: strings.Join(catalogService.SecretTypes, ","),
-		}
+		annotations[CatalogServiceSecretTypesAnnotation] = strings.Join(catalogService.SecretTypes, ",")
+	}
+
+	data := map[string][]byte{}
+	for key, value := range settings {
+		data[key] = []byte(value)
 	}
 
-	err := s.kubeClient.CreateLabeledSecret(ctx, namespace, service, nil, labels, annotations)
+	err = s.kubeClient.CreateLabeledSecret(ctx, namespace, service, data, labels, annotations)
 	if err != nil {
 		return errors.Wrap(err, "error creating service secret")
 	}
 
-	err = helm.DeployService(
-		requestctx.Logger(ctx),
+	// The request does not wait for the deployment. Its progress is recorded in the
+	// secret, see Get.
+	go s.provision(
+		requestctx.WithLogger(context.Background(), requestctx.Logger(ctx)),
 		helm.ServiceParameters{
 			AppRef:     models.NewAppRef(name, namespace),
-			Context:    ctx,
 			Cluster:    s.kubeClient,
 			Chart:      catalogService.HelmChart,
 			Version:    catalogService.ChartVersion,
 			Repository: catalogService.HelmRepo.URL,
-			Values:     catalogService.Values,
+			Values:     values,
 		})
 
-	if err != nil {
-		errb := s.kubeClient.DeleteSecret(ctx, namespace, service)
-		if errb != nil {
-			return errors.Wrap(errb, "error deploying service helm chart while undoing the secret")
-		}
-	}
-
-	return errors.Wrap(err, "error deploying service helm chart")
+	return nil
 }
 
 // Delete deletes the helmcharts that matches the given service which is installed on the namespace
@@ -298,6 +448,7 @@ func (s *ServiceClient) list(ctx context.Context, namespace string) (models.Serv
 		}
 
 		service.Status = models.NewServiceStatusFromHelmRelease(serviceStatus)
+		service.Phase = ServicePhaseOf(models.ServicePhase(srv.GetAnnotations()[ServicePhaseAnnotation]), serviceStatus)
 
 		serviceList = append(serviceList, service)
 	}
@@ -393,6 +544,7 @@ func (s *ServiceClient) GetForHelmController(ctx context.Context, namespace, nam
 	}
 
 	service.Status = models.NewServiceStatusFromHelmRelease(serviceStatus)
+	service.Phase = ServicePhaseOf("", serviceStatus)
 
 	return &service, nil
 }
@@ -501,6 +653,7 @@ func (s *ServiceClient) listForHelmController(ctx context.Context, namespace str
 		}
 
 		service.Status = models.NewServiceStatusFromHelmRelease(serviceStatus)
+		service.Phase = ServicePhaseOf("", serviceStatus)
 
 		serviceList = append(serviceList, service)
 	}
@@ -13,6 +13,8 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
@@ -155,6 +157,125 @@ var _ = Describe("Service Instances", func() {
 			})
 		})
 	})
+
+	Describe("GetPortForwardTarget", func() {
+		var clientset *kubefake.Clientset
+		var release map[string]string
+		var instance string
+
+		BeforeEach(func() {
+			release = map[string]string{"app.kubernetes.io/instance": names.ServiceReleaseName(name)}
+			clientset = kubefake.NewSimpleClientset()
+			instance = ""
+		})
+
+		target := func() (string, map[int32]int32, error) {
+			return services.GetPortForwardTarget(ctx,
+				clientset.CoreV1().Services(namespace),
+				clientset.CoreV1().Pods(namespace),
+				name, instance)
+		}
+
+		podName := func() (string, error) {
+			pod, _, err := target()
+			return pod, err
+		}
+
+		addServiceWithPorts := func(serviceName string, selector map[string]string, ports ...corev1.ServicePort) {
+			service := newService(serviceName, namespace, nil)
+			service.Spec.Ports = ports
+			service.Labels = release
+			service.Spec.Selector = selector
+			_, err := clientset.CoreV1().Services(namespace).Create(ctx, &service, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		addService := func(serviceName string, selector map[string]string) {
+			addServiceWithPorts(serviceName, selector, corev1.ServicePort{Port: 5432})
+		}
+
+		addPod := func(podName string, podLabels map[string]string, phase corev1.PodPhase, ports ...corev1.ContainerPort) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace, Labels: podLabels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Ports: ports}}},
+				Status:     corev1.PodStatus{Phase: phase},
+			}
+			_, err := clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("returns a running pod selected by the services of the release", func() {
+			addService("db", map[string]string{"component": "primary"})
+			addPod("db-0", map[string]string{"component": "primary"}, corev1.PodPending)
+			addPod("db-1", map[string]string{"component": "primary"}, corev1.PodRunning)
+			addPod("other", map[string]string{"component": "other"}, corev1.PodRunning)
+
+			pod, err := podName()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).To(Equal("db-1"))
+		})
+
+		It("ignores services without selector", func() {
+			addService("db-headless", nil)
+			addPod("db-0", map[string]string{"component": "primary"}, corev1.PodRunning)
+
+			pod, err := podName()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).To(BeEmpty())
+		})
+
+		It("ignores services of other releases", func() {
+			addService("db", map[string]string{"component": "primary"})
+			release = map[string]string{"app.kubernetes.io/instance": "other"}
+			addService("cache", map[string]string{"component": "cache"})
+			addPod("cache-0", map[string]string{"component": "cache"}, corev1.PodRunning)
+
+			pod, err := podName()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).To(BeEmpty())
+		})
+
+		It("returns the requested instance only", func() {
+			addService("db", map[string]string{"component": "primary"})
+			addPod("db-0", map[string]string{"component": "primary"}, corev1.PodRunning)
+			addPod("db-1", map[string]string{"component": "primary"}, corev1.PodRunning)
+
+			instance = "db-1"
+			pod, err := podName()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).To(Equal("db-1"))
+
+			instance = "db-2"
+			pod, err = podName()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).To(BeEmpty())
+		})
+
+		It("maps the service ports to the target ports of the pod", func() {
+			selector := map[string]string{"component": "primary"}
+			addServiceWithPorts("db", selector,
+				corev1.ServicePort{Port: 5432, TargetPort: intstr.FromInt(15432)},
+				corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")},
+				corev1.ServicePort{Port: 9187},
+				corev1.ServicePort{Port: 81, TargetPort: intstr.FromString("missing")})
+			addServiceWithPorts("db-metrics", selector,
+				corev1.ServicePort{Port: 5432, TargetPort: intstr.FromInt(25432)},
+				corev1.ServicePort{Port: 9090, TargetPort: intstr.FromString("metrics")})
+			addPod("db-0", selector, corev1.PodRunning,
+				corev1.ContainerPort{Name: "http", ContainerPort: 8080},
+				corev1.ContainerPort{Name: "metrics", ContainerPort: 9091, Protocol: corev1.ProtocolTCP})
+
+			pod, ports, err := target()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod).To(Equal("db-0"))
+			Expect(ports).To(Equal(map[int32]int32{
+				5432: 15432,
+				80:   8080,
+				9187: 9187,
+				9090: 9091,
+			}))
+		})
+	})
 })
 
 func newServiceList(services ...corev1.Service) *corev1.ServiceList {
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	helmdriver "helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubectl/pkg/util/podutils"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+const (
+	// ServicePhaseAnnotation records the phase of the asynchronous provisioning of the
+	// service instance, and ServiceFailureAnnotation the error of a failed provisioning.
+	ServicePhaseAnnotation   = "application.epinio.io/service-phase"
+	ServiceFailureAnnotation = "application.epinio.io/service-failure"
+)
+
+// provision deploys the helm chart of the service instance, recording the progress in
+// the service's secret. It runs after the request creating the service has returned.
+func (s *ServiceClient) provision(ctx context.Context, parameters helm.ServiceParameters) {
+	log := requestctx.Logger(ctx).WithName("ServiceProvision").
+		WithValues("namespace", parameters.Namespace, "service", parameters.Name)
+
+	err := s.recordPhase(ctx, parameters.Namespace, parameters.Name, models.ServicePhaseInstalling, "")
+	if err != nil {
+		log.Error(err, "recording the phase")
+	}
+
+	parameters.Context = ctx
+	err = helm.DeployService(log, parameters)
+	if err != nil {
+		log.Error(err, "deploying the service helm chart")
+
+		err = s.recordPhase(ctx, parameters.Namespace, parameters.Name, models.ServicePhaseFailed, err.Error())
+		if err != nil {
+			log.Error(err, "recording the phase")
+		}
+		return
+	}
+
+	err = s.recordPhase(ctx, parameters.Namespace, parameters.Name, models.ServicePhaseReady, "")
+	if err != nil {
+		log.Error(err, "recording the phase")
+	}
+}
+
+// ReconcileProvisioning resolves the provisioning of the services left unfinished by a
+// previous run of the server, which the server restart interrupted. It waits for the
+// deployment timeout first, so that provisioning still running in a previous server, as
+// during a rolling update, has ended. Services whose helm release was deployed are marked
+// ready. The others are marked failed, their stuck release is removed, to allow deleting
+// and creating them again.
+func ReconcileProvisioning(ctx context.Context, logger logr.Logger) {
+	started := time.Now()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(duration.ToDeployment()):
+	}
+
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		logger.Error(err, "service reconciliation, no cluster access")
+		return
+	}
+
+	client, err := NewKubernetesServiceClient(cluster)
+	if err != nil {
+		logger.Error(err, "service reconciliation, no service client")
+		return
+	}
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s,%s", ServiceNameLabelKey, CatalogServiceLabelKey),
+	})
+	if err != nil {
+		logger.Error(err, "listing the service instances")
+		return
+	}
+
+	for _, srv := range secrets.Items {
+		// Services created by this server are provisioned by it.
+		if !srv.CreationTimestamp.Time.Before(started) {
+			continue
+		}
+
+		recorded := models.ServicePhase(srv.Annotations[ServicePhaseAnnotation])
+		if recorded != models.ServicePhasePending && recorded != models.ServicePhaseInstalling {
+			continue
+		}
+
+		namespace := srv.Namespace
+		name := srv.Labels[ServiceNameLabelKey]
+		log := logger.WithValues("namespace", namespace, "service", name)
+
+		status, err := helm.Status(ctx, log, cluster, namespace, names.ServiceReleaseName(name))
+		if err != nil && !errors.Is(err, helmdriver.ErrReleaseNotFound) {
+			log.Error(err, "finding helm release status")
+			continue
+		}
+
+		phase, reason := ReconciledPhase(status)
+		log.Info("reconciling interrupted provisioning", "status", status, "phase", phase)
+
+		if phase == models.ServicePhaseFailed && status != "" {
+			err = helm.RemoveService(log, cluster, models.NewAppRef(name, namespace))
+			if err != nil {
+				log.Error(err, "removing the stuck helm release")
+			}
+		}
+
+		err = client.recordPhase(ctx, namespace, name, phase, reason)
+		if err != nil {
+			log.Error(err, "recording the phase")
+		}
+	}
+}
+
+// ReconciledPhase returns the phase of a service whose provisioning was interrupted, and
+// the reason of a failure, from the status of its helm release, if any.
+func ReconciledPhase(status helmrelease.Status) (models.ServicePhase, string) {
+	switch status {
+	case helmrelease.StatusDeployed:
+		return models.ServicePhaseReady, ""
+	case helmrelease.StatusFailed:
+		return models.ServicePhaseFailed, "the installation of the helm release failed"
+	}
+	return models.ServicePhaseFailed, "the provisioning was interrupted by a restart of the Epinio server"
+}
+
+// recordPhase saves the phase of the provisioning, and the reason of a failure, in the
+// annotations of the service's secret.
+func (s *ServiceClient) recordPhase(ctx context.Context, namespace, name string, phase models.ServicePhase, reason string) error {
+	var failure interface{} // nil removes the annotation
+	if reason != "" {
+		failure = reason
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				ServicePhaseAnnotation:   phase.String(),
+				ServiceFailureAnnotation: failure,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.kubeClient.Kubectl.CoreV1().Secrets(namespace).Patch(ctx,
+		serviceResourceName(name), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// ServicePhaseOf derives the phase of the service instance from the status of its helm
+// release, and the phase recorded by the provisioning, if any. The recorded phase is used
+// when the release does not exist (yet). Note that a failed atomic installation removes
+// the release.
+func ServicePhaseOf(recorded models.ServicePhase, status helmrelease.Status) models.ServicePhase {
+	switch status {
+	case helmrelease.StatusDeployed:
+		return models.ServicePhaseReady
+	case helmrelease.StatusFailed:
+		return models.ServicePhaseFailed
+	case helmrelease.StatusPendingInstall, helmrelease.StatusPendingUpgrade, helmrelease.StatusPendingRollback:
+		return models.ServicePhaseInstalling
+	}
+
+	switch recorded {
+	case models.ServicePhaseInstalling, models.ServicePhaseFailed:
+		return recorded
+	}
+	return models.ServicePhasePending
+}
+
+// warnings returns the latest warning event of each pod of the service instance which is
+// not ready.
+func (s *ServiceClient) warnings(ctx context.Context, namespace, name string) ([]string, error) {
+	pods, err := s.kubeClient.Kubectl.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/instance=" + names.ServiceReleaseName(name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the service pods")
+	}
+
+	warnings := []string{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if podutils.IsPodReady(pod) {
+			continue
+		}
+
+		events, err := s.kubeClient.Kubectl.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s,type=%s",
+				pod.Name, corev1.EventTypeWarning),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing the service pod events")
+		}
+		if len(events.Items) == 0 {
+			continue
+		}
+
+		sort.Slice(events.Items, func(i, j int) bool {
+			return eventTime(events.Items[i]).Before(eventTime(events.Items[j]))
+		})
+		latest := events.Items[len(events.Items)-1]
+
+		warnings = append(warnings, fmt.Sprintf("pod %s: %s: %s", pod.Name, latest.Reason, latest.Message))
+	}
+
+	return warnings, nil
+}
+
+// eventTime returns the time the event was last seen.
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+)
+
+var _ = Describe("ServicePhaseOf", func() {
+	It("follows the status of the helm release", func() {
+		Expect(services.ServicePhaseOf(models.ServicePhaseInstalling, helmrelease.StatusDeployed)).
+			To(Equal(models.ServicePhaseReady))
+		Expect(services.ServicePhaseOf(models.ServicePhaseInstalling, helmrelease.StatusFailed)).
+			To(Equal(models.ServicePhaseFailed))
+		Expect(services.ServicePhaseOf(models.ServicePhasePending, helmrelease.StatusPendingInstall)).
+			To(Equal(models.ServicePhaseInstalling))
+	})
+
+	It("uses the recorded phase without helm release", func() {
+		Expect(services.ServicePhaseOf(models.ServicePhaseInstalling, "")).
+			To(Equal(models.ServicePhaseInstalling))
+		Expect(services.ServicePhaseOf(models.ServicePhaseFailed, "")).
+			To(Equal(models.ServicePhaseFailed))
+	})
+
+	It("is pending without helm release and recorded phase", func() {
+		Expect(services.ServicePhaseOf("", "")).To(Equal(models.ServicePhasePending))
+		Expect(services.ServicePhaseOf(models.ServicePhaseReady, "")).To(Equal(models.ServicePhasePending))
+	})
+})
+
+var _ = Describe("ReconciledPhase", func() {
+	It("is ready for a deployed helm release", func() {
+		phase, reason := services.ReconciledPhase(helmrelease.StatusDeployed)
+		Expect(phase).To(Equal(models.ServicePhaseReady))
+		Expect(reason).To(BeEmpty())
+	})
+
+	It("is failed for a failed helm release", func() {
+		phase, reason := services.ReconciledPhase(helmrelease.StatusFailed)
+		Expect(phase).To(Equal(models.ServicePhaseFailed))
+		Expect(reason).To(ContainSubstring("failed"))
+	})
+
+	It("is failed for a stuck or missing helm release", func() {
+		phase, reason := services.ReconciledPhase(helmrelease.StatusPendingInstall)
+		Expect(phase).To(Equal(models.ServicePhaseFailed))
+		Expect(reason).To(ContainSubstring("interrupted"))
+
+		phase, _ = services.ReconciledPhase("")
+		Expect(phase).To(Equal(models.ServicePhaseFailed))
+	})
+})
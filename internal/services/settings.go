@@ -0,0 +1,75 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// MergeSettings merges the user's settings into the values of a catalog service, and
+// returns the resulting values as YAML-formatted string. The settings are converted to
+// their declared types. A dotted setting name, like `primary.persistence.size`, refers to
+// a nested field of the values. Settings override the values of the catalog service.
+func MergeSettings(values string, settings models.AppSettings, declarations map[string]models.AppChartSetting) (string, error) {
+	if len(settings) == 0 {
+		return values, nil
+	}
+
+	merged := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(values), &merged); err != nil {
+		return "", errors.Wrap(err, "parsing the catalog service values")
+	}
+	if merged == nil {
+		// The values were empty, or an explicit null.
+		merged = map[string]interface{}{}
+	}
+
+	for key, value := range settings {
+		spec, found := declarations[key]
+		if !found {
+			return "", errors.Errorf(`Setting "%s": Not known`, key)
+		}
+
+		typed, err := helm.ValidateField(key, value, spec)
+		if err != nil {
+			return "", err
+		}
+
+		if err := setField(merged, strings.Split(key, "."), typed); err != nil {
+			return "", errors.Wrapf(err, `Setting "%s"`, key)
+		}
+	}
+
+	result, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", errors.Wrap(err, "rendering the service values")
+	}
+
+	return string(result), nil
+}
+
+// setField sets the field at the path to the value, creating the intermediate maps as
+// needed.
+func setField(values map[string]interface{}, path []string, value interface{}) error {
+	for _, field := range path[:len(path)-1] {
+		next, found := values[field]
+		if !found || next == nil {
+			child := map[string]interface{}{}
+			values[field] = child
+			values = child
+			continue
+		}
+
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return errors.Errorf(`field "%s" is not a map`, field)
+		}
+		values = child
+	}
+
+	values[path[len(path)-1]] = value
+	return nil
+}
@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("MergeSettings", func() {
+	declarations := map[string]models.AppChartSetting{
+		"storage":           {Type: "string"},
+		"replicas":          {Type: "integer", Minimum: "1", Maximum: "5"},
+		"auth.enabled":      {Type: "bool"},
+		"primary.resources": {Type: "string"},
+	}
+
+	values := `
+auth:
+  enabled: false
+  username: admin
+storage: 8Gi
+`
+
+	merge := func(settings models.AppSettings) (map[string]interface{}, error) {
+		merged, err := services.MergeSettings(values, settings, declarations)
+		if err != nil {
+			return nil, err
+		}
+		result := map[string]interface{}{}
+		Expect(yaml.Unmarshal([]byte(merged), &result)).To(Succeed())
+		return result, nil
+	}
+
+	It("returns the values unchanged without settings", func() {
+		merged, err := services.MergeSettings(values, models.AppSettings{}, declarations)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(merged).To(Equal(values))
+	})
+
+	It("overrides the values with the typed settings", func() {
+		result, err := merge(models.AppSettings{
+			"storage":      "20Gi",
+			"replicas":     "3",
+			"auth.enabled": "true",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveKeyWithValue("storage", "20Gi"))
+		Expect(result).To(HaveKeyWithValue("replicas", BeNumerically("==", 3)))
+		Expect(result).To(HaveKeyWithValue("auth", map[string]interface{}{
+			"enabled":  true,
+			"username": "admin",
+		}))
+	})
+
+	It("creates missing nested fields", func() {
+		result, err := merge(models.AppSettings{"primary.resources": "small"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveKeyWithValue("primary", map[string]interface{}{
+			"resources": "small",
+		}))
+	})
+
+	It("rejects undeclared settings", func() {
+		_, err := merge(models.AppSettings{"version": "8.0"})
+		Expect(err).To(MatchError(`Setting "version": Not known`))
+	})
+
+	It("rejects invalid values", func() {
+		_, err := merge(models.AppSettings{"replicas": "10"})
+		Expect(err).To(MatchError(ContainSubstring("too large")))
+	})
+
+	It("rejects settings nested below scalar values", func() {
+		_, err := services.MergeSettings(values, models.AppSettings{"storage.size": "1Gi"},
+			map[string]models.AppChartSetting{"storage.size": {Type: "string"}})
+		Expect(err).To(MatchError(ContainSubstring(`field "storage" is not a map`)))
+	})
+})
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// ServiceSharesAnnotation on the service secret holds the comma-separated list of the
+// namespaces the service is shared with.
+const ServiceSharesAnnotation = "application.epinio.io/service-shares"
+
+// RecordShare adds the target namespace to, or removes it from, the namespaces the service
+// instance is shared with.
+func (s *ServiceClient) RecordShare(ctx context.Context, namespace, name, target string, shared bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		srv, err := s.kubeClient.GetSecret(ctx, namespace, serviceResourceName(name))
+		if err != nil {
+			return err
+		}
+
+		shares := WithShare(serviceShares(srv.Annotations[ServiceSharesAnnotation]), target, shared)
+		if len(shares) > 0 {
+			if srv.Annotations == nil {
+				srv.Annotations = map[string]string{}
+			}
+			srv.Annotations[ServiceSharesAnnotation] = strings.Join(shares, ",")
+		} else {
+			delete(srv.Annotations, ServiceSharesAnnotation)
+		}
+
+		_, err = s.kubeClient.Kubectl.CoreV1().Secrets(namespace).Update(ctx, srv, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// WithShare returns the sorted namespaces of a service's shares, with the target namespace
+// added or removed.
+func WithShare(shares []string, target string, shared bool) []string {
+	result := []string{}
+	for _, namespace := range shares {
+		if namespace != target {
+			result = append(result, namespace)
+		}
+	}
+	if shared {
+		result = append(result, target)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// serviceShares parses the value of the ServiceSharesAnnotation.
+func serviceShares(annotation string) []string {
+	shares := []string{}
+	for _, namespace := range strings.Split(annotation, ",") {
+		if namespace != "" {
+			shares = append(shares, namespace)
+		}
+	}
+	return shares
+}
@@ -0,0 +1,25 @@
+package services_test
+
+import (
+	"github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithShare", func() {
+	It("adds the namespace once, keeping the shares sorted", func() {
+		shares := services.WithShare([]string{"workspace"}, "other", true)
+		Expect(shares).To(Equal([]string{"other", "workspace"}))
+
+		shares = services.WithShare(shares, "other", true)
+		Expect(shares).To(Equal([]string{"other", "workspace"}))
+	})
+
+	It("removes the namespace", func() {
+		shares := services.WithShare([]string{"other", "workspace"}, "other", false)
+		Expect(shares).To(Equal([]string{"workspace"}))
+
+		shares = services.WithShare(shares, "workspace", false)
+		Expect(shares).To(BeEmpty())
+	})
+})
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/yaml"
+)
+
+// Update changes the settings of the service instance, and deploys it with the chart
+// version. An empty version keeps the deployed chart. The new settings are merged with the
+// settings chosen before, and are expected to be validated by the caller. The returned
+// change reports the chart versions and the difference of the helm values. A dry run only
+// computes the change.
+func (s *ServiceClient) Update(ctx context.Context, namespace, name string, settings models.AppSettings, chartVersion string, catalogService models.CatalogService, dryRun bool) (*models.ServiceUpdateResponse, error) {
+	logger := requestctx.Logger(ctx)
+	service := serviceResourceName(name)
+	ref := models.NewAppRef(name, namespace)
+
+	srv, err := s.kubeClient.GetSecret(ctx, namespace, service)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the service instance")
+	}
+
+	release, err := helm.ServiceRelease(s.kubeClient, logger, ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the service helm release")
+	}
+
+	deployedVersion := chartVersionOf(release)
+	if chartVersion == "" {
+		chartVersion = deployedVersion
+	}
+
+	merged := models.AppSettings{}
+	for key, value := range srv.Data {
+		merged[key] = string(value)
+	}
+	for key, value := range settings {
+		merged[key] = value
+	}
+
+	values, err := MergeSettings(catalogService.Values, merged, catalogService.Settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "error merging the service settings")
+	}
+
+	values, err = KeepCredentials(values, release.Config, catalogService.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := valuesDiff(release.Config, values)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &models.ServiceUpdateResponse{
+		DryRun:               dryRun,
+		PreviousChartVersion: deployedVersion,
+		ChartVersion:         chartVersion,
+		Diff:                 diff,
+	}
+	if dryRun {
+		return change, nil
+	}
+
+	err = helm.DeployService(logger,
+		helm.ServiceParameters{
+			AppRef:     ref,
+			Context:    ctx,
+			Cluster:    s.kubeClient,
+			Chart:      catalogService.HelmChart,
+			Version:    chartVersion,
+			Repository: catalogService.HelmRepo.URL,
+			Values:     values,
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "error deploying service helm chart")
+	}
+
+	// Record the new settings. The version of the catalog service is only known when
+	// deploying the chart of the catalog service.
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		srv, err := s.kubeClient.GetSecret(ctx, namespace, service)
+		if err != nil {
+			return err
+		}
+
+		srv.Data = map[string][]byte{}
+		for key, value := range merged {
+			srv.Data[key] = []byte(value)
+		}
+		if chartVersion == catalogService.ChartVersion {
+			srv.Labels[CatalogServiceVersionLabelKey] = catalogService.AppVersion
+		}
+
+		_, err = s.kubeClient.Kubectl.CoreV1().Secrets(namespace).Update(ctx, srv, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error saving the service settings")
+	}
+
+	return change, nil
+}
+
+// chartVersionOf returns the version of the chart deployed by the release.
+func chartVersionOf(release *helmrelease.Release) string {
+	if release == nil || release.Chart == nil || release.Chart.Metadata == nil {
+		return ""
+	}
+	return release.Chart.Metadata.Version
+}
+
+// NewerChart returns true if the offered chart version is newer than the deployed one.
+// Versions which are no semantic versions are considered newer when they differ.
+func NewerChart(deployed, offered string) bool {
+	if deployed == "" || offered == "" {
+		return false
+	}
+
+	deployedVersion, err := semver.NewVersion(deployed)
+	if err != nil {
+		return deployed != offered
+	}
+	offeredVersion, err := semver.NewVersion(offered)
+	if err != nil {
+		return deployed != offered
+	}
+
+	return offeredVersion.GreaterThan(deployedVersion)
+}
+
+// valuesDiff returns the line-based difference between the values of the release and the
+// new values, in unified diff format. Both are normalized to sorted YAML first.
+func valuesDiff(current map[string]interface{}, values string) (string, error) {
+	before := ""
+	if len(current) > 0 {
+		rendered, err := yaml.Marshal(current)
+		if err != nil {
+			return "", errors.Wrap(err, "rendering the current service values")
+		}
+		before = string(rendered)
+	}
+
+	after := ""
+	desired := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(values), &desired); err != nil {
+		return "", errors.Wrap(err, "parsing the service values")
+	}
+	if len(desired) > 0 {
+		rendered, err := yaml.Marshal(desired)
+		if err != nil {
+			return "", errors.Wrap(err, "rendering the service values")
+		}
+		after = string(rendered)
+	}
+
+	return LineDiff(before, after), nil
+}
+
+// LineDiff returns the differences between the two texts, line by line. Removed lines are
+// prefixed with `-`, added lines with `+`, and unchanged lines with a space. The result is
+// empty when the texts are the same.
+func LineDiff(before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+	if before == "" {
+		a = []string{}
+	}
+	if after == "" {
+		b = []string{}
+	}
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var result strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			result.WriteString(" " + a[i] + "\n")
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			result.WriteString("-" + a[i] + "\n")
+			i++
+		default:
+			result.WriteString("+" + b[j] + "\n")
+			j++
+		}
+	}
+
+	return result.String()
+}
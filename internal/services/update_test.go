@@ -0,0 +1,40 @@
+package services_test
+
+import (
+	"github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewerChart", func() {
+	It("compares semantic versions", func() {
+		Expect(services.NewerChart("1.2.0", "1.10.0")).To(BeTrue())
+		Expect(services.NewerChart("1.10.0", "1.2.0")).To(BeFalse())
+		Expect(services.NewerChart("1.2.0", "1.2.0")).To(BeFalse())
+	})
+
+	It("treats different non-semantic versions as newer", func() {
+		Expect(services.NewerChart("latest", "stable")).To(BeTrue())
+		Expect(services.NewerChart("stable", "stable")).To(BeFalse())
+	})
+
+	It("ignores unknown versions", func() {
+		Expect(services.NewerChart("", "1.0.0")).To(BeFalse())
+		Expect(services.NewerChart("1.0.0", "")).To(BeFalse())
+	})
+})
+
+var _ = Describe("LineDiff", func() {
+	It("is empty for the same texts", func() {
+		Expect(services.LineDiff("a: 1\n", "a: 1\n")).To(BeEmpty())
+	})
+
+	It("marks removed and added lines", func() {
+		diff := services.LineDiff("a: 1\nb: 2\nc: 3\n", "a: 1\nb: 4\nc: 3\nd: 5\n")
+		Expect(diff).To(Equal(" a: 1\n-b: 2\n+b: 4\n c: 3\n+d: 5\n"))
+	})
+
+	It("adds all lines of new values", func() {
+		Expect(services.LineDiff("", "a: 1\nb: 2\n")).To(Equal("+a: 1\n+b: 2\n"))
+	})
+})
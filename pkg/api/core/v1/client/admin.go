@@ -0,0 +1,82 @@
+package client
+
+import (
+	"encoding/json"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ImageGC garbage collects the application images in the Epinio registry
+func (c *Client) ImageGC(req models.ImageGCRequest) (models.ImageGCResponse, error) {
+	resp := models.ImageGCResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("ImageGC"), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// BlobPrune prunes the orphaned application source blobs from the S3 storage
+func (c *Client) BlobPrune(req models.BlobPruneRequest) (models.BlobPruneResponse, error) {
+	resp := models.BlobPruneResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("BlobPrune"), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// CatalogServiceAdd adds a service to the catalog
+func (c *Client) CatalogServiceAdd(req models.CatalogServiceRequest) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post(api.Routes.Path("CatalogServiceAdd"), string(b))
+	return err
+}
+
+// CatalogServiceUpdate replaces a service of the catalog
+func (c *Client) CatalogServiceUpdate(req models.CatalogServiceRequest, name string) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(api.Routes.Path("CatalogServiceUpdate", name), string(b))
+	return err
+}
+
+// CatalogServiceDelete removes a service from the catalog
+func (c *Client) CatalogServiceDelete(name string) error {
+	_, err := c.delete(api.Routes.Path("CatalogServiceDelete", name))
+	return err
+}
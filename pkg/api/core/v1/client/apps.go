@@ -311,11 +311,37 @@ func (c *Client) AppValidateCV(namespace string, name string) (models.Response,
 	return resp, nil
 }
 
+// AppWebhook returns the information needed to configure a git webhook for an app
+func (c *Client) AppWebhook(namespace string, name string) (models.AppWebhookResponse, error) {
+	resp := models.AppWebhookResponse{}
+
+	data, err := c.get(api.Routes.Path("AppWebhook", namespace, name))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, errors.Wrap(err, "response body is not JSON")
+	}
+
+	resp.URL = fmt.Sprintf("%s%s/%s", c.Settings.API, api.HookRoot, api.HookRoutes.Path("AppGitPush", namespace, name))
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
 // AppImportGit asks the server to import a git repo and put in into the blob store
 func (c *Client) AppImportGit(app models.AppRef, gitRef models.GitRef) (*models.ImportGitResponse, error) {
 	data := url.Values{}
 	data.Set("giturl", gitRef.URL)
 	data.Set("gitrev", gitRef.Revision)
+	if gitRef.Credentials != "" {
+		data.Set("gitcredentials", gitRef.Credentials)
+	}
+	if gitRef.Submodules {
+		data.Set("gitsubmodules", "true")
+	}
 
 	url := fmt.Sprintf("%s%s/%s", c.Settings.API, api.Root, api.Routes.Path("AppImportGit", app.Namespace, app.Name))
 	request, err := http.NewRequest("POST", url, strings.NewReader(data.Encode()))
@@ -556,35 +582,40 @@ func (c *Client) AppRunning(app models.AppRef) (models.Response, error) {
 }
 
 func (c *Client) AppExec(namespace string, appName, instance string, tty kubectlterm.TTY) error {
-	endpoint := fmt.Sprintf("%s%s/%s",
-		c.Settings.API, api.WsRoot, api.WsRoutes.Path("AppExec", namespace, appName))
-
-	upgradeRoundTripper := NewUpgrader(spdy.RoundTripperConfig{
-		TLS:        http.DefaultTransport.(*http.Transport).TLSClientConfig, // See `ExtendLocalTrust`
-		PingPeriod: time.Second * 5,
-	})
+	query := url.Values{}
+	if instance != "" {
+		query.Add("instance", instance)
+	}
 
-	execURL, err := url.Parse(endpoint)
+	exec, err := c.appExecutor("AppExec", namespace, appName, query)
 	if err != nil {
 		return err
 	}
 
-	if err := c.addAuthTokenToURL(execURL); err != nil {
-		return err
-	}
+	return streamTTY(exec, tty)
+}
 
+// AppDebug attaches to a new debug container running the image in an instance of the
+// application. An empty image is the default image of the server.
+func (c *Client) AppDebug(namespace string, appName, instance, image string, tty kubectlterm.TTY) error {
+	query := url.Values{}
 	if instance != "" {
-		values := execURL.Query()
-		values.Add("instance", instance)
-		execURL.RawQuery = values.Encode()
+		query.Add("instance", instance)
+	}
+	if image != "" {
+		query.Add("image", image)
 	}
 
-	// upgradeRoundTripper implements both interfaces, Roundtripper and Upgrader
-	exec, err := remotecommand.NewSPDYExecutorForTransports(upgradeRoundTripper, upgradeRoundTripper, "GET", execURL)
+	exec, err := c.appExecutor("AppDebug", namespace, appName, query)
 	if err != nil {
 		return err
 	}
 
+	return streamTTY(exec, tty)
+}
+
+// streamTTY streams the terminal over the executor.
+func streamTTY(exec remotecommand.Executor, tty kubectlterm.TTY) error {
 	fn := func() error {
 		options := remotecommand.StreamOptions{
 			Stdin:             tty.In,
@@ -600,6 +631,74 @@ func (c *Client) AppExec(namespace string, appName, instance string, tty kubectl
 	return tty.Safe(fn)
 }
 
+// ExecOpts are the command to run in an application instance, and its streams. Stdin is
+// optional.
+type ExecOpts struct {
+	Command []string
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// AppExecCommand runs the command in an instance of the application, without TTY. A
+// non-zero exit status of the command is returned as an exec.CodeExitError of client-go.
+func (c *Client) AppExecCommand(namespace string, appName, instance string, opts ExecOpts) error {
+	query := url.Values{}
+	if instance != "" {
+		query.Add("instance", instance)
+	}
+	for _, arg := range opts.Command {
+		query.Add("command", arg)
+	}
+	query.Add("tty", "false")
+	if opts.Stdin != nil {
+		query.Add("stdin", "true")
+	}
+
+	exec, err := c.appExecutor("AppExec", namespace, appName, query)
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Stderr: opts.Stderr,
+	})
+}
+
+// appExecutor returns the executor streaming over the named websocket route of the
+// application, i.e. AppExec or AppDebug.
+func (c *Client) appExecutor(route, namespace string, appName string, query url.Values) (remotecommand.Executor, error) {
+	endpoint := fmt.Sprintf("%s%s/%s",
+		c.Settings.API, api.WsRoot, api.WsRoutes.Path(route, namespace, appName))
+
+	upgradeRoundTripper := NewUpgrader(spdy.RoundTripperConfig{
+		TLS:        http.DefaultTransport.(*http.Transport).TLSClientConfig, // See `ExtendLocalTrust`
+		PingPeriod: time.Second * 5,
+	})
+
+	execURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.addAuthTokenToURL(execURL); err != nil {
+		return nil, err
+	}
+
+	values := execURL.Query()
+	for key, list := range query {
+		for _, value := range list {
+			values.Add(key, value)
+		}
+	}
+	execURL.RawQuery = values.Encode()
+
+	// upgradeRoundTripper implements both interfaces, Roundtripper and Upgrader
+	return remotecommand.NewSPDYExecutorForTransports(upgradeRoundTripper, upgradeRoundTripper, "GET", execURL)
+}
+
 type PortForwardOpts struct {
 	Address      []string
 	Ports        []string
@@ -651,6 +750,12 @@ func (c *Client) AppPortForward(namespace string, appName, instance string, opts
 		portForwardURL.RawQuery = values.Encode()
 	}
 
+	return c.forwardPorts(portForwardURL, opts)
+}
+
+// forwardPorts forwards the local traffic through the port-forward websocket endpoint at
+// the URL.
+func (c *Client) forwardPorts(portForwardURL *url.URL, opts *PortForwardOpts) error {
 	upgradeRoundTripper := NewUpgrader(spdy.RoundTripperConfig{
 		TLS:        http.DefaultTransport.(*http.Transport).TLSClientConfig, // See `ExtendLocalTrust`
 		PingPeriod: time.Second * 5,
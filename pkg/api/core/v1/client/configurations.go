@@ -146,8 +146,8 @@ func (c *Client) ConfigurationCreate(req models.ConfigurationCreateRequest, name
 }
 
 // ConfigurationUpdate updates a configuration by invoking the associated API endpoint
-func (c *Client) ConfigurationUpdate(req models.ConfigurationUpdateRequest, namespace, name string) (models.Response, error) {
-	resp := models.Response{}
+func (c *Client) ConfigurationUpdate(req models.ConfigurationUpdateRequest, namespace, name string) (models.ConfigurationUpdateResponse, error) {
+	resp := models.ConfigurationUpdateResponse{}
 
 	c.log.V(5).WithValues("request", req, "namespace", namespace, "configuration", name).Info("requesting ConfigurationUpdate")
 
@@ -103,3 +103,62 @@ func (c *Client) EnvMatch(namespace string, appName string, prefix string) (mode
 
 	return resp, nil
 }
+
+// BuildEnvList returns a map of all build-only env vars for an app
+func (c *Client) BuildEnvList(namespace string, appName string) (models.EnvVariableMap, error) {
+	var resp models.EnvVariableMap
+
+	data, err := c.get(api.Routes.Path("BuildEnvList", namespace, appName))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// BuildEnvSet set build-only env vars for an app
+func (c *Client) BuildEnvSet(req models.EnvVariableMap, namespace string, appName string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("BuildEnvSet", namespace, appName), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, errors.Wrap(err, "response body is not JSON")
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// BuildEnvUnset removes a build-only env var
+func (c *Client) BuildEnvUnset(namespace string, appName string, envName string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.delete(api.Routes.Path("BuildEnvUnset", namespace, appName, envName))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
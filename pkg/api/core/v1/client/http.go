@@ -59,6 +59,10 @@ func (c *Client) patch(endpoint string, data string) ([]byte, error) {
 	return c.do(endpoint, "PATCH", data)
 }
 
+func (c *Client) put(endpoint string, data string) ([]byte, error) {
+	return c.do(endpoint, "PUT", data)
+}
+
 func (c *Client) delete(endpoint string) ([]byte, error) {
 	return c.do(endpoint, "DELETE", "")
 }
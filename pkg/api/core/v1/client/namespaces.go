@@ -2,9 +2,15 @@ package client
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 
 	api "github.com/epinio/epinio/internal/api/v1"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
 )
 
 // NamespaceCreate creates a namespace
@@ -30,6 +36,70 @@ func (c *Client) NamespaceCreate(req models.NamespaceCreateRequest) (models.Resp
 	return resp, nil
 }
 
+// NamespaceQuotaUpdate replaces the quota of a namespace
+func (c *Client) NamespaceQuotaUpdate(quota models.NamespaceQuota, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(quota)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.put(api.Routes.Path("NamespaceQuotaUpdate", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceMemberAdd gives a user access to a namespace
+func (c *Client) NamespaceMemberAdd(req models.NamespaceMemberRequest, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("NamespaceMemberAdd", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceMemberRemove removes the access of a user to a namespace
+func (c *Client) NamespaceMemberRemove(namespace, username string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.delete(api.Routes.Path("NamespaceMemberRemove", namespace, username))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
 // NamespaceDelete deletes a namespace
 func (c *Client) NamespaceDelete(namespace string) (models.Response, error) {
 	resp := models.Response{}
@@ -101,3 +171,53 @@ func (c *Client) Namespaces() (models.NamespaceList, error) {
 
 	return resp, nil
 }
+
+// NamespaceEvents streams the events of the namespace, calling the callback for each,
+// until the server closes the connection.
+func (c *Client) NamespaceEvents(namespace string, callback func(models.Event)) error {
+	token, err := c.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("authtoken", token)
+
+	endpoint := api.WsRoutes.Path("NamespaceEvents", namespace)
+	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
+	webSocketConn, resp, err := websocket.DefaultDialer.Dial(websocketURL, http.Header{})
+	if err != nil {
+		// Report detailed error found in the server response
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			bodyBytes, errBody := io.ReadAll(resp.Body)
+
+			if errBody != nil {
+				return errBody
+			}
+
+			return formatError(bodyBytes, resp)
+		}
+
+		// Report the dialer error if response claimed to be OK
+		return errors.Wrap(err, fmt.Sprintf("Failed to connect to websockets endpoint. Response was = %+v\nThe error is", resp))
+	}
+	defer webSocketConn.Close()
+
+	for {
+		_, message, err := webSocketConn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return errors.Wrap(err, "error reading event")
+		}
+
+		var event models.Event
+		if err := json.Unmarshal(message, &event); err != nil {
+			return errors.Wrap(err, "error parsing event")
+		}
+
+		callback(event)
+	}
+}
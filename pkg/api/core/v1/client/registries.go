@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RegistryLogins returns the container registries the namespace has pull credentials for
+func (c *Client) RegistryLogins(namespace string) (models.RegistryLoginList, error) {
+	resp := models.RegistryLoginList{}
+
+	data, err := c.get(api.Routes.Path("RegistryLogins", namespace))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// RegistryLogin stores the pull credentials for a container registry in the namespace
+func (c *Client) RegistryLogin(namespace string, req models.RegistryLoginRequest) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("RegistryLogin", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// RegistryLogout removes the pull credentials for a container registry from the namespace
+func (c *Client) RegistryLogout(namespace, registryURL string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.delete(api.Routes.Path("RegistryLogout", namespace, registryURL))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
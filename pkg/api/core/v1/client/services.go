@@ -3,11 +3,18 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/avast/retry-go"
 	"github.com/pkg/errors"
 
+	"github.com/epinio/epinio/helpers"
 	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
@@ -87,6 +94,171 @@ func (c *Client) ServiceCreate(req *models.ServiceCreateRequest, namespace strin
 	return err
 }
 
+// ServiceUpdate changes the settings of a service, and/or upgrades it to another chart version
+func (c *Client) ServiceUpdate(req models.ServiceUpdateRequest, namespace, name string) (models.ServiceUpdateResponse, error) {
+	resp := models.ServiceUpdateResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.patch(api.Routes.Path("ServiceUpdate", namespace, name), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ServiceBackup backs up the data of a service
+func (c *Client) ServiceBackup(namespace, name string) (models.ServiceBackup, error) {
+	resp := models.ServiceBackup{}
+
+	data, err := c.post(api.Routes.Path("ServiceBackup", namespace, name), "")
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ServiceBackups returns the backups of a service
+func (c *Client) ServiceBackups(namespace, name string) (models.ServiceBackupList, error) {
+	resp := models.ServiceBackupList{}
+
+	data, err := c.get(api.Routes.Path("ServiceBackups", namespace, name))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ServiceRestore restores the data of a service from a backup
+func (c *Client) ServiceRestore(namespace, name, backupID string) error {
+	_, err := c.post(api.Routes.Path("ServiceRestore", namespace, name, backupID), "")
+	return err
+}
+
+// ServiceBackupSchedule sets the schedule of the backups of a service
+func (c *Client) ServiceBackupSchedule(req models.ServiceBackupScheduleRequest, namespace, name string) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.put(api.Routes.Path("ServiceBackupSchedule", namespace, name), string(b))
+	return err
+}
+
+// ServiceReady waits for the provisioning of a service to complete
+func (c *Client) ServiceReady(namespace, name string) error {
+	details := c.log.V(1)
+
+	return retry.Do(
+		func() error {
+			_, err := c.get(api.Routes.Path("ServiceReady", namespace, name))
+			return err
+		},
+		retry.RetryIf(func(err error) bool {
+			// A failed provisioning, or an unknown service, is final.
+			if r, ok := err.(interface{ StatusCode() int }); ok {
+				return r.StatusCode() >= http.StatusInternalServerError
+			}
+			retry := helpers.Retryable(err.Error())
+
+			details.Info("ready error", "error", err.Error(), "retry", retry)
+			return retry
+		}),
+		retry.OnRetry(func(n uint, err error) {
+			details.WithValues(
+				"tries", fmt.Sprintf("%d/%d", n, duration.RetryMax),
+				"error", err.Error(),
+			).Info("Retrying ServiceReady")
+		}),
+		retry.Delay(time.Second),
+		retry.Attempts(duration.RetryMax),
+	)
+}
+
+// ServiceRotateCredentials rotates the credentials of a service
+func (c *Client) ServiceRotateCredentials(namespace, name string) (models.ServiceRotateCredentialsResponse, error) {
+	resp := models.ServiceRotateCredentialsResponse{}
+
+	data, err := c.post(api.Routes.Path("ServiceRotateCredentials", namespace, name), "")
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ServiceShare shares a service with another namespace
+func (c *Client) ServiceShare(req models.ServiceShareRequest, namespace, name string) (models.ServiceShareResponse, error) {
+	resp := models.ServiceShareResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("ServiceShare", namespace, name), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ServiceUnshare stops sharing a service with the target namespace
+func (c *Client) ServiceUnshare(namespace, name, target string) (models.ServiceUnshareResponse, error) {
+	resp := models.ServiceUnshareResponse{}
+
+	data, err := c.delete(api.Routes.Path("ServiceUnshare", namespace, name, target))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
 func (c *Client) ServiceShow(req *models.ServiceShowRequest, namespace string) (*models.Service, error) {
 	data, err := c.get(api.Routes.Path("ServiceShow", namespace, req.Name))
 	if err != nil {
@@ -205,6 +377,70 @@ func (c *Client) ServiceApps(namespace string) (models.ServiceAppsResponse, erro
 	return resp, nil
 }
 
+// ServicePortForward will forward the local traffic to a running pod of the service. The
+// remote ports are the ports of the service's kubernetes services. Kubernetes forwards to
+// pods, so they are mapped to the ports of the pod first.
+func (c *Client) ServicePortForward(namespace, name string, opts *PortForwardOpts) error {
+	data, err := c.get(api.Routes.Path("ServicePortForwardTarget", namespace, name))
+	if err != nil {
+		return err
+	}
+
+	var target models.ServicePortForwardTargetResponse
+	if err := json.Unmarshal(data, &target); err != nil {
+		return errors.Wrap(err, "response body is not JSON")
+	}
+
+	c.log.V(1).Info("response decoded", "response", target)
+
+	endpoint := fmt.Sprintf("%s%s/%s", c.Settings.API, api.WsRoot, api.WsRoutes.Path("ServicePortForward", namespace, name))
+	portForwardURL, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := c.addAuthTokenToURL(portForwardURL); err != nil {
+		return err
+	}
+
+	// Forward to the pod the ports were mapped for.
+	values := portForwardURL.Query()
+	values.Add("instance", target.Instance)
+	portForwardURL.RawQuery = values.Encode()
+
+	podOpts := *opts
+	podOpts.Ports = podPorts(opts.Ports, target.Ports)
+
+	return c.forwardPorts(portForwardURL, &podOpts)
+}
+
+// podPorts maps the remote ports of the `[LOCAL_PORT:]REMOTE_PORT` specifications from
+// the service ports to the ports of the pod. The local ports stay the same, i.e. a
+// specification without local port listens on the service port. Remote ports unknown to
+// the mapping are kept as is, i.e. taken as ports of the pod.
+func podPorts(ports []string, mapping map[int32]int32) []string {
+	result := []string{}
+	for _, spec := range ports {
+		local, remote := spec, spec
+		if parts := strings.SplitN(spec, ":", 2); len(parts) == 2 {
+			local, remote = parts[0], parts[1]
+		}
+
+		port, err := strconv.ParseInt(remote, 10, 32)
+		if err != nil {
+			// Invalid specifications are reported by the port forwarding.
+			result = append(result, spec)
+			continue
+		}
+		if podPort, ok := mapping[int32(port)]; ok {
+			remote = strconv.Itoa(int(podPort))
+		}
+
+		result = append(result, local+":"+remote)
+	}
+	return result
+}
+
 func constructServiceBatchDeleteURL(namespace string, names []string) string {
 	q := url.Values{}
 	for _, c := range names {
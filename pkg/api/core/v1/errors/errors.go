@@ -188,3 +188,17 @@ func ConfigurationAlreadyKnown(configuration string) APIError {
 func ServiceAlreadyKnown(service string) APIError {
 	return NewConflictError("service", service)
 }
+
+/////////////////////////
+//
+// Forbidden (403) errors
+//
+/////////////////////////
+
+// NamespaceQuotaExceeded constructs an API error for when creating another resource of the
+// kind would exceed the quota of the namespace
+func NamespaceQuotaExceeded(namespace, kind string, max int) APIError {
+	msg := fmt.Sprintf("namespace '%s' quota exceeded", namespace)
+	return NewAPIError(msg, http.StatusForbidden).
+		WithDetailsf("the namespace is limited to %d %s", max, kind)
+}
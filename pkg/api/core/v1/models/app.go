@@ -11,6 +11,16 @@ const (
 
 	EpinioCreatedByAnnotation = "epinio.io/created-by"
 
+	// EpinioStageTimesAnnotation on the application holds, as JSON, the start times of
+	// its recent stages, by stage ID.
+	EpinioStageTimesAnnotation = "epinio.io/stage-times"
+
+	EpinioGitCredentialsAnnotation = "epinio.io/git-credentials"
+	EpinioGitSubmodulesAnnotation  = "epinio.io/git-submodules"
+
+	EpinioContainerDigestAnnotation = "epinio.io/container-digest"
+	EpinioContainerTrackAnnotation  = "epinio.io/container-track"
+
 	ApplicationCreated = "created"
 	ApplicationStaging = "staging"
 	ApplicationRunning = "running"
@@ -20,8 +30,10 @@ const (
 type ApplicationStatus string
 
 type GitRef struct {
-	Revision string `json:"revision,omitempty" yaml:"revision,omitempty"`
-	URL      string `json:"repository"         yaml:"url"`
+	Revision    string `json:"revision,omitempty"    yaml:"revision,omitempty"`
+	URL         string `json:"repository"            yaml:"url"`
+	Credentials string `json:"credentials,omitempty" yaml:"credentials,omitempty"` // Name of the configuration holding the git credentials
+	Submodules  bool   `json:"submodules,omitempty"  yaml:"submodules,omitempty"`  // Recursively clone submodules
 }
 
 // App has all the application's properties, for at rest (Configuration), and active (Workload).
@@ -144,6 +156,22 @@ func (ar *AppRef) MakeEnvSecretName() string {
 	return names.GenerateResourceName(ar.Name + "-env")
 }
 
+// MakeBuildEnvSecretName returns the name of the kube secret holding the
+// build-only environment variables of the referenced application. These
+// are used by staging only, and are not visible to the running workload.
+// The `_` separator cannot occur in application names, thus the name
+// cannot collide with the runtime environment secret of another app.
+func (ar *AppRef) MakeBuildEnvSecretName() string {
+	return names.GenerateResourceName(ar.Name + "_build-env")
+}
+
+// MakeWebhookSecretName returns the name of the kube secret holding the
+// key used to verify the signatures of git webhook requests for the
+// referenced application
+func (ar *AppRef) MakeWebhookSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-webhook")
+}
+
 // MakeConfigurationSecretName returns the name of the kube secret holding the
 // bound configurations of the referenced application
 func (ar *AppRef) MakeConfigurationSecretName() string {
@@ -0,0 +1,46 @@
+package models
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EventType is the type of an event of the namespace event stream.
+type EventType string
+
+// The types of events. The Name of an event is the name of the application, service,
+// or configuration it is about.
+const (
+	EventAppCreated           EventType = "app-created"
+	EventAppDeleted           EventType = "app-deleted"
+	EventStagingStarted       EventType = "staging-started"
+	EventStagingFinished      EventType = "staging-finished"    // Message is `succeeded` or `failed`
+	EventDeploymentProgress   EventType = "deployment-progress" // Ready of Desired instances
+	EventInstanceReady        EventType = "instance-ready"      // Instance is the pod
+	EventInstanceNotReady     EventType = "instance-not-ready"  // Instance is the pod
+	EventServiceReady         EventType = "service-ready"
+	EventServiceFailed        EventType = "service-failed"        // Message is the reason
+	EventConfigurationChanged EventType = "configuration-changed" // Message is `created`, `updated` or `deleted`
+)
+
+// The messages of events.
+const (
+	EventMessageSucceeded = "succeeded"
+	EventMessageFailed    = "failed"
+	EventMessageCreated   = "created"
+	EventMessageUpdated   = "updated"
+	EventMessageDeleted   = "deleted"
+)
+
+// Event is a change of the state of an application, its staging, a service, or a
+// configuration, as streamed by the namespace event endpoint.
+type Event struct {
+	Type      EventType   `json:"type"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Time      metav1.Time `json:"time"`
+	StageID   string      `json:"stageid,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	Ready     int32       `json:"ready,omitempty"`
+	Desired   int32       `json:"desired,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
@@ -8,6 +8,7 @@ package models
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/epinio/epinio/helpers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,6 +62,13 @@ type ConfigurationResponseList []ConfigurationResponse
 type ConfigurationCreateRequest struct {
 	Name string            `json:"name"`
 	Data map[string]string `json:"data"`
+	// External maps keys to references into the external secret stores known to the
+	// server, of the form `SCHEME:PATH`, for example `vault:secret/data/NAMESPACE/db#password`.
+	// Paths are restricted to the namespace of the configuration. The server resolves
+	// them into the values of the keys, and re-syncs them periodically.
+	External map[string]string `json:"external,omitempty"`
+	// Restart is the restart policy of the configuration. Defaults to `always`.
+	Restart ConfigurationRestartPolicy `json:"restart,omitempty"`
 }
 
 // ConfigurationUpdateRequest represents and contains the data needed to
@@ -68,6 +76,46 @@ type ConfigurationCreateRequest struct {
 type ConfigurationUpdateRequest struct {
 	Remove []string          `json:"remove,omitempty"`
 	Set    map[string]string `json:"edit,omitempty"`
+	// Restart changes the restart policy of the configuration, if set.
+	Restart ConfigurationRestartPolicy `json:"restart,omitempty"`
+}
+
+// ConfigurationUpdateResponse represents the server's response to a successful update or
+// replacement of a configuration. It lists the bound applications which were restarted.
+type ConfigurationUpdateResponse struct {
+	RestartedApps []string `json:"restarted_apps"`
+}
+
+// ConfigurationRestartPolicy determines how the applications bound to a configuration are
+// restarted when the configuration changes. The policy of a binding overrides the policy of
+// the configuration.
+type ConfigurationRestartPolicy string
+
+const (
+	// ConfigurationRestartAlways restarts the bound applications one after the other. It
+	// stops at the first failure. This is the default.
+	ConfigurationRestartAlways ConfigurationRestartPolicy = "always"
+	// ConfigurationRestartParallel restarts the bound applications at once.
+	ConfigurationRestartParallel ConfigurationRestartPolicy = "parallel"
+	// ConfigurationRestartRolling restarts the bound applications one after the other,
+	// each after the previous one is ready again, after all the others. It stops at the
+	// first failure.
+	ConfigurationRestartRolling ConfigurationRestartPolicy = "rolling"
+	// ConfigurationRestartNever does not restart the bound applications. They see the
+	// changed values in their mounted configuration files only.
+	ConfigurationRestartNever ConfigurationRestartPolicy = "never"
+)
+
+func (p ConfigurationRestartPolicy) String() string { return string(p) }
+
+// Validate returns an error for unknown policies. The empty policy is valid, and stands
+// for the default.
+func (p ConfigurationRestartPolicy) Validate() error {
+	switch p {
+	case "", ConfigurationRestartAlways, ConfigurationRestartParallel, ConfigurationRestartRolling, ConfigurationRestartNever:
+		return nil
+	}
+	return fmt.Errorf("unknown restart policy %s, expected one of always, parallel, rolling, never", p)
 }
 
 // ConfigurationReplaceRequest represents and contains the data needed to
@@ -87,6 +135,54 @@ type ConfigurationDeleteResponse struct {
 // BindRequest represents and contains the data needed to bind configurations to an application.
 type BindRequest struct {
 	Names []string `json:"names"`
+	// Restart is the restart policy of the bindings, overriding the policy of the
+	// configurations, if set.
+	Restart ConfigurationRestartPolicy `json:"restart,omitempty"`
+	// Env exposes the keys of the configurations as environment variables of the
+	// application, in addition to the mounted files, if set.
+	Env *ConfigurationEnvMapping `json:"env,omitempty"`
+}
+
+// envNameRegex matches the names kubernetes accepts for environment variables.
+var envNameRegex = regexp.MustCompile(`^[-._a-zA-Z][-._a-zA-Z0-9]*$`)
+
+// ConfigurationEnvMapping maps the keys of a bound configuration to environment variables
+// of the application. The variables reference the configuration's secret, their values
+// are not copied into the application's environment.
+type ConfigurationEnvMapping struct {
+	// Prefix is put before the names of all variables.
+	Prefix string `json:"prefix,omitempty"`
+	// Keys selects the keys to expose, mapped to the names of their variables. An empty
+	// name keeps the name of the key. Without keys all keys are exposed.
+	Keys map[string]string `json:"keys,omitempty"`
+}
+
+// Validate returns an error if the mapping would produce invalid variable names.
+func (m ConfigurationEnvMapping) Validate() error {
+	if m.Prefix != "" && !envNameRegex.MatchString(m.Prefix) {
+		return fmt.Errorf("bad environment variable prefix %s", m.Prefix)
+	}
+	for key, name := range m.Keys {
+		if key == "" {
+			return fmt.Errorf("cannot map an empty key to an environment variable")
+		}
+		if name == "" {
+			name = key
+		}
+		if !envNameRegex.MatchString(m.Prefix + name) {
+			return fmt.Errorf("bad environment variable name %s for key %s", m.Prefix+name, key)
+		}
+	}
+	return nil
+}
+
+// EnvName returns the name of the variable of the selected key.
+func (m ConfigurationEnvMapping) EnvName(key string) string {
+	name := m.Keys[key]
+	if name == "" {
+		name = key
+	}
+	return m.Prefix + name
 }
 
 // BindResponse represents the server's response to the successful binding of configurations to
@@ -106,10 +202,13 @@ type ApplicationManifest struct {
 }
 
 // ApplicationStage is the part of the manifest holding information
-// relevant to staging the application's sources. This is, currently,
-// only the reference to the Paketo builder image to use.
+// relevant to staging the application's sources. This is the reference
+// to the Paketo builder image to use, and the build-only environment.
+// The latter is made available to staging, but not to the running
+// application.
 type ApplicationStage struct {
-	Builder string `yaml:"builder,omitempty"`
+	Builder     string         `yaml:"builder,omitempty"`
+	Environment EnvVariableMap `yaml:"environment,omitempty"`
 }
 
 // ApplicationOrigin is the part of the manifest describing the origin of the application
@@ -123,6 +222,12 @@ type ApplicationOrigin struct {
 	Container string  `yaml:"container,omitempty" json:"container,omitempty"`
 	Git       *GitRef `yaml:"git,omitempty"       json:"git,omitempty"`
 	Path      string  `yaml:"path,omitempty"      json:"path,omitempty"`
+
+	// Container origins only. The digest the image tag resolved to at deploy time, and
+	// whether the tag is periodically checked for changes, redeploying the application
+	// when the digest changed.
+	Digest string `yaml:"digest,omitempty" json:"digest,omitempty"`
+	Track  bool   `yaml:"track,omitempty"  json:"track,omitempty"`
 }
 
 // manifest origin codes for `Kind`.
@@ -143,7 +248,10 @@ func (o *ApplicationOrigin) String() string {
 		}
 		return fmt.Sprintf("%s @ %s", o.Git.URL, o.Git.Revision)
 	case OriginContainer:
-		return o.Container
+		if o.Digest == "" {
+			return o.Container
+		}
+		return fmt.Sprintf("%s @ %s", o.Container, o.Digest)
 	default:
 		// Nothing
 	}
@@ -175,6 +283,15 @@ type ImportGitResponse struct {
 	BlobUID string `json:"blobuid,omitempty"`
 }
 
+// AppWebhookResponse contains the information needed to configure a git hosting
+// service to notify Epinio of pushes to the application's repository. The secret is the
+// key used to sign the notification payloads. The url is not provided by the server, as
+// only the client knows under which address the server is reachable.
+type AppWebhookResponse struct {
+	URL    string `json:"url,omitempty"`
+	Secret string `json:"secret"`
+}
+
 // UploadRequest is a multipart form
 
 // UploadResponse represents the server's response to a successful app sources upload
@@ -230,6 +347,11 @@ type ConfigurationShowResponse struct {
 	Type      string            `json:"type,omitempty"`     // User or service-created configuration
 	Origin    string            `json:"origin,omitempty"`   // Name of service it came from, if any
 	Siblings  []string          `json:"siblings,omitempty"` // Name of other configs from same service, if any
+
+	OriginNamespace string            `json:"origin_namespace,omitempty"` // Namespace of the service, if shared. Read-only then
+	External        map[string]string `json:"external,omitempty"`         // References of the keys taken from external secret stores
+
+	Restart ConfigurationRestartPolicy `json:"restart,omitempty"` // Restart policy of the configuration
 }
 
 // ConfigurationMatchResponse contains the list of names for matching configurations
@@ -252,7 +374,8 @@ type AuthTokenResponse struct {
 
 // NamespaceCreateRequest contains the name of the namespace that should be created
 type NamespaceCreateRequest struct {
-	Name string `json:"name,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Quota *NamespaceQuota `json:"quota,omitempty"`
 }
 
 // NamespacesMatchResponse contains the list of names for matching namespaces
@@ -278,9 +401,33 @@ type CatalogMatchResponse struct {
 	Names []string `json:"names,omitempty"`
 }
 
+// ServiceCreateRequest represents and contains the data needed to create a service
+// instance. The settings customize the deployment of the catalog service, and have to be
+// declared by it.
 type ServiceCreateRequest struct {
-	CatalogService string `json:"catalog_service,omitempty"`
-	Name           string `json:"name,omitempty"`
+	CatalogService string      `json:"catalog_service,omitempty"`
+	Name           string      `json:"name,omitempty"`
+	Settings       AppSettings `json:"settings,omitempty"`
+}
+
+// ServiceUpdateRequest represents and contains the data needed to change a service
+// instance. The settings are merged with the settings chosen before. With Upgrade set the
+// service is upgraded to the chart version, or to the chart of its catalog service, if
+// no version is specified. A dry run only reports the change.
+type ServiceUpdateRequest struct {
+	Settings     AppSettings `json:"settings,omitempty"`
+	Upgrade      bool        `json:"upgrade,omitempty"`
+	ChartVersion string      `json:"chart_version,omitempty"`
+	DryRun       bool        `json:"dryrun,omitempty"`
+}
+
+// ServiceUpdateResponse reports the change of a service instance, i.e. the chart versions
+// before and after, and the difference of the helm values, in unified diff format.
+type ServiceUpdateResponse struct {
+	DryRun               bool   `json:"dryrun,omitempty"`
+	PreviousChartVersion string `json:"previous_chart_version,omitempty"`
+	ChartVersion         string `json:"chart_version,omitempty"`
+	Diff                 string `json:"diff,omitempty"`
 }
 
 // CatalogService mostly matches github.com/epinio/application/api/v1 ServiceSpec
@@ -296,6 +443,66 @@ type CatalogService struct {
 	AppVersion       string   `json:"appVersion,omitempty"`
 	HelmRepo         HelmRepo `json:"helm_repo,omitempty"`
 	Values           string   `json:"values,omitempty"`
+
+	// Settings declares the fields of the values the user is allowed to set when
+	// creating a service instance.
+	Settings map[string]AppChartSetting `json:"settings,omitempty"`
+
+	// Backup and Restore declare the commands to save and restore the data of a
+	// service instance. Services without them cannot be backed up.
+	Backup  *ServiceHook `json:"backup,omitempty"`
+	Restore *ServiceHook `json:"restore,omitempty"`
+
+	// Credentials declares how the credentials of a service instance are rotated.
+	// Services without it cannot rotate their credentials.
+	Credentials *ServiceCredentials `json:"credentials,omitempty"`
+
+	// Namespaces restricts the catalog service to the listed namespaces. Without
+	// namespaces the catalog service is available in all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// AvailableIn returns true if service instances of the catalog service can be created in
+// the namespace.
+func (cs CatalogService) AvailableIn(namespace string) bool {
+	if len(cs.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range cs.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// CatalogServiceRequest represents the data of a catalog service to add or update. It is
+// the `Service` custom resource of the catalog, as applied with kubectl.
+type CatalogServiceRequest map[string]interface{}
+
+// ServiceHook declares a command executed in a pod of a service instance. The pod is
+// chosen from the pods of the service's helm release, narrowed by the label selector, if
+// any. A backup command writes the archive to stdout, a restore command reads it from
+// stdin.
+type ServiceHook struct {
+	Command   []string `json:"command"`
+	Container string   `json:"container,omitempty"`
+	Selector  string   `json:"selector,omitempty"`
+}
+
+// ServiceCredentials declares the credentials of a service instance. Rotation sets the
+// fields of the values, like `auth.password`, to new random values. The hook, if any,
+// changes the credentials in the running service before the new values are deployed. It
+// reads the new values from stdin, as JSON object keyed by field.
+type ServiceCredentials struct {
+	Fields []string     `json:"fields"`
+	Hook   *ServiceHook `json:"hook,omitempty"`
+}
+
+// ServiceRotateCredentialsResponse reports the applications restarted after the rotation
+// of the credentials of a service, to pick up the new values.
+type ServiceRotateCredentialsResponse struct {
+	RestartedApps []string `json:"restarted_apps"`
 }
 
 // HelmRepo matches github.com/epinio/application/api/v1 HelmRepo
@@ -308,6 +515,8 @@ type HelmRepo struct {
 // ServiceDeleteRequest represents and contains the data needed to delete a service
 type ServiceDeleteRequest struct {
 	Unbind bool `json:"unbind"`
+	// Force deletes shared services, removing their shares first.
+	Force bool `json:"force,omitempty"`
 }
 
 // ServiceDeleteResponse represents the server's response to a successful service deletion
@@ -333,9 +542,69 @@ type Service struct {
 	CatalogService          string        `json:"catalog_service,omitempty"`
 	CatalogServiceVersion   string        `json:"catalog_service_version,omitempty"`
 	Status                  ServiceStatus `json:"status,omitempty"`
+	Phase                   ServicePhase  `json:"phase,omitempty"`
 	BoundApps               []string      `json:"boundapps"`
 	ManagedByHelmController bool          `json:"hcmanaged"`
 	InternalRoutes          []string      `json:"internal_routes,omitempty"`
+	Settings                AppSettings   `json:"settings,omitempty"`
+
+	// ChartVersion is the version of the helm chart deployed for the service.
+	ChartVersion string `json:"chart_version,omitempty"`
+	// AvailableChartVersion is set when the catalog service offers a newer chart
+	// than the deployed one. See ServiceUpdateRequest for upgrading to it.
+	AvailableChartVersion string `json:"available_chart_version,omitempty"`
+
+	// BackupInterval and BackupKeep describe the scheduled backups of the service,
+	// if any. See ServiceBackupScheduleRequest.
+	BackupInterval string `json:"backup_interval,omitempty"`
+	BackupKeep     int    `json:"backup_keep,omitempty"`
+
+	// Notes are the notes of the service's helm release. Reason is the error of a
+	// failed provisioning, and Warnings are the latest warning events of the service's
+	// pods which are not ready.
+	Notes    string   `json:"notes,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Shares are the namespaces the service is shared with. See ServiceShareRequest.
+	Shares []string `json:"shares,omitempty"`
+}
+
+// ServiceShareRequest represents and contains the data needed to share a service instance
+// with another namespace. The service's configurations are projected into that namespace
+// as read-only configurations, to be bound to the applications there.
+type ServiceShareRequest struct {
+	Namespace string `json:"namespace"`
+}
+
+// ServiceShareResponse represents the server's response to a successful service share
+type ServiceShareResponse struct {
+	Configurations []string `json:"configurations"`
+}
+
+// ServiceUnshareResponse represents the server's response to a successful removal of a
+// service share. It lists the applications the projected configurations were unbound from.
+type ServiceUnshareResponse struct {
+	UnboundApps []string `json:"unboundapps"`
+}
+
+// ServiceBackup describes a backup of a service instance, stored in the S3 storage
+type ServiceBackup struct {
+	ID        string      `json:"id"`
+	Size      int64       `json:"size"`
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+}
+
+// ServiceBackupList is a list of service backups, newest first
+type ServiceBackupList []ServiceBackup
+
+// ServiceBackupScheduleRequest represents and contains the data needed to schedule the
+// backups of a service instance. The interval is a duration, like `24h`. An empty
+// interval disables the scheduled backups. After each scheduled backup only the `Keep`
+// newest backups are kept. Zero keeps all backups.
+type ServiceBackupScheduleRequest struct {
+	Interval string `json:"interval,omitempty"`
+	Keep     int    `json:"keep,omitempty"`
 }
 
 func (s Service) Namespace() string {
@@ -347,6 +616,13 @@ type ServiceStatus string
 // ServiceList represents a collection of service instances
 type ServiceList []Service
 
+// ServicePortForwardTargetResponse names the pod the ports of a service are forwarded to,
+// and maps the ports of the service's kubernetes services to the ports of that pod.
+type ServicePortForwardTargetResponse struct {
+	Instance string          `json:"instance"`
+	Ports    map[int32]int32 `json:"ports,omitempty"`
+}
+
 // ServiceMatchResponse contains the list of names for matching services
 type ServiceMatchResponse struct {
 	Names []string `json:"names,omitempty"`
@@ -358,6 +634,19 @@ const (
 	ServiceStatusUnknown  ServiceStatus = "unknown"
 )
 
+// ServicePhase is the phase of the provisioning of a service instance. Service instances
+// are provisioned asynchronously, after their creation.
+type ServicePhase string
+
+const (
+	ServicePhasePending    ServicePhase = "pending"
+	ServicePhaseInstalling ServicePhase = "installing"
+	ServicePhaseReady      ServicePhase = "ready"
+	ServicePhaseFailed     ServicePhase = "failed"
+)
+
+func (p ServicePhase) String() string { return string(p) }
+
 func NewServiceStatusFromHelmRelease(status helmrelease.Status) ServiceStatus {
 	switch status {
 	case helmrelease.StatusDeployed:
@@ -415,3 +704,74 @@ type AppChartList []AppChart
 type ChartMatchResponse struct {
 	Names []string `json:"names,omitempty"`
 }
+
+// RegistryLoginRequest represents and contains the data needed to store the pull
+// credentials for a container registry in a namespace
+type RegistryLoginRequest struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RegistryLogin describes the pull credentials stored for a container registry. The
+// password is not exposed.
+type RegistryLogin struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+}
+
+// RegistryLoginList is a collection of registry logins
+type RegistryLoginList []RegistryLogin
+
+// ImageGCRequest represents and contains the data needed to garbage collect the
+// application images in the Epinio registry. A Keep of zero uses the server's default.
+type ImageGCRequest struct {
+	Keep   int  `json:"keep,omitempty"`
+	DryRun bool `json:"dryrun,omitempty"`
+}
+
+// ImageGCResponse reports the application images deleted by a garbage collection, or to
+// be deleted, for a dry run. Reclaimed is the size in bytes of the layers no remaining
+// image refers to. The registry frees that space when it runs its own garbage collection.
+type ImageGCResponse struct {
+	DryRun    bool      `json:"dryrun,omitempty"`
+	Deleted   []GCImage `json:"deleted"`
+	Kept      int       `json:"kept"`
+	Reclaimed int64     `json:"reclaimed"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// GCImage references an application image in the Epinio registry
+type GCImage struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest"`
+}
+
+// BlobPruneRequest represents and contains the data needed to prune the orphaned
+// application source blobs from the S3 storage. OlderThan is a duration, like `24h`.
+// Only orphans older than it are deleted. Empty uses the server's default.
+type BlobPruneRequest struct {
+	OlderThan string `json:"olderthan,omitempty"`
+	DryRun    bool   `json:"dryrun,omitempty"`
+}
+
+// BlobPruneResponse reports the orphaned source blobs deleted from the S3 storage, or to
+// be deleted, for a dry run. Reclaimed is the size in bytes of these blobs.
+type BlobPruneResponse struct {
+	DryRun    bool     `json:"dryrun,omitempty"`
+	Deleted   []GCBlob `json:"deleted"`
+	Kept      int      `json:"kept"`
+	Reclaimed int64    `json:"reclaimed"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// GCBlob references an application source blob in the S3 storage. Application and
+// namespace are taken from the blob's meta data, if present.
+type GCBlob struct {
+	BlobUID      string      `json:"blobuid"`
+	App          string      `json:"app,omitempty"`
+	Namespace    string      `json:"namespace,omitempty"`
+	Size         int64       `json:"size"`
+	LastModified metav1.Time `json:"lastmodified"`
+}
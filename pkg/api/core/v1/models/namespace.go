@@ -3,9 +3,44 @@ package models
 // Namespace has all the namespace properties, i.e. name, app names, and configuration names
 // It is used in the CLI and API responses.
 type Namespace struct {
-	Meta           MetaLite `json:"meta,omitempty"`
-	Apps           []string `json:"apps,omitempty"`
-	Configurations []string `json:"configurations,omitempty"`
+	Meta           MetaLite        `json:"meta,omitempty"`
+	Apps           []string        `json:"apps,omitempty"`
+	Configurations []string        `json:"configurations,omitempty"`
+	Quota          *NamespaceQuota `json:"quota,omitempty"`
+	Usage          *NamespaceUsage `json:"usage,omitempty"`
+	Owners         []string        `json:"owners,omitempty"`
+	Members        []string        `json:"members,omitempty"` // Users with access, besides the owners
+}
+
+// The roles of the members of a namespace. Owners manage the members.
+const (
+	NamespaceRoleMember = "member"
+	NamespaceRoleOwner  = "owner"
+)
+
+// NamespaceMemberRequest gives the user access to the namespace, in the role. The role
+// defaults to member.
+type NamespaceMemberRequest struct {
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+}
+
+// NamespaceQuota limits what the namespace can hold. Zero values are not limited. Memory
+// and CPU are kubernetes quantities, for example `16Gi` and `8`, limiting the sum of the
+// requests of the namespace's pods.
+type NamespaceQuota struct {
+	MaxApps     int    `json:"max_apps,omitempty"`
+	MaxServices int    `json:"max_services,omitempty"`
+	Memory      string `json:"memory,omitempty"`
+	CPU         string `json:"cpu,omitempty"`
+}
+
+// NamespaceUsage is what the namespace holds, to compare against its quota.
+type NamespaceUsage struct {
+	Apps     int    `json:"apps"`
+	Services int    `json:"services"`
+	Memory   string `json:"memory,omitempty"`
+	CPU      string `json:"cpu,omitempty"`
 }
 
 // NamespaceList is a collection of namespaces
@@ -0,0 +1,37 @@
+package models
+
+// The kinds of the documents of a stack manifest.
+const (
+	StackKindApp           = "app"
+	StackKindConfiguration = "configuration"
+	StackKindService       = "service"
+)
+
+// StackManifest describes a system of applications, configurations and services, and
+// their bindings. It is read from a multi-document manifest, each document declaring its
+// kind. The configuration bindings are part of the applications, the service bindings
+// part of the services.
+type StackManifest struct {
+	Self           string // The file's location.
+	Apps           []ApplicationManifest
+	Configurations []ConfigurationManifest
+	Services       []ServiceManifest
+}
+
+// ConfigurationManifest describes a user configuration, in stack manifests and namespace
+// exports.
+type ConfigurationManifest struct {
+	Name     string                     `yaml:"name"`
+	Data     map[string]string          `yaml:"data,omitempty"`
+	External map[string]string          `yaml:"external,omitempty"`
+	Restart  ConfigurationRestartPolicy `yaml:"restart,omitempty"`
+}
+
+// ServiceManifest describes a service instance and the applications bound to it, in
+// stack manifests and namespace exports.
+type ServiceManifest struct {
+	Name           string      `yaml:"name"`
+	CatalogService string      `yaml:"catalog_service"`
+	Settings       AppSettings `yaml:"settings,omitempty"`
+	BoundApps      []string    `yaml:"bound_apps,omitempty"`
+}